@@ -16,13 +16,16 @@ package cmd
 
 import (
 	"context"
+	"time"
 
 	"github.com/pkg/errors"
 	"github.com/spf13/cobra"
 
 	"github.com/pulumi/pulumi/pkg/backend"
+	"github.com/pulumi/pulumi/pkg/backend/local"
 	"github.com/pulumi/pulumi/pkg/engine"
 	"github.com/pulumi/pulumi/pkg/util/cmdutil"
+	"github.com/pulumi/pulumi/pkg/util/contract"
 )
 
 func newDestroyCmd() *cobra.Command {
@@ -33,7 +36,11 @@ func newDestroyCmd() *cobra.Command {
 
 	// Flags for engine.UpdateOptions.
 	var analyzers []string
+	var cancelTimeout time.Duration
+	var continueOnError bool
 	var diffDisplay bool
+	var displayFilter string
+	var eventLogPath string
 	var parallel int
 	var showConfig bool
 	var showReplacementSteps bool
@@ -66,6 +73,11 @@ func newDestroyCmd() *cobra.Command {
 				return err
 			}
 
+			filter, err := backend.ParseDisplayFilter(displayFilter)
+			if err != nil {
+				return err
+			}
+
 			opts.Display = backend.DisplayOptions{
 				Color:                cmdutil.GetGlobalColorization(),
 				ShowConfig:           showConfig,
@@ -73,9 +85,19 @@ func newDestroyCmd() *cobra.Command {
 				ShowSameResources:    showSames,
 				IsInteractive:        interactive,
 				DiffDisplay:          diffDisplay,
+				Filter:               filter,
 				Debug:                debug,
 			}
 
+			if eventLogPath != "" {
+				eventLog, logErr := local.OpenEventLog(eventLogPath)
+				if logErr != nil {
+					return logErr
+				}
+				defer contract.IgnoreClose(eventLog)
+				opts.Display.EventLog = eventLog
+			}
+
 			s, err := requireStack(stack, false, opts.Display)
 			if err != nil {
 				return err
@@ -91,12 +113,13 @@ func newDestroyCmd() *cobra.Command {
 			}
 
 			opts.Engine = engine.UpdateOptions{
-				Analyzers: analyzers,
-				Parallel:  parallel,
-				Debug:     debug,
+				Analyzers:       analyzers,
+				Parallel:        parallel,
+				Debug:           debug,
+				ContinueOnError: continueOnError,
 			}
 
-			_, err = s.Destroy(commandContext(), proj, root, m, opts, cancellationScopes)
+			_, err = s.Destroy(commandContext(), proj, root, m, opts, newCancellationScopeSource(cancelTimeout))
 			if err == context.Canceled {
 				return errors.New("destroy cancelled")
 			}
@@ -118,9 +141,24 @@ func newDestroyCmd() *cobra.Command {
 	cmd.PersistentFlags().StringSliceVar(
 		&analyzers, "analyzer", []string{},
 		"Run one or more analyzers as part of this update")
+	cmd.PersistentFlags().DurationVar(
+		&cancelTimeout, "cancel-timeout", defaultCancelTimeout,
+		"How long to wait on ^C for resource operations in progress to complete before interrupting them (0 waits "+
+			"indefinitely)")
+	cmd.PersistentFlags().BoolVar(
+		&continueOnError, "continue-on-error", false,
+		"Keep deleting resources that no failed resource depends on after an error, and report all failures at the end")
 	cmd.PersistentFlags().BoolVar(
 		&diffDisplay, "diff", false,
 		"Display operation as a rich diff showing the overall change")
+	cmd.PersistentFlags().StringVar(
+		&displayFilter, "display-filter", "",
+		"Show only some resources: all, changes (hide unchanged resources) or failed; in a terminal, press f to "+
+			"change the filter, arrow keys and space to select and collapse components, and d to show diagnostics")
+	cmd.PersistentFlags().StringVar(
+		&eventLogPath, "event-log", "",
+		"Log the operation's events as newline-delimited JSON to a file, or to a Unix domain socket if one exists at "+
+			"the given path")
 	cmd.PersistentFlags().BoolVar(
 		&nonInteractive, "non-interactive", false, "Disable interactive mode")
 	cmd.PersistentFlags().IntVarP(
@@ -17,7 +17,6 @@ package cmd
 import (
 	"bufio"
 	"fmt"
-	"io"
 	"os"
 	"os/exec"
 	"path/filepath"
@@ -35,6 +34,7 @@ import (
 	"github.com/pulumi/pulumi/pkg/diag/colors"
 
 	"github.com/pulumi/pulumi/pkg/util/cmdutil"
+	"github.com/pulumi/pulumi/pkg/util/contract"
 	"github.com/spf13/cobra"
 
 	survey "gopkg.in/AlecAivazis/survey.v1"
@@ -54,9 +54,20 @@ func newNewCmd() *cobra.Command {
 	var dir string
 
 	cmd := &cobra.Command{
-		Use:   "new [template]",
+		Use:   "new [template|url]",
 		Short: "Create a new Pulumi project",
-		Args:  cmdutil.MaximumNArgs(1),
+		Long: "Create a new Pulumi project.\n" +
+			"\n" +
+			"The template may be the name of a template published by the Pulumi service or cached locally,\n" +
+			"or the location of a template outside of the service:\n" +
+			"\n" +
+			"  - a git repository URL, such as `https://github.com/acme/templates.git`, optionally\n" +
+			"    followed by `//<subdirectory>` and `#<branch, tag, or commit>`; prefix the URL with\n" +
+			"    `git::` to treat any other URL as a git repository\n" +
+			"  - a `.tar.gz` or `.tgz` tarball URL or local path, optionally followed by `//<subdirectory>`\n" +
+			"\n" +
+			"Templates retrieved from a URL must contain a `.pulumi.template.yaml` manifest.",
+		Args: cmdutil.MaximumNArgs(1),
 		Run: cmdutil.RunFunc(func(cmd *cobra.Command, args []string) error {
 			var err error
 
@@ -113,46 +124,36 @@ func newNewCmd() *cobra.Command {
 			// Get the selected template.
 			var templateName string
 			if len(args) > 0 {
-				templateName = strings.ToLower(args[0])
+				templateName = args[0]
+				if !workspace.IsTemplateURL(templateName) {
+					templateName = strings.ToLower(templateName)
+				}
 			} else {
 				if templateName, err = chooseTemplate(releases, offline, displayOpts); err != nil {
 					return err
 				}
 			}
 
-			// Download and install the template to the local template cache.
-			if !offline {
-				var tarball io.ReadCloser
-				source := releases.CloudURL()
-
-				if tarball, err = releases.DownloadTemplate(commandContext(), templateName, false, displayOpts); err != nil {
-					message := ""
-					// If the local template is available locally, provide a nicer error message.
-					if localTemplates, localErr := workspace.ListLocalTemplates(); localErr == nil && len(localTemplates) > 0 {
-						_, m := templateArrayToStringArrayAndMap(localTemplates)
-						if _, ok := m[templateName]; ok {
-							message = fmt.Sprintf(
-								"; rerun the command and pass --offline to use locally cached template '%s'",
-								templateName)
-						}
-					}
-
-					return errors.Wrapf(err, "downloading template '%s' from %s%s", templateName, source, message)
+			var template workspace.Template
+			if workspace.IsTemplateURL(templateName) {
+				// Retrieve the template from its git repository or tarball into a temporary directory.
+				if offline {
+					return errors.Errorf("template '%s' cannot be retrieved when --offline is specified", templateName)
 				}
-				if err = workspace.InstallTemplate(templateName, tarball); err != nil {
-					return errors.Wrapf(err, "installing template '%s' from %s", templateName, source)
+				var remote workspace.RemoteTemplate
+				if remote, err = workspace.RetrieveTemplate(templateName); err != nil {
+					return errors.Wrapf(err, "retrieving template '%s'", templateName)
 				}
+				defer func() { contract.IgnoreError(remote.Delete()) }()
+				template = remote.Template
+			} else if template, err = loadTemplate(releases, templateName, offline, displayOpts); err != nil {
+				return err
 			}
 
-			// Load the local template.
-			var template workspace.Template
-			if template, err = workspace.LoadLocalTemplate(templateName); err != nil {
-				return errors.Wrapf(err, "template '%s' not found", templateName)
-			}
-
-			// Do a dry run, if we're not forcing files to be overwritten.
-			if !force {
-				if err = template.CopyTemplateFilesDryRun(cwd); err != nil {
+			// Do a dry run, if we're not forcing files to be overwritten.  If the names of the template's files depend
+			// on the values it's expanded with, this has to wait until we've prompted for them.
+			if !force && !template.NeedsValuesForFileNames() {
+				if err = template.CopyTemplateFilesDryRun(cwd, workspace.TemplateValues{}); err != nil {
 					if os.IsNotExist(err) {
 						return errors.Wrapf(err, "template '%s' not found", templateName)
 					}
@@ -161,7 +162,7 @@ func newNewCmd() *cobra.Command {
 			}
 
 			// Show instructions, if we're going to show at least one prompt.
-			hasAtLeastOnePrompt := (name == "") || (description == "") || !generateOnly
+			hasAtLeastOnePrompt := (name == "") || (description == "") || !generateOnly || len(template.Variables) > 0
 			if !yes && hasAtLeastOnePrompt {
 				fmt.Println("This command will walk you through creating a new Pulumi project.")
 				fmt.Println()
@@ -181,8 +182,26 @@ func newNewCmd() *cobra.Command {
 				description = promptForValue(yes, "project description", defaultValue, nil, displayOpts)
 			}
 
+			// Prompt for the template's variables.
+			values := workspace.TemplateValues{
+				Project:     name,
+				Description: description,
+			}
+			if values.Variables, err = promptForTemplateVariables(yes, template.Variables, displayOpts); err != nil {
+				return err
+			}
+
+			if !force && template.NeedsValuesForFileNames() {
+				if err = template.CopyTemplateFilesDryRun(cwd, values); err != nil {
+					if os.IsNotExist(err) {
+						return errors.Wrapf(err, "template '%s' not found", templateName)
+					}
+					return err
+				}
+			}
+
 			// Actually copy the files.
-			if err = template.CopyTemplateFiles(cwd, force, name, description); err != nil {
+			if err = template.CopyTemplateFiles(cwd, force, values); err != nil {
 				if os.IsNotExist(err) {
 					return errors.Wrapf(err, "template '%s' not found", templateName)
 				}
@@ -224,8 +243,9 @@ func newNewCmd() *cobra.Command {
 
 					c := make(config.Map)
 					for _, k := range keys {
-						value := promptForValue(yes, k.String(), template.Config[k], nil, displayOpts)
-						c[k] = config.NewValue(value)
+						if c[k], err = promptForConfigValue(yes, stack, k, template.Config[k], displayOpts); err != nil {
+							return err
+						}
 					}
 
 					if err = saveConfig(stack.Name().StackName(), c); err != nil {
@@ -301,6 +321,43 @@ func newNewCmd() *cobra.Command {
 	return cmd
 }
 
+// loadTemplate downloads the named template and installs it to the local template cache (unless offline is
+// specified), and then loads it from the cache.
+func loadTemplate(
+	releases cloud.Backend, templateName string, offline bool, opts backend.DisplayOptions) (workspace.Template, error) {
+
+	if !offline {
+		source := releases.CloudURL()
+
+		tarball, err := releases.DownloadTemplate(commandContext(), templateName, false, opts)
+		if err != nil {
+			message := ""
+			// If the local template is available locally, provide a nicer error message.
+			if localTemplates, localErr := workspace.ListLocalTemplates(); localErr == nil && len(localTemplates) > 0 {
+				_, m := templateArrayToStringArrayAndMap(localTemplates)
+				if _, ok := m[templateName]; ok {
+					message = fmt.Sprintf(
+						"; rerun the command and pass --offline to use locally cached template '%s'",
+						templateName)
+				}
+			}
+
+			return workspace.Template{},
+				errors.Wrapf(err, "downloading template '%s' from %s%s", templateName, source, message)
+		}
+		if err = workspace.InstallTemplate(templateName, tarball); err != nil {
+			return workspace.Template{}, errors.Wrapf(err, "installing template '%s' from %s", templateName, source)
+		}
+	}
+
+	// Load the local template.
+	template, err := workspace.LoadLocalTemplate(templateName)
+	if err != nil {
+		return workspace.Template{}, errors.Wrapf(err, "template '%s' not found", templateName)
+	}
+	return template, nil
+}
+
 // getDevStackName returns the stack name suffixed with -dev.
 func getDevStackName(name string) string {
 	const suffix = "-dev"
@@ -343,10 +400,10 @@ func installDependencies() error {
 	// TODO[pulumi/pulumi#1307]: move to the language plugins so we don't have to hard code here.
 	var command string
 	var c *exec.Cmd
-	if strings.EqualFold(proj.Runtime, "nodejs") {
+	if strings.EqualFold(proj.Runtime.Name, "nodejs") {
 		command = "npm install"
 		c = exec.Command("npm", "install") // nolint: gas, intentionally launching with partial path
-	} else if strings.EqualFold(proj.Runtime, "python") {
+	} else if strings.EqualFold(proj.Runtime.Name, "python") {
 		command = "pip install -r requirements.txt"
 		c = exec.Command("pip", "install", "-r", "requirements.txt") // nolint: gas, intentionally launching with partial path
 	} else {
@@ -430,8 +487,8 @@ func chooseTemplate(backend cloud.Backend, offline bool, opts backend.DisplayOpt
 
 // promptForValue prompts the user for a value with a defaultValue preselected. Hitting enter accepts the
 // default. If yes is true, defaultValue is returned without prompting. isValidFn is an optional parameter;
-// when specified, it will be run to validate that value entered. An invalid value will result in an error
-// message followed by another prompt for the value.
+// when specified, it will be run to validate that value entered, or the default if none was. An invalid value
+// will result in an error message followed by another prompt for the value.
 func promptForValue(
 	yes bool, prompt string, defaultValue string,
 	isValidFn func(value string) bool, opts backend.DisplayOptions) string {
@@ -440,30 +497,127 @@ func promptForValue(
 		return defaultValue
 	}
 
+	var colorized string
+	if defaultValue == "" {
+		colorized = opts.Color.Colorize(
+			fmt.Sprintf("%s%s:%s ", colors.BrightCyan, prompt, colors.Reset))
+	} else {
+		colorized = opts.Color.Colorize(
+			fmt.Sprintf("%s%s: (%s)%s ", colors.BrightCyan, prompt, defaultValue, colors.Reset))
+	}
+
 	for {
-		if defaultValue == "" {
-			prompt = opts.Color.Colorize(
-				fmt.Sprintf("%s%s:%s ", colors.BrightCyan, prompt, colors.Reset))
-		} else {
-			prompt = opts.Color.Colorize(
-				fmt.Sprintf("%s%s: (%s)%s ", colors.BrightCyan, prompt, defaultValue, colors.Reset))
-		}
-		fmt.Print(prompt)
+		fmt.Print(colorized)
 
 		reader := bufio.NewReader(os.Stdin)
 		line, _ := reader.ReadString('\n')
 		value := strings.TrimSpace(line)
+		if value == "" {
+			value = defaultValue
+		}
 
-		if value != "" {
-			if isValidFn == nil || isValidFn(value) {
-				return value
+		if isValidFn == nil || isValidFn(value) {
+			return value
+		}
+
+		// The value is invalid, let the user know and try again
+		fmt.Printf("Sorry, '%s' is not a valid %s.\n", value, prompt)
+	}
+}
+
+// promptForTemplateVariables prompts for the values of a template's variables, in the order they are declared.
+func promptForTemplateVariables(
+	yes bool, variables []workspace.TemplateVariable, opts backend.DisplayOptions) (map[string]string, error) {
+
+	values := make(map[string]string)
+	for _, v := range variables {
+		prompt := v.Name
+		if v.Description != "" {
+			prompt = fmt.Sprintf("%s (%s)", v.Name, v.Description)
+		}
+		isValid := func(value string) bool {
+			return v.Validate(value) == nil
+		}
+
+		var value string
+		if v.Secret {
+			var err error
+			if value, err = promptForSecretValue(yes, prompt, v.Default, isValid, opts); err != nil {
+				return nil, err
 			}
+		} else {
+			value = promptForValue(yes, prompt, v.Default, isValid, opts)
+		}
 
-			// The value is invalid, let the user know and try again
-			fmt.Printf("Sorry, '%s' is not a valid %s.\n", value, prompt)
-			continue
+		// With --yes, the default is accepted without prompting, so check it here.
+		if yes {
+			if err := v.Validate(value); err != nil {
+				return nil, err
+			}
 		}
-		return defaultValue
+		values[v.Name] = value
+	}
+	return values, nil
+}
+
+// promptForConfigValue prompts for the value of one of a template's config keys, encrypting it if it is secret.
+func promptForConfigValue(yes bool, stack backend.Stack, key config.Key,
+	v workspace.TemplateConfigValue, opts backend.DisplayOptions) (config.Value, error) {
+
+	prompt := key.String()
+	if v.Description != "" {
+		prompt = fmt.Sprintf("%s (%s)", key.String(), v.Description)
+	}
+
+	if !v.Secret {
+		return config.NewValue(promptForValue(yes, prompt, v.Default, nil, opts)), nil
+	}
+
+	value, err := promptForSecretValue(yes, prompt, v.Default, nil, opts)
+	if err != nil {
+		return config.Value{}, err
+	}
+	c, err := backend.GetStackCrypter(stack)
+	if err != nil {
+		return config.Value{}, err
+	}
+	enc, err := c.EncryptValue(value)
+	if err != nil {
+		return config.Value{}, err
+	}
+	return config.NewSecureValue(enc), nil
+}
+
+// promptForSecretValue is like promptForValue, but doesn't echo the value that is entered, and doesn't show
+// the default value.
+func promptForSecretValue(
+	yes bool, prompt string, defaultValue string,
+	isValidFn func(value string) bool, opts backend.DisplayOptions) (string, error) {
+
+	if yes {
+		return defaultValue, nil
+	}
+
+	if defaultValue != "" {
+		prompt = fmt.Sprintf("%s (leave blank to use the default)", prompt)
+	}
+	prompt = opts.Color.Colorize(fmt.Sprintf("%s%s%s", colors.BrightCyan, prompt, colors.Reset))
+	for {
+		value, err := cmdutil.ReadConsoleNoEcho(prompt)
+		if err != nil {
+			return "", err
+		}
+		value = strings.TrimSpace(value)
+		if value == "" {
+			value = defaultValue
+		}
+
+		if isValidFn == nil || isValidFn(value) {
+			return value, nil
+		}
+
+		// The value is invalid, let the user know and try again
+		fmt.Println("Sorry, that is not a valid value.")
 	}
 }
 
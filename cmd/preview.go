@@ -15,12 +15,17 @@
 package cmd
 
 import (
+	"time"
+
 	"github.com/pkg/errors"
 	"github.com/spf13/cobra"
 
 	"github.com/pulumi/pulumi/pkg/backend"
+	"github.com/pulumi/pulumi/pkg/backend/local"
 	"github.com/pulumi/pulumi/pkg/engine"
+	"github.com/pulumi/pulumi/pkg/resource/deploy"
 	"github.com/pulumi/pulumi/pkg/util/cmdutil"
+	"github.com/pulumi/pulumi/pkg/util/contract"
 )
 
 func newPreviewCmd() *cobra.Command {
@@ -31,9 +36,16 @@ func newPreviewCmd() *cobra.Command {
 
 	// Flags for engine.UpdateOptions.
 	var analyzers []string
+	var cancelTimeout time.Duration
 	var diffDisplay bool
+	var displayFilter string
+	var eventLogPath string
+	var jsonDisplay bool
+	var jsonStream bool
 	var nonInteractive bool
 	var parallel int
+	var reportSpecs []string
+	var savePlanPath string
 	var showConfig bool
 	var showReplacementSteps bool
 	var showSames bool
@@ -52,10 +64,18 @@ func newPreviewCmd() *cobra.Command {
 			"operations must take place to achieve the desired state. No changes to the stack will\n" +
 			"actually take place.\n" +
 			"\n" +
+			"With `--save-plan`, the steps the preview computed are saved to a file. Passing that file to\n" +
+			"`pulumi update --plan` ensures that the update performs only those steps.\n" +
+			"\n" +
 			"The program to run is loaded from the project in the current directory. Use the `-C` or\n" +
 			"`--cwd` flag to use a different directory.",
 		Args: cmdutil.NoArgs,
 		Run: cmdutil.RunFunc(func(cmd *cobra.Command, args []string) error {
+			filter, err := backend.ParseDisplayFilter(displayFilter)
+			if err != nil {
+				return err
+			}
+
 			opts := backend.UpdateOptions{
 				Engine: engine.UpdateOptions{
 					Analyzers: analyzers,
@@ -67,12 +87,39 @@ func newPreviewCmd() *cobra.Command {
 					ShowConfig:           showConfig,
 					ShowReplacementSteps: showReplacementSteps,
 					ShowSameResources:    showSames,
-					IsInteractive:        isInteractive(nonInteractive),
+					IsInteractive:        isInteractive(nonInteractive) && !jsonDisplay && !jsonStream,
 					DiffDisplay:          diffDisplay,
+					JSONDisplay:          jsonDisplay || jsonStream,
+					JSONStream:           jsonStream,
+					Filter:               filter,
 					Debug:                debug,
 				},
 			}
 
+			if savePlanPath != "" {
+				opts.Engine.SavePlan = deploy.NewSavedPlan()
+			}
+
+			var eventLogs backend.EventLogs
+			if eventLogPath != "" {
+				eventLog, logErr := local.OpenEventLog(eventLogPath)
+				if logErr != nil {
+					return logErr
+				}
+				defer contract.IgnoreClose(eventLog)
+				eventLogs = append(eventLogs, eventLog)
+			}
+			reports, err := newReports(reportSpecs)
+			if err != nil {
+				return err
+			}
+			for _, report := range reports {
+				eventLogs = append(eventLogs, report)
+			}
+			if len(eventLogs) > 0 {
+				opts.Display.EventLog = eventLogs
+			}
+
 			s, err := requireStack(stack, true, opts.Display)
 			if err != nil {
 				return err
@@ -88,7 +135,13 @@ func newPreviewCmd() *cobra.Command {
 				return errors.Wrap(err, "gathering environment metadata")
 			}
 
-			changes, err := s.Preview(commandContext(), proj, root, m, opts, cancellationScopes)
+			changes, err := s.Preview(commandContext(), proj, root, m, opts, newCancellationScopeSource(cancelTimeout))
+			if reportErr := writeReports(reports); err == nil {
+				err = reportErr
+			}
+			if err == nil && savePlanPath != "" {
+				err = writePlan(savePlanPath, opts.Engine.SavePlan)
+			}
 			switch {
 			case err != nil:
 				return err
@@ -118,14 +171,39 @@ func newPreviewCmd() *cobra.Command {
 	cmd.PersistentFlags().StringSliceVar(
 		&analyzers, "analyzer", []string{},
 		"Run one or more analyzers as part of this update")
+	cmd.PersistentFlags().DurationVar(
+		&cancelTimeout, "cancel-timeout", defaultCancelTimeout,
+		"How long to wait on ^C for resource operations in progress to complete before interrupting them (0 waits "+
+			"indefinitely)")
 	cmd.PersistentFlags().BoolVar(
 		&diffDisplay, "diff", false,
 		"Display operation as a rich diff showing the overall change")
+	cmd.PersistentFlags().StringVar(
+		&displayFilter, "display-filter", "",
+		"Show only some resources: all, changes (hide unchanged resources) or failed; in a terminal, press f to "+
+			"change the filter, arrow keys and space to select and collapse components, and d to show diagnostics")
+	cmd.PersistentFlags().BoolVar(
+		&jsonDisplay, "json", false,
+		"Emit the operation's events as a single JSON document once it completes, instead of displaying them")
+	cmd.PersistentFlags().BoolVar(
+		&jsonStream, "json-stream", false,
+		"Emit the operation's events as newline-delimited JSON as they occur, instead of displaying them")
+	cmd.PersistentFlags().StringVar(
+		&eventLogPath, "event-log", "",
+		"Log the operation's events as newline-delimited JSON to a file, or to a Unix domain socket if one exists at "+
+			"the given path")
 	cmd.PersistentFlags().BoolVar(
 		&nonInteractive, "non-interactive", false, "Disable interactive mode")
 	cmd.PersistentFlags().IntVarP(
 		&parallel, "parallel", "p", 0,
 		"Allow P resource operations to run in parallel at once (<=1 for no parallelism)")
+	cmd.PersistentFlags().StringVar(
+		&savePlanPath, "save-plan", "",
+		"Save the steps computed by the preview to a plan file, which `pulumi update --plan` can be constrained to")
+	cmd.PersistentFlags().StringArrayVar(
+		&reportSpecs, "report", []string{},
+		"Write a summary of the preview to a file, given as <format>:<path> where format is markdown or junit; "+
+			"may be repeated")
 	cmd.PersistentFlags().BoolVar(
 		&showConfig, "show-config", false,
 		"Show configuration keys and variables")
@@ -129,6 +129,7 @@ func NewPulumiCmd() *cobra.Command {
 	cmd.AddCommand(newPreviewCmd())
 	cmd.AddCommand(newRefreshCmd())
 	cmd.AddCommand(newStackCmd())
+	cmd.AddCommand(newStateCmd())
 	cmd.AddCommand(newUpdateCmd())
 	cmd.AddCommand(newVersionCmd())
 	cmd.AddCommand(newWhoAmICmd())
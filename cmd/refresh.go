@@ -16,13 +16,16 @@ package cmd
 
 import (
 	"context"
+	"time"
 
 	"github.com/pkg/errors"
 	"github.com/spf13/cobra"
 
 	"github.com/pulumi/pulumi/pkg/backend"
+	"github.com/pulumi/pulumi/pkg/backend/local"
 	"github.com/pulumi/pulumi/pkg/engine"
 	"github.com/pulumi/pulumi/pkg/util/cmdutil"
+	"github.com/pulumi/pulumi/pkg/util/contract"
 )
 
 func newRefreshCmd() *cobra.Command {
@@ -32,12 +35,16 @@ func newRefreshCmd() *cobra.Command {
 
 	// Flags for engine.UpdateOptions.
 	var analyzers []string
+	var cancelTimeout time.Duration
 	var diffDisplay bool
+	var displayFilter string
+	var eventLogPath string
 	var parallel int
 	var showConfig bool
 	var showReplacementSteps bool
 	var showSames bool
 	var nonInteractive bool
+	var previewOnly bool
 	var skipPreview bool
 	var yes bool
 
@@ -51,19 +58,31 @@ func newRefreshCmd() *cobra.Command {
 			"the program text isn't updated accordingly, subsequent updates may still appear to be out of\n" +
 			"synch with respect to the cloud provider's source of truth.\n" +
 			"\n" +
+			"With `--preview-only`, the differences are displayed but not adopted, and the command fails if\n" +
+			"any resource has drifted from the stack's state.\n" +
+			"\n" +
 			"The program to run is loaded from the project in the current directory. Use the `-C` or\n" +
 			"`--cwd` flag to use a different directory.",
 		Args: cmdutil.NoArgs,
 		Run: cmdutil.RunFunc(func(cmd *cobra.Command, args []string) error {
 			interactive := isInteractive(nonInteractive)
-			if !interactive {
-				yes = true // auto-approve changes, since we cannot prompt.
+			if !interactive || previewOnly {
+				yes = true // auto-approve changes, since we cannot prompt (and won't make any when previewing).
+			}
+			if previewOnly && skipPreview {
+				return errors.New("--preview-only and --skip-preview cannot be used together")
 			}
 
 			opts, err := updateFlagsToOptions(interactive, skipPreview, yes)
 			if err != nil {
 				return err
 			}
+			opts.PreviewOnly = previewOnly
+
+			filter, err := backend.ParseDisplayFilter(displayFilter)
+			if err != nil {
+				return err
+			}
 
 			opts.Display = backend.DisplayOptions{
 				Color:                cmdutil.GetGlobalColorization(),
@@ -72,9 +91,19 @@ func newRefreshCmd() *cobra.Command {
 				ShowSameResources:    showSames,
 				IsInteractive:        interactive,
 				DiffDisplay:          diffDisplay,
+				Filter:               filter,
 				Debug:                debug,
 			}
 
+			if eventLogPath != "" {
+				eventLog, logErr := local.OpenEventLog(eventLogPath)
+				if logErr != nil {
+					return logErr
+				}
+				defer contract.IgnoreClose(eventLog)
+				opts.Display.EventLog = eventLog
+			}
+
 			s, err := requireStack(stack, true, opts.Display)
 			if err != nil {
 				return err
@@ -96,11 +125,17 @@ func newRefreshCmd() *cobra.Command {
 				Debug:     debug,
 			}
 
-			_, err = s.Refresh(commandContext(), proj, root, m, opts, cancellationScopes)
-			if err == context.Canceled {
+			changes, err := s.Refresh(commandContext(), proj, root, m, opts, newCancellationScopeSource(cancelTimeout))
+			switch {
+			case err == context.Canceled:
 				return errors.New("refresh cancelled")
+			case err != nil:
+				return err
+			case previewOnly && changes.HasChanges():
+				return errors.New("error: resources have drifted from the stack's state")
+			default:
+				return nil
 			}
-			return err
 		}),
 	}
 
@@ -119,14 +154,29 @@ func newRefreshCmd() *cobra.Command {
 	cmd.PersistentFlags().StringSliceVar(
 		&analyzers, "analyzer", nil,
 		"Run one or more analyzers as part of this update")
+	cmd.PersistentFlags().DurationVar(
+		&cancelTimeout, "cancel-timeout", defaultCancelTimeout,
+		"How long to wait on ^C for resource operations in progress to complete before interrupting them (0 waits "+
+			"indefinitely)")
 	cmd.PersistentFlags().BoolVar(
 		&diffDisplay, "diff", false,
 		"Display operation as a rich diff showing the overall change")
+	cmd.PersistentFlags().StringVar(
+		&displayFilter, "display-filter", "",
+		"Show only some resources: all, changes (hide unchanged resources) or failed; in a terminal, press f to "+
+			"change the filter, arrow keys and space to select and collapse components, and d to show diagnostics")
+	cmd.PersistentFlags().StringVar(
+		&eventLogPath, "event-log", "",
+		"Log the operation's events as newline-delimited JSON to a file, or to a Unix domain socket if one exists at "+
+			"the given path")
 	cmd.PersistentFlags().BoolVar(
 		&nonInteractive, "non-interactive", false, "Disable interactive mode")
 	cmd.PersistentFlags().IntVarP(
 		&parallel, "parallel", "p", 0,
 		"Allow P resource operations to run in parallel at once (<=1 for no parallelism)")
+	cmd.PersistentFlags().BoolVar(
+		&previewOnly, "preview-only", false,
+		"Only show the differences between the stack's state and the live resources, failing if there are any")
 	cmd.PersistentFlags().BoolVar(
 		&showReplacementSteps, "show-replacement-steps", false,
 		"Show detailed resource replacement creates and deletes instead of a single step")
@@ -0,0 +1,345 @@
+// Copyright 2016-2018, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/dustin/go-humanize"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+
+	"github.com/pulumi/pulumi/pkg/backend"
+	"github.com/pulumi/pulumi/pkg/diag/colors"
+	"github.com/pulumi/pulumi/pkg/engine"
+	"github.com/pulumi/pulumi/pkg/resource"
+	"github.com/pulumi/pulumi/pkg/resource/config"
+	"github.com/pulumi/pulumi/pkg/resource/deploy"
+	"github.com/pulumi/pulumi/pkg/resource/stack"
+	"github.com/pulumi/pulumi/pkg/util/cmdutil"
+)
+
+func newStackHistoryCmd() *cobra.Command {
+	var stackName string
+	var jsonOut bool
+	var pageSize int
+	var page int
+	var showDiff int
+
+	cmd := &cobra.Command{
+		Use:   "history",
+		Short: "Show the update history of a stack",
+		Long: "Show the update history of a stack.\n" +
+			"\n" +
+			"This command lists the updates, previews, refreshes and destroys that have been performed on a stack,\n" +
+			"newest first.  Use --page-size and --page to list a subset of them, and --json to emit them as JSON.\n" +
+			"\n" +
+			"With --show-diff, the resources of the deployment produced by the given version are instead compared\n" +
+			"with those of the version before it, and the differences are shown.",
+		Args: cmdutil.NoArgs,
+		Run: cmdutil.RunFunc(func(cmd *cobra.Command, args []string) error {
+			opts := backend.DisplayOptions{
+				Color: cmdutil.GetGlobalColorization(),
+			}
+
+			s, err := requireStack(stackName, false, opts)
+			if err != nil {
+				return err
+			}
+			updates, err := s.Backend().GetHistory(commandContext(), s.Name())
+			if err != nil {
+				return errors.Wrap(err, "getting history")
+			}
+
+			if showDiff != 0 {
+				return showUpdateDiff(updates, showDiff, opts)
+			}
+
+			if pageSize < 0 || page < 1 {
+				return errors.New("--page-size must not be negative and --page must be at least 1")
+			}
+			updates = pageUpdates(updates, pageSize, page)
+
+			if jsonOut {
+				return printUpdatesJSON(updates)
+			}
+			printUpdates(updates, opts)
+			return nil
+		}),
+	}
+
+	cmd.PersistentFlags().StringVarP(
+		&stackName, "stack", "s", "", "The name of the stack to operate on. Defaults to the current stack")
+	cmd.PersistentFlags().BoolVarP(
+		&jsonOut, "json", "j", false, "Emit the history as JSON")
+	cmd.PersistentFlags().IntVar(
+		&pageSize, "page-size", 0, "The number of updates to show per page; if 0, all updates are shown")
+	cmd.PersistentFlags().IntVar(
+		&page, "page", 1, "The page of updates to show, starting at 1 for the newest")
+	cmd.PersistentFlags().IntVar(
+		&showDiff, "show-diff", 0, "Show the changes to resources made by the update with the given version")
+
+	return cmd
+}
+
+// pageUpdates returns the given page of updates, where pages contain pageSize updates each.  If pageSize is 0, all
+// updates are returned.
+func pageUpdates(updates []backend.UpdateInfo, pageSize int, page int) []backend.UpdateInfo {
+	if pageSize == 0 {
+		return updates
+	}
+	start := (page - 1) * pageSize
+	if start >= len(updates) {
+		return nil
+	}
+	end := start + pageSize
+	if end > len(updates) {
+		end = len(updates)
+	}
+	return updates[start:end]
+}
+
+// updateInfoJSON is the shape of the JSON emitted for each update by `pulumi stack history --json`.
+type updateInfoJSON struct {
+	Version         int                    `json:"version"`
+	Kind            string                 `json:"kind"`
+	StartTime       string                 `json:"startTime"`
+	Message         string                 `json:"message"`
+	Environment     map[string]string      `json:"environment,omitempty"`
+	Config          map[string]configValue `json:"config,omitempty"`
+	Result          string                 `json:"result"`
+	EndTime         string                 `json:"endTime,omitempty"`
+	ResourceChanges map[string]int         `json:"resourceChanges,omitempty"`
+}
+
+// configValue is the JSON representation of a config value; secret values are never shown.
+type configValue struct {
+	Value  string `json:"value,omitempty"`
+	Secret bool   `json:"secret"`
+}
+
+func printUpdatesJSON(updates []backend.UpdateInfo) error {
+	result := make([]updateInfoJSON, len(updates))
+	for i, update := range updates {
+		info := updateInfoJSON{
+			Version:     update.Version,
+			Kind:        string(update.Kind),
+			StartTime:   time.Unix(update.StartTime, 0).UTC().Format(time.RFC3339),
+			Message:     update.Message,
+			Environment: update.Environment,
+			Result:      string(update.Result),
+		}
+		if update.EndTime != 0 {
+			info.EndTime = time.Unix(update.EndTime, 0).UTC().Format(time.RFC3339)
+		}
+		if len(update.Config) > 0 {
+			info.Config = make(map[string]configValue)
+			for k, v := range update.Config {
+				if v.Secure() {
+					info.Config[k.String()] = configValue{Secret: true}
+				} else {
+					value, err := v.Value(config.NewBlindingDecrypter())
+					if err != nil {
+						return err
+					}
+					info.Config[k.String()] = configValue{Value: value}
+				}
+			}
+		}
+		if len(update.ResourceChanges) > 0 {
+			info.ResourceChanges = make(map[string]int)
+			for op, count := range update.ResourceChanges {
+				info.ResourceChanges[string(op)] = count
+			}
+		}
+		result[i] = info
+	}
+
+	b, err := json.MarshalIndent(result, "", "    ")
+	if err != nil {
+		return err
+	}
+	fmt.Println(string(b))
+	return nil
+}
+
+func printUpdates(updates []backend.UpdateInfo, opts backend.DisplayOptions) {
+	if len(updates) == 0 {
+		fmt.Println("Stack has no updates")
+		return
+	}
+
+	fmt.Printf("%-8s %-8s %-12s %-16s %-10s %-16s %s\n",
+		"VERSION", "KIND", "RESULT", "STARTED", "DURATION", "CHANGES", "MESSAGE")
+	for _, update := range updates {
+		version := "n/a"
+		if update.Version != 0 {
+			version = strconv.Itoa(update.Version)
+		}
+		duration := "n/a"
+		if update.EndTime != 0 {
+			duration = (time.Duration(update.EndTime-update.StartTime) * time.Second).String()
+		}
+
+		// Only show the first line of the message, since the rest wouldn't fit in the table.
+		message := strings.SplitN(update.Message, "\n", 2)[0]
+
+		// Pad the changes column ourselves, since the color codes would throw off the format directive.
+		changes := formatResourceChanges(update.ResourceChanges)
+		if width := len(colors.Never.Colorize(changes)); width < 16 {
+			changes += strings.Repeat(" ", 16-width)
+		}
+
+		fmt.Printf("%-8s %-8s %-12s %-16s %-10s %s %s\n",
+			version, update.Kind, update.Result, humanize.Time(time.Unix(update.StartTime, 0)), duration,
+			opts.Color.Colorize(changes), message)
+	}
+}
+
+// formatResourceChanges summarizes the given changes as, for example, "+1 ~2 -3", omitting unchanged resources.
+func formatResourceChanges(changes engine.ResourceChanges) string {
+	var parts []string
+	for _, op := range deploy.StepOps {
+		if op == deploy.OpSame {
+			continue
+		}
+		if count := changes[op]; count > 0 {
+			parts = append(parts, fmt.Sprintf("%s%s%d%s", op.Color(), strings.TrimSpace(op.RawPrefix()), count,
+				colors.Reset))
+		}
+	}
+	if len(parts) == 0 {
+		return "none"
+	}
+	return strings.Join(parts, " ")
+}
+
+// showUpdateDiff prints the differences between the resources of the deployment produced by the update with the
+// given version and those of the version before it.
+func showUpdateDiff(updates []backend.UpdateInfo, version int, opts backend.DisplayOptions) error {
+	var old, new *deploy.Snapshot
+	found, foundOld := false, false
+	for _, update := range updates {
+		if update.Version != version && update.Version != version-1 {
+			continue
+		}
+		deployment, err := update.GetDeployment()
+		if err != nil {
+			return errors.Wrapf(err, "reading the deployment for version %d", update.Version)
+		} else if deployment == nil {
+			return errors.Errorf("the deployment for version %d is not available", update.Version)
+		}
+		snap, err := stack.DeserializeDeployment(deployment)
+		if err != nil {
+			return errors.Wrapf(err, "reading the deployment for version %d", update.Version)
+		}
+		if update.Version == version {
+			new, found = snap, true
+		} else {
+			old, foundOld = snap, true
+		}
+	}
+	if !found {
+		return errors.Errorf("version %d not found in the stack's history", version)
+	}
+	if version > 1 && !foundOld {
+		return errors.Errorf("version %d, which version %d is compared to, not found in the stack's history",
+			version-1, version)
+	}
+
+	steps := diffSnapshots(old, new)
+	if len(steps) == 0 {
+		fmt.Printf("Version %d made no changes to resources\n", version)
+		return nil
+	}
+
+	fmt.Printf("Changes made by version %d:\n", version)
+	seen := make(map[resource.URN]engine.StepEventMetadata)
+	changes := make(engine.ResourceChanges)
+	for _, step := range steps {
+		seen[step.URN] = step
+		changes[step.Op]++
+
+		indent := engine.GetIndent(step, seen)
+		out := engine.GetResourcePropertiesSummary(step, indent) +
+			engine.GetResourcePropertiesDetails(step, indent, false /*planning*/, false /*summary*/, false /*debug*/)
+		fmt.Print(opts.Color.Colorize(out + colors.Reset))
+	}
+	fmt.Printf("Resources: %s\n", opts.Color.Colorize(formatResourceChanges(changes)))
+	return nil
+}
+
+// diffSnapshots returns the resources that were created, updated, or deleted between the old and new snapshots,
+// either of which may be nil.  Created and updated resources are returned in the order of the new snapshot, followed
+// by deleted resources in the order of the old snapshot.
+func diffSnapshots(old, new *deploy.Snapshot) []engine.StepEventMetadata {
+	olds := make(map[resource.URN]*resource.State)
+	if old != nil {
+		for _, res := range old.Resources {
+			olds[res.URN] = res
+		}
+	}
+	news := make(map[resource.URN]bool)
+
+	var steps []engine.StepEventMetadata
+	if new != nil {
+		for _, res := range new.Resources {
+			news[res.URN] = true
+			step := engine.StepEventMetadata{URN: res.URN, Type: res.Type, New: stepEventStateMetadata(res)}
+			if o, has := olds[res.URN]; !has {
+				step.Op = deploy.OpCreate
+			} else if o.ID != res.ID || o.Inputs.Diff(res.Inputs) != nil || o.Outputs.Diff(res.Outputs) != nil {
+				step.Op, step.Old = deploy.OpUpdate, stepEventStateMetadata(o)
+			} else {
+				continue
+			}
+			step.Res = step.New
+			steps = append(steps, step)
+		}
+	}
+	if old != nil {
+		for _, res := range old.Resources {
+			if !news[res.URN] {
+				meta := stepEventStateMetadata(res)
+				steps = append(steps, engine.StepEventMetadata{
+					Op:   deploy.OpDelete,
+					URN:  res.URN,
+					Type: res.Type,
+					Old:  meta,
+					Res:  meta,
+				})
+			}
+		}
+	}
+	return steps
+}
+
+func stepEventStateMetadata(res *resource.State) *engine.StepEventStateMetadata {
+	return &engine.StepEventStateMetadata{
+		Type:    res.Type,
+		URN:     res.URN,
+		Custom:  res.Custom,
+		Delete:  res.Delete,
+		ID:      res.ID,
+		Parent:  res.Parent,
+		Protect: res.Protect,
+		Inputs:  res.Inputs,
+		Outputs: res.Outputs,
+	}
+}
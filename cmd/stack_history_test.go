@@ -0,0 +1,88 @@
+// Copyright 2016-2018, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/pulumi/pulumi/pkg/apitype"
+	"github.com/pulumi/pulumi/pkg/backend"
+	"github.com/pulumi/pulumi/pkg/diag/colors"
+	"github.com/pulumi/pulumi/pkg/resource"
+	"github.com/pulumi/pulumi/pkg/resource/deploy"
+	"github.com/pulumi/pulumi/pkg/tokens"
+)
+
+func TestPageUpdates(t *testing.T) {
+	updates := []backend.UpdateInfo{{Version: 5}, {Version: 4}, {Version: 3}, {Version: 2}, {Version: 1}}
+
+	assert.Equal(t, updates, pageUpdates(updates, 0, 1))
+	assert.Equal(t, updates[:2], pageUpdates(updates, 2, 1))
+	assert.Equal(t, updates[2:4], pageUpdates(updates, 2, 2))
+	assert.Equal(t, updates[4:], pageUpdates(updates, 2, 3))
+	assert.Nil(t, pageUpdates(updates, 2, 4))
+}
+
+func TestDiffSnapshots(t *testing.T) {
+	newResource := func(name string, props resource.PropertyMap) *resource.State {
+		urn := resource.NewURN("stack", "proj", "", tokens.Type("test:index:Resource"), tokens.QName(name))
+		return resource.NewState(urn.Type(), urn, true, false, resource.ID(name), props, props,
+			"", false, false, 0, nil, nil)
+	}
+
+	same := newResource("same", resource.PropertyMap{"a": resource.NewStringProperty("a")})
+	updatedOld := newResource("updated", resource.PropertyMap{"a": resource.NewStringProperty("old")})
+	updatedNew := newResource("updated", resource.PropertyMap{"a": resource.NewStringProperty("new")})
+	deleted := newResource("deleted", resource.PropertyMap{})
+	created := newResource("created", resource.PropertyMap{})
+
+	old := deploy.NewSnapshot(deploy.Manifest{}, []*resource.State{same, updatedOld, deleted})
+	new := deploy.NewSnapshot(deploy.Manifest{}, []*resource.State{same, updatedNew, created})
+
+	steps := diffSnapshots(old, new)
+	if assert.Len(t, steps, 3) {
+		assert.Equal(t, deploy.OpUpdate, steps[0].Op)
+		assert.Equal(t, updatedNew.URN, steps[0].URN)
+		assert.Equal(t, deploy.OpCreate, steps[1].Op)
+		assert.Equal(t, created.URN, steps[1].URN)
+		assert.Equal(t, deploy.OpDelete, steps[2].Op)
+		assert.Equal(t, deleted.URN, steps[2].URN)
+	}
+
+	// The first version of a stack creates all of its resources.
+	steps = diffSnapshots(nil, old)
+	assert.Len(t, steps, 3)
+	for _, step := range steps {
+		assert.Equal(t, deploy.OpCreate, step.Op)
+	}
+}
+
+func TestShowUpdateDiffMissingVersion(t *testing.T) {
+	deployment := &apitype.UntypedDeployment{
+		Version:    apitype.DeploymentSchemaVersionCurrent,
+		Deployment: []byte("{}"),
+	}
+	updates := []backend.UpdateInfo{{Version: 3, Deployment: deployment}, {Version: 1, Deployment: deployment}}
+	opts := backend.DisplayOptions{Color: colors.Never}
+
+	// Version 3 can't be diffed when the version before it is missing, rather than being diffed against nothing.
+	if err := showUpdateDiff(updates, 3, opts); assert.Error(t, err) {
+		assert.Contains(t, err.Error(), "version 2")
+	}
+	assert.Error(t, showUpdateDiff(updates, 2, opts))
+	assert.NoError(t, showUpdateDiff(updates, 1, opts))
+}
@@ -18,11 +18,13 @@ import (
 	"fmt"
 	"sort"
 	"strconv"
+	"strings"
 
 	"github.com/dustin/go-humanize"
 	"github.com/pkg/errors"
 	"github.com/spf13/cobra"
 
+	"github.com/pulumi/pulumi/pkg/apitype"
 	"github.com/pulumi/pulumi/pkg/backend"
 	"github.com/pulumi/pulumi/pkg/backend/cloud"
 	"github.com/pulumi/pulumi/pkg/backend/state"
@@ -34,11 +36,22 @@ import (
 
 func newStackLsCmd() *cobra.Command {
 	var allStacks bool
+	var tagFilters []string
 	cmd := &cobra.Command{
 		Use:   "ls",
 		Short: "List all known stacks",
-		Args:  cmdutil.NoArgs,
+		Long: "List all known stacks\n" +
+			"\n" +
+			"By default, only stacks for the current project are listed.  The list may be further\n" +
+			"narrowed using one or more --tag-filter flags, each of the form <name> (the stack has\n" +
+			"the tag) or <name>=<value> (the stack has the tag with exactly that value).",
+		Args: cmdutil.NoArgs,
 		Run: cmdutil.RunFunc(func(cmd *cobra.Command, args []string) error {
+			filter, err := parseStackTagFilters(tagFilters)
+			if err != nil {
+				return err
+			}
+
 			// Ensure we are in a project; if not, we will fail.
 			projPath, err := workspace.DetectProjectPath()
 			if err != nil {
@@ -86,6 +99,9 @@ func newStackLsCmd() *cobra.Command {
 			_, showURLColumn := b.(cloud.Backend)
 
 			for _, stack := range bs {
+				if !filter.matches(stack.Tags()) {
+					continue
+				}
 				name := stack.Name().String()
 				stacks[name] = stack
 				stackNames = append(stackNames, name)
@@ -180,10 +196,46 @@ func newStackLsCmd() *cobra.Command {
 	}
 	cmd.PersistentFlags().BoolVarP(
 		&allStacks, "all", "a", false, "List all stacks instead of just stacks for the current project")
+	cmd.PersistentFlags().StringArrayVarP(
+		&tagFilters, "tag-filter", "t", nil,
+		"Only list stacks with a matching tag, given as <name> or <name>=<value>; may be repeated")
 
 	return cmd
 }
 
+// stackTagFilter is a set of conditions on a stack's tags.  A nil value for a tag name means the stack must simply
+// have the tag, while a non-nil value means the tag must also have exactly that value.
+type stackTagFilter map[apitype.StackTagName]*string
+
+// parseStackTagFilters parses a list of <name> or <name>=<value> filter expressions.
+func parseStackTagFilters(exprs []string) (stackTagFilter, error) {
+	filter := make(stackTagFilter)
+	for _, expr := range exprs {
+		name, value := expr, (*string)(nil)
+		if eq := strings.Index(expr, "="); eq != -1 {
+			name = expr[:eq]
+			v := expr[eq+1:]
+			value = &v
+		}
+		if name == "" {
+			return nil, errors.Errorf("invalid tag filter %q; expected <name> or <name>=<value>", expr)
+		}
+		filter[name] = value
+	}
+	return filter, nil
+}
+
+// matches returns true if the given set of tags satisfies every condition in the filter.
+func (f stackTagFilter) matches(tags map[apitype.StackTagName]string) bool {
+	for name, value := range f {
+		actual, has := tags[name]
+		if !has || (value != nil && actual != *value) {
+			return false
+		}
+	}
+	return true
+}
+
 func hasAnyPPCStacks(stacks []backend.Stack) (bool, int) {
 	res, maxLen := false, 0
 	for _, s := range stacks {
@@ -0,0 +1,52 @@
+// Copyright 2016-2018, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/pulumi/pulumi/pkg/apitype"
+)
+
+func TestStackTagFilter(t *testing.T) {
+	tags := map[apitype.StackTagName]string{
+		apitype.ProjectNameTag:    "my-project",
+		apitype.ProjectRuntimeTag: "nodejs",
+		"team":                    "",
+	}
+
+	filter, err := parseStackTagFilters(nil)
+	assert.NoError(t, err)
+	assert.True(t, filter.matches(tags))
+	assert.True(t, filter.matches(nil))
+
+	filter, err = parseStackTagFilters([]string{"team"})
+	assert.NoError(t, err)
+	assert.True(t, filter.matches(tags))
+	assert.False(t, filter.matches(nil))
+
+	filter, err = parseStackTagFilters([]string{"pulumi:runtime=nodejs", "team="})
+	assert.NoError(t, err)
+	assert.True(t, filter.matches(tags))
+
+	filter, err = parseStackTagFilters([]string{"pulumi:runtime=nodejs", "pulumi:project=other"})
+	assert.NoError(t, err)
+	assert.False(t, filter.matches(tags))
+
+	_, err = parseStackTagFilters([]string{"=value"})
+	assert.Error(t, err)
+}
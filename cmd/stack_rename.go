@@ -0,0 +1,114 @@
+// Copyright 2016-2018, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+
+	"github.com/pulumi/pulumi/pkg/backend"
+	"github.com/pulumi/pulumi/pkg/backend/state"
+	"github.com/pulumi/pulumi/pkg/tokens"
+	"github.com/pulumi/pulumi/pkg/util/cmdutil"
+	"github.com/pulumi/pulumi/pkg/workspace"
+)
+
+func newStackRenameCmd() *cobra.Command {
+	var stack string
+	var cmd = &cobra.Command{
+		Use:   "rename <new-stack-name>",
+		Args:  cmdutil.ExactArgs(1),
+		Short: "Rename an existing stack",
+		Long: "Rename an existing stack\n" +
+			"\n" +
+			"This command renames a stack, rewriting the URNs of all of its resources to refer\n" +
+			"to the new name.  The stack's configuration file, if any, is renamed to match.\n" +
+			"\n" +
+			"Note that because URNs change, any references to this stack's resources that were\n" +
+			"recorded elsewhere using the old name will need to be updated by hand.",
+		Run: cmdutil.RunFunc(func(cmd *cobra.Command, args []string) error {
+			opts := backend.DisplayOptions{
+				Color: cmdutil.GetGlobalColorization(),
+			}
+
+			s, err := requireStack(stack, false, opts)
+			if err != nil {
+				return err
+			}
+			oldName := s.Name()
+			newName := tokens.QName(args[0])
+
+			// Before doing anything, figure out where the stack's settings live, both now and after the rename.
+			oldConfigPath, err := workspace.DetectProjectStackPath(oldName.StackName())
+			if err != nil {
+				return err
+			}
+			newConfigPath, err := workspace.DetectProjectStackPath(newName)
+			if err != nil {
+				return err
+			}
+
+			// Refuse to clobber settings that already exist under the new name.
+			if _, err = os.Stat(newConfigPath); err == nil {
+				return errors.Errorf("a stack settings file already exists at %s; remove it before renaming", newConfigPath)
+			} else if !os.IsNotExist(err) {
+				return err
+			}
+
+			// Determine whether this stack is the currently selected one, so we can keep it selected.
+			current, err := state.CurrentStack(commandContext(), s.Backend())
+			if err != nil {
+				return err
+			}
+			isCurrent := current != nil && current.Name().String() == oldName.String()
+
+			// Move the stack's settings file first, so that it can be put back if the stack itself can't be renamed.
+			movedConfig := false
+			if err = os.Rename(oldConfigPath, newConfigPath); err == nil {
+				movedConfig = true
+			} else if !os.IsNotExist(err) {
+				return err
+			}
+
+			newRef, err := s.Rename(commandContext(), newName)
+			if err != nil {
+				if movedConfig {
+					if rerr := os.Rename(newConfigPath, oldConfigPath); rerr != nil {
+						return errors.Wrapf(err, "renaming stack (and its settings could not be restored from %s: %v)",
+							newConfigPath, rerr)
+					}
+				}
+				return err
+			}
+
+			if isCurrent {
+				if err = state.SetCurrentStack(newRef.String()); err != nil {
+					return err
+				}
+			}
+
+			fmt.Printf("Renamed %s to %s\n", oldName, newRef)
+			return nil
+		}),
+	}
+
+	cmd.PersistentFlags().StringVarP(
+		&stack, "stack", "s", "", "The name of the stack to operate on. Defaults to the current stack")
+
+	return cmd
+}
@@ -0,0 +1,185 @@
+// Copyright 2016-2018, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+
+	"github.com/pulumi/pulumi/pkg/apitype"
+	"github.com/pulumi/pulumi/pkg/backend"
+	"github.com/pulumi/pulumi/pkg/util/cmdutil"
+)
+
+func newStackTagCmd() *cobra.Command {
+	var stack string
+
+	cmd := &cobra.Command{
+		Use:   "tag",
+		Short: "Manage stack tags",
+		Long: "Manage stack tags\n" +
+			"\n" +
+			"Stacks have associated metadata in the form of tags. Each tag consists of a name\n" +
+			"and value. The `get`, `ls`, `rm`, and `set` commands can be used to manage tags.\n" +
+			"Some tags, such as `pulumi:project`, are automatically assigned based on the\n" +
+			"environment each time a stack is updated; these cannot be set or removed by hand.\n",
+		Args: cmdutil.NoArgs,
+	}
+
+	cmd.PersistentFlags().StringVarP(
+		&stack, "stack", "s", "", "The name of the stack to operate on. Defaults to the current stack")
+
+	cmd.AddCommand(newStackTagGetCmd(&stack))
+	cmd.AddCommand(newStackTagLsCmd(&stack))
+	cmd.AddCommand(newStackTagRmCmd(&stack))
+	cmd.AddCommand(newStackTagSetCmd(&stack))
+
+	return cmd
+}
+
+func newStackTagGetCmd(stack *string) *cobra.Command {
+	return &cobra.Command{
+		Use:   "get <name>",
+		Short: "Get a single stack tag value",
+		Args:  cmdutil.SpecificArgs([]string{"name"}),
+		Run: cmdutil.RunFunc(func(cmd *cobra.Command, args []string) error {
+			name := args[0]
+
+			opts := backend.DisplayOptions{
+				Color: cmdutil.GetGlobalColorization(),
+			}
+			s, err := requireStack(*stack, false, opts)
+			if err != nil {
+				return err
+			}
+
+			if value, ok := s.Tags()[name]; ok {
+				fmt.Printf("%v\n", value)
+				return nil
+			}
+
+			return errors.Errorf("stack tag '%s' not found for stack '%s'", name, s.Name())
+		}),
+	}
+}
+
+func newStackTagLsCmd(stack *string) *cobra.Command {
+	return &cobra.Command{
+		Use:   "ls",
+		Short: "List all stack tags",
+		Args:  cmdutil.NoArgs,
+		Run: cmdutil.RunFunc(func(cmd *cobra.Command, args []string) error {
+			opts := backend.DisplayOptions{
+				Color: cmdutil.GetGlobalColorization(),
+			}
+			s, err := requireStack(*stack, false, opts)
+			if err != nil {
+				return err
+			}
+
+			printStackTags(s.Tags())
+			return nil
+		}),
+	}
+}
+
+func printStackTags(tags map[apitype.StackTagName]string) {
+	var names []string
+	maxname := 24
+	for n := range tags {
+		names = append(names, n)
+		if len(n) > maxname {
+			maxname = len(n)
+		}
+	}
+	sort.Strings(names)
+
+	formatDirective := "%-" + strconv.Itoa(maxname) + "s %s\n"
+	fmt.Printf(formatDirective, "NAME", "VALUE")
+	for _, name := range names {
+		fmt.Printf(formatDirective, name, tags[name])
+	}
+}
+
+func newStackTagRmCmd(stack *string) *cobra.Command {
+	return &cobra.Command{
+		Use:   "rm <name>",
+		Short: "Remove a stack tag",
+		Args:  cmdutil.SpecificArgs([]string{"name"}),
+		Run: cmdutil.RunFunc(func(cmd *cobra.Command, args []string) error {
+			name := args[0]
+			if backend.IsComputedStackTag(name) {
+				return errors.Errorf("stack tag '%s' is computed automatically and cannot be removed", name)
+			}
+
+			opts := backend.DisplayOptions{
+				Color: cmdutil.GetGlobalColorization(),
+			}
+			s, err := requireStack(*stack, false, opts)
+			if err != nil {
+				return err
+			}
+
+			tags := copyStackTags(s.Tags())
+			if _, ok := tags[name]; !ok {
+				return errors.Errorf("stack tag '%s' not found for stack '%s'", name, s.Name())
+			}
+			delete(tags, name)
+
+			return backend.UpdateStackTags(commandContext(), s, tags)
+		}),
+	}
+}
+
+func newStackTagSetCmd(stack *string) *cobra.Command {
+	return &cobra.Command{
+		Use:   "set <name> <value>",
+		Short: "Set a stack tag",
+		Args:  cmdutil.SpecificArgs([]string{"name", "value"}),
+		Run: cmdutil.RunFunc(func(cmd *cobra.Command, args []string) error {
+			name := args[0]
+			value := args[1]
+			if backend.IsComputedStackTag(name) {
+				return errors.Errorf("stack tag '%s' is computed automatically and cannot be set", name)
+			}
+
+			opts := backend.DisplayOptions{
+				Color: cmdutil.GetGlobalColorization(),
+			}
+			s, err := requireStack(*stack, false, opts)
+			if err != nil {
+				return err
+			}
+
+			tags := copyStackTags(s.Tags())
+			tags[name] = value
+
+			return backend.UpdateStackTags(commandContext(), s, tags)
+		}),
+	}
+}
+
+// copyStackTags returns a mutable copy of the given set of tags, which may be nil.
+func copyStackTags(tags map[apitype.StackTagName]string) map[apitype.StackTagName]string {
+	result := make(map[apitype.StackTagName]string)
+	for k, v := range tags {
+		result[k] = v
+	}
+	return result
+}
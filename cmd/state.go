@@ -0,0 +1,98 @@
+// Copyright 2016-2018, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+
+	"github.com/pulumi/pulumi/pkg/apitype"
+	"github.com/pulumi/pulumi/pkg/backend"
+	"github.com/pulumi/pulumi/pkg/resource/deploy"
+	"github.com/pulumi/pulumi/pkg/resource/stack"
+	"github.com/pulumi/pulumi/pkg/util/cmdutil"
+)
+
+func newStateCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "state",
+		Short: "Edit the current stack's state",
+		Long: "Edit the current stack's state.\n" +
+			"\n" +
+			"Subcommands of this command can be used to surgically edit parts of a stack's state,\n" +
+			"for example to repair a checkpoint after a failed update, without having to export,\n" +
+			"hand-edit, and reimport the whole deployment.  These commands only change Pulumi's\n" +
+			"record of your resources; they never touch the resources themselves.",
+		Args: cmdutil.NoArgs,
+	}
+
+	cmd.AddCommand(newStateDeleteCmd())
+	cmd.AddCommand(newStateMoveCmd())
+	cmd.AddCommand(newStateProtectCmd())
+	cmd.AddCommand(newStateReparentCmd())
+	cmd.AddCommand(newStateUnprotectCmd())
+
+	return cmd
+}
+
+// loadStackSnapshot fetches the given stack's latest deployment and deserializes it into a snapshot.  An empty stack
+// produces an empty snapshot.
+func loadStackSnapshot(s backend.Stack) (*deploy.Snapshot, error) {
+	deployment, err := s.ExportDeployment(commandContext())
+	if err != nil {
+		return nil, err
+	}
+	snap, err := stack.DeserializeDeployment(deployment)
+	if err != nil {
+		return nil, errors.Wrapf(err, "could not deserialize the deployment of stack '%s'", s.Name())
+	}
+	if snap == nil {
+		snap = deploy.NewSnapshot(deploy.Manifest{}, nil)
+	}
+	return snap, nil
+}
+
+// saveStackSnapshot verifies the integrity of an edited snapshot and, if it checks out, replaces the given stack's
+// deployment with it.
+func saveStackSnapshot(s backend.Stack, snap *deploy.Snapshot) error {
+	if err := snap.VerifyIntegrity(); err != nil {
+		return errors.Wrapf(err, "refusing to save an invalid state for stack '%s'", s.Name())
+	}
+
+	bytes, err := json.Marshal(stack.SerializeDeployment(snap))
+	if err != nil {
+		return err
+	}
+	deployment := &apitype.UntypedDeployment{
+		Version:    apitype.DeploymentSchemaVersionCurrent,
+		Deployment: bytes,
+	}
+	if err = s.ImportDeployment(commandContext(), deployment); err != nil {
+		return errors.Wrapf(err, "could not save the state of stack '%s'", s.Name())
+	}
+	return nil
+}
+
+// confirmStateEdit asks the user to confirm an edit to a stack's state, unless they have already done so with --yes.
+func confirmStateEdit(s backend.Stack, what string, yes bool, opts backend.DisplayOptions) error {
+	prompt := fmt.Sprintf("This will %s in the state of stack '%s'.", what, s.Name())
+	if !yes && !confirmPrompt(prompt, s.Name().String(), opts) {
+		return errors.New("confirmation declined")
+	}
+	return nil
+}
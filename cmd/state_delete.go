@@ -0,0 +1,89 @@
+// Copyright 2016-2018, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/pulumi/pulumi/pkg/backend"
+	"github.com/pulumi/pulumi/pkg/resource"
+	"github.com/pulumi/pulumi/pkg/resource/edit"
+	"github.com/pulumi/pulumi/pkg/util/cmdutil"
+)
+
+func newStateDeleteCmd() *cobra.Command {
+	var force bool
+	var stackName string
+	var yes bool
+	cmd := &cobra.Command{
+		Use:   "delete <urn>",
+		Args:  cmdutil.ExactArgs(1),
+		Short: "Delete a resource from a stack's state",
+		Long: "Delete a resource from a stack's state.\n" +
+			"\n" +
+			"This command removes the resource with the given URN from the stack's state, without\n" +
+			"deleting the resource itself.  Protected resources cannot be deleted; use\n" +
+			"`pulumi state unprotect` first.  If other resources depend on the resource, or are its\n" +
+			"children, the deletion is refused unless --force is passed, in which case the dependents\n" +
+			"forget the dependency and the children are reparented to the resource's own parent.",
+		Run: cmdutil.RunFunc(func(cmd *cobra.Command, args []string) error {
+			opts := backend.DisplayOptions{
+				Color: cmdutil.GetGlobalColorization(),
+			}
+
+			s, err := requireStack(stackName, false, opts)
+			if err != nil {
+				return err
+			}
+			snap, err := loadStackSnapshot(s)
+			if err != nil {
+				return err
+			}
+
+			urn := resource.URN(args[0])
+			res, err := edit.LocateResource(snap, urn)
+			if err != nil {
+				return err
+			}
+			if err = edit.DeleteResource(snap, res, force); err != nil {
+				if _, ok := err.(edit.ResourceHasDependenciesError); ok {
+					return fmt.Errorf("%v; pass --force to delete it anyway", err)
+				}
+				return err
+			}
+
+			if err = confirmStateEdit(s, fmt.Sprintf("delete '%s'", urn), yes, opts); err != nil {
+				return err
+			}
+			if err = saveStackSnapshot(s, snap); err != nil {
+				return err
+			}
+			fmt.Printf("Deleted %s from the state\n", urn)
+			return nil
+		}),
+	}
+
+	cmd.PersistentFlags().BoolVarP(
+		&force, "force", "f", false,
+		"Delete the resource even if other resources depend on it or are its children")
+	cmd.PersistentFlags().StringVarP(
+		&stackName, "stack", "s", "", "The name of the stack to operate on. Defaults to the current stack")
+	cmd.PersistentFlags().BoolVarP(
+		&yes, "yes", "y", false, "Skip confirmation prompts, and proceed with the edit anyway")
+
+	return cmd
+}
@@ -0,0 +1,114 @@
+// Copyright 2016-2018, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+
+	"github.com/pulumi/pulumi/pkg/backend"
+	"github.com/pulumi/pulumi/pkg/resource"
+	"github.com/pulumi/pulumi/pkg/resource/edit"
+	"github.com/pulumi/pulumi/pkg/util/cmdutil"
+)
+
+func newStateMoveCmd() *cobra.Command {
+	var dest string
+	var stackName string
+	var yes bool
+	cmd := &cobra.Command{
+		Use:   "move <urn>...",
+		Args:  cmdutil.MinimumNArgs(1),
+		Short: "Move resources from one stack's state to another's",
+		Long: "Move resources from one stack's state to another's.\n" +
+			"\n" +
+			"This command moves the resources with the given URNs, along with all of their\n" +
+			"children, from the source stack's state to the state of the stack named by --dest.\n" +
+			"The moved resources' URNs are rewritten to refer to the destination stack, and children\n" +
+			"of the source stack's root resource become children of the destination's.  The move is\n" +
+			"refused if the stacks belong to different projects, if resources left behind depend on\n" +
+			"the moved ones, or if the moved resources refer to parents or dependencies that the\n" +
+			"destination stack lacks.",
+		Run: cmdutil.RunFunc(func(cmd *cobra.Command, args []string) error {
+			if dest == "" {
+				return errors.New("missing required --dest stack")
+			}
+
+			opts := backend.DisplayOptions{
+				Color: cmdutil.GetGlobalColorization(),
+			}
+
+			source, err := requireStack(stackName, false, opts)
+			if err != nil {
+				return err
+			}
+			destination, err := requireStack(dest, false, opts)
+			if err != nil {
+				return err
+			}
+			if source.Name().String() == destination.Name().String() {
+				return errors.New("the source and destination stacks must differ")
+			}
+
+			sourceSnap, err := loadStackSnapshot(source)
+			if err != nil {
+				return err
+			}
+			destSnap, err := loadStackSnapshot(destination)
+			if err != nil {
+				return err
+			}
+
+			var resources []*resource.State
+			for _, arg := range args {
+				res, err := edit.LocateResource(sourceSnap, resource.URN(arg))
+				if err != nil {
+					return err
+				}
+				resources = append(resources, res)
+			}
+			err = edit.MoveResources(sourceSnap, destSnap, resources, destination.Name().StackName())
+			if err != nil {
+				return err
+			}
+
+			what := fmt.Sprintf("move %d resource(s) to stack '%s'", len(args), destination.Name())
+			if err = confirmStateEdit(source, what, yes, opts); err != nil {
+				return err
+			}
+
+			// Save the destination first, so that a failure can never lose track of the moved resources.
+			if err = saveStackSnapshot(destination, destSnap); err != nil {
+				return err
+			}
+			if err = saveStackSnapshot(source, sourceSnap); err != nil {
+				return err
+			}
+			fmt.Printf("Moved %d resource(s) from %s to %s\n", len(args), source.Name(), destination.Name())
+			return nil
+		}),
+	}
+
+	cmd.PersistentFlags().StringVarP(
+		&dest, "dest", "d", "", "The name of the stack to move the resources to")
+	cmd.PersistentFlags().StringVarP(
+		&stackName, "stack", "s", "", "The name of the stack to move the resources from. Defaults to the current stack")
+	cmd.PersistentFlags().BoolVarP(
+		&yes, "yes", "y", false, "Skip confirmation prompts, and proceed with the edit anyway")
+
+	return cmd
+}
@@ -0,0 +1,101 @@
+// Copyright 2016-2018, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+
+	"github.com/pulumi/pulumi/pkg/backend"
+	"github.com/pulumi/pulumi/pkg/resource"
+	"github.com/pulumi/pulumi/pkg/resource/edit"
+	"github.com/pulumi/pulumi/pkg/util/cmdutil"
+)
+
+func newStateProtectCmd() *cobra.Command {
+	var all bool
+	var stackName string
+	cmd := &cobra.Command{
+		Use:   "protect [<urn>]",
+		Args:  cmdutil.MaximumNArgs(1),
+		Short: "Protect a resource in a stack's state",
+		Long: "Protect a resource in a stack's state.\n" +
+			"\n" +
+			"This command sets the protect bit of the resource with the given URN, or of every\n" +
+			"resource in the stack if --all is passed, so that it may not be deleted.",
+		Run: cmdutil.RunFunc(func(cmd *cobra.Command, args []string) error {
+			return setStateProtection(stackName, all, args, true)
+		}),
+	}
+
+	cmd.PersistentFlags().BoolVar(
+		&all, "all", false, "Protect every resource in the stack")
+	cmd.PersistentFlags().StringVarP(
+		&stackName, "stack", "s", "", "The name of the stack to operate on. Defaults to the current stack")
+
+	return cmd
+}
+
+// setStateProtection sets or clears the protect bit of the resource with the URN given in args, or of every resource
+// in the stack if all is true, and saves the stack's state.
+func setStateProtection(stackName string, all bool, args []string, protect bool) error {
+	if all == (len(args) > 0) {
+		return errors.New("either a URN or --all must be passed, but not both")
+	}
+
+	opts := backend.DisplayOptions{
+		Color: cmdutil.GetGlobalColorization(),
+	}
+
+	s, err := requireStack(stackName, false, opts)
+	if err != nil {
+		return err
+	}
+	snap, err := loadStackSnapshot(s)
+	if err != nil {
+		return err
+	}
+
+	update := edit.UnprotectResource
+	verb := "Unprotected"
+	if protect {
+		update = edit.ProtectResource
+		verb = "Protected"
+	}
+
+	if all {
+		for _, res := range snap.Resources {
+			update(snap, res)
+		}
+	} else {
+		res, err := edit.LocateResource(snap, resource.URN(args[0]))
+		if err != nil {
+			return err
+		}
+		update(snap, res)
+	}
+
+	if err = saveStackSnapshot(s, snap); err != nil {
+		return err
+	}
+	if all {
+		fmt.Printf("%s all resources in %s\n", verb, s.Name())
+	} else {
+		fmt.Printf("%s %s\n", verb, args[0])
+	}
+	return nil
+}
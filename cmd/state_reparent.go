@@ -0,0 +1,87 @@
+// Copyright 2016-2018, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/pulumi/pulumi/pkg/backend"
+	"github.com/pulumi/pulumi/pkg/resource"
+	"github.com/pulumi/pulumi/pkg/resource/edit"
+	"github.com/pulumi/pulumi/pkg/util/cmdutil"
+)
+
+func newStateReparentCmd() *cobra.Command {
+	var stackName string
+	var yes bool
+	cmd := &cobra.Command{
+		Use:   "reparent <urn> [<parent-urn>]",
+		Args:  cmdutil.RangeArgs(1, 2),
+		Short: "Change the parent of a resource in a stack's state",
+		Long: "Change the parent of a resource in a stack's state.\n" +
+			"\n" +
+			"This command makes the resource with the second URN the parent of the resource with\n" +
+			"the first, or leaves it without a parent if only one URN is given.  Because a\n" +
+			"resource's URN encodes its parent's type, the URNs of the resource and all of its\n" +
+			"children change; your program must be updated to use the same parent, or the next\n" +
+			"update will replace them.",
+		Run: cmdutil.RunFunc(func(cmd *cobra.Command, args []string) error {
+			opts := backend.DisplayOptions{
+				Color: cmdutil.GetGlobalColorization(),
+			}
+
+			s, err := requireStack(stackName, false, opts)
+			if err != nil {
+				return err
+			}
+			snap, err := loadStackSnapshot(s)
+			if err != nil {
+				return err
+			}
+
+			res, err := edit.LocateResource(snap, resource.URN(args[0]))
+			if err != nil {
+				return err
+			}
+			var parent *resource.State
+			if len(args) > 1 {
+				if parent, err = edit.LocateResource(snap, resource.URN(args[1])); err != nil {
+					return err
+				}
+			}
+			if err = edit.ReparentResource(snap, res, parent); err != nil {
+				return err
+			}
+
+			if err = confirmStateEdit(s, fmt.Sprintf("reparent '%s'", args[0]), yes, opts); err != nil {
+				return err
+			}
+			if err = saveStackSnapshot(s, snap); err != nil {
+				return err
+			}
+			fmt.Printf("Reparented %s; its URN is now %s\n", args[0], res.URN)
+			return nil
+		}),
+	}
+
+	cmd.PersistentFlags().StringVarP(
+		&stackName, "stack", "s", "", "The name of the stack to operate on. Defaults to the current stack")
+	cmd.PersistentFlags().BoolVarP(
+		&yes, "yes", "y", false, "Skip confirmation prompts, and proceed with the edit anyway")
+
+	return cmd
+}
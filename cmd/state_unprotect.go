@@ -0,0 +1,45 @@
+// Copyright 2016-2018, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/pulumi/pulumi/pkg/util/cmdutil"
+)
+
+func newStateUnprotectCmd() *cobra.Command {
+	var all bool
+	var stackName string
+	cmd := &cobra.Command{
+		Use:   "unprotect [<urn>]",
+		Args:  cmdutil.MaximumNArgs(1),
+		Short: "Unprotect a resource in a stack's state",
+		Long: "Unprotect a resource in a stack's state.\n" +
+			"\n" +
+			"This command clears the protect bit of the resource with the given URN, or of every\n" +
+			"resource in the stack if --all is passed, so that it may be deleted.",
+		Run: cmdutil.RunFunc(func(cmd *cobra.Command, args []string) error {
+			return setStateProtection(stackName, all, args, false)
+		}),
+	}
+
+	cmd.PersistentFlags().BoolVar(
+		&all, "all", false, "Unprotect every resource in the stack")
+	cmd.PersistentFlags().StringVarP(
+		&stackName, "stack", "s", "", "The name of the stack to operate on. Defaults to the current stack")
+
+	return cmd
+}
@@ -16,13 +16,16 @@ package cmd
 
 import (
 	"context"
+	"time"
 
 	"github.com/pkg/errors"
 	"github.com/spf13/cobra"
 
 	"github.com/pulumi/pulumi/pkg/backend"
+	"github.com/pulumi/pulumi/pkg/backend/local"
 	"github.com/pulumi/pulumi/pkg/engine"
 	"github.com/pulumi/pulumi/pkg/util/cmdutil"
+	"github.com/pulumi/pulumi/pkg/util/contract"
 )
 
 func newUpdateCmd() *cobra.Command {
@@ -33,9 +36,17 @@ func newUpdateCmd() *cobra.Command {
 
 	// Flags for engine.UpdateOptions.
 	var analyzers []string
+	var cancelTimeout time.Duration
+	var continueOnError bool
 	var diffDisplay bool
+	var displayFilter string
+	var eventLogPath string
+	var jsonDisplay bool
+	var jsonStream bool
 	var nonInteractive bool
 	var parallel int
+	var reportSpecs []string
+	var planPath string
 	var showConfig bool
 	var showReplacementSteps bool
 	var showSames bool
@@ -56,6 +67,10 @@ func newUpdateCmd() *cobra.Command {
 			"command results in a checkpoint containing a full snapshot of the stack's new resource state, so\n" +
 			"that it may be updated incrementally again later.\n" +
 			"\n" +
+			"With `--plan`, the update is constrained to the steps saved by `pulumi preview --save-plan`.\n" +
+			"If the update would create, update, replace or delete a resource differently than planned,\n" +
+			"or change its properties in a way the plan does not contain, it fails before doing so.\n" +
+			"\n" +
 			"The program to run is loaded from the project in the current directory. Use the `-C` or\n" +
 			"`--cwd` flag to use a different directory.",
 		Args: cmdutil.NoArgs,
@@ -65,11 +80,26 @@ func newUpdateCmd() *cobra.Command {
 				yes = true // auto-approve changes, since we cannot prompt.
 			}
 
+			// When emitting JSON, we cannot prompt, and the update's own events describe its changes, so skip the
+			// preview rather than emitting two sets of events.
+			if jsonDisplay || jsonStream {
+				if !yes {
+					return errors.New("--yes must be passed when using --json or --json-stream")
+				}
+				interactive = false
+				skipPreview = true
+			}
+
 			opts, err := updateFlagsToOptions(interactive, skipPreview, yes)
 			if err != nil {
 				return err
 			}
 
+			filter, err := backend.ParseDisplayFilter(displayFilter)
+			if err != nil {
+				return err
+			}
+
 			opts.Display = backend.DisplayOptions{
 				Color:                cmdutil.GetGlobalColorization(),
 				ShowConfig:           showConfig,
@@ -77,9 +107,32 @@ func newUpdateCmd() *cobra.Command {
 				ShowSameResources:    showSames,
 				IsInteractive:        interactive,
 				DiffDisplay:          diffDisplay,
+				JSONDisplay:          jsonDisplay || jsonStream,
+				JSONStream:           jsonStream,
+				Filter:               filter,
 				Debug:                debug,
 			}
 
+			var eventLogs backend.EventLogs
+			if eventLogPath != "" {
+				eventLog, logErr := local.OpenEventLog(eventLogPath)
+				if logErr != nil {
+					return logErr
+				}
+				defer contract.IgnoreClose(eventLog)
+				eventLogs = append(eventLogs, eventLog)
+			}
+			reports, err := newReports(reportSpecs)
+			if err != nil {
+				return err
+			}
+			for _, report := range reports {
+				eventLogs = append(eventLogs, report)
+			}
+			if len(eventLogs) > 0 {
+				opts.Display.EventLog = eventLogs
+			}
+
 			s, err := requireStack(stack, true, opts.Display)
 			if err != nil {
 				return err
@@ -96,12 +149,24 @@ func newUpdateCmd() *cobra.Command {
 			}
 
 			opts.Engine = engine.UpdateOptions{
-				Analyzers: analyzers,
-				Parallel:  parallel,
-				Debug:     debug,
+				Analyzers:       analyzers,
+				Parallel:        parallel,
+				Debug:           debug,
+				ContinueOnError: continueOnError,
+			}
+
+			if planPath != "" {
+				plan, planErr := readPlan(planPath)
+				if planErr != nil {
+					return planErr
+				}
+				opts.Engine.Plan = plan
 			}
 
-			changes, err := s.Update(commandContext(), proj, root, m, opts, cancellationScopes)
+			changes, err := s.Update(commandContext(), proj, root, m, opts, newCancellationScopeSource(cancelTimeout))
+			if reportErr := writeReports(reports); err == nil {
+				err = reportErr
+			}
 			switch {
 			case err == context.Canceled:
 				return errors.New("update cancelled")
@@ -133,14 +198,44 @@ func newUpdateCmd() *cobra.Command {
 	cmd.PersistentFlags().StringSliceVar(
 		&analyzers, "analyzer", []string{},
 		"Run one or more analyzers as part of this update")
+	cmd.PersistentFlags().DurationVar(
+		&cancelTimeout, "cancel-timeout", defaultCancelTimeout,
+		"How long to wait on ^C for resource operations in progress to complete before interrupting them (0 waits "+
+			"indefinitely)")
+	cmd.PersistentFlags().BoolVar(
+		&continueOnError, "continue-on-error", false,
+		"Keep updating resources that don't depend on a failed resource after an error, skipping those that do, "+
+			"and report all failures at the end")
 	cmd.PersistentFlags().BoolVar(
 		&diffDisplay, "diff", false,
 		"Display operation as a rich diff showing the overall change")
+	cmd.PersistentFlags().StringVar(
+		&displayFilter, "display-filter", "",
+		"Show only some resources: all, changes (hide unchanged resources) or failed; in a terminal, press f to "+
+			"change the filter, arrow keys and space to select and collapse components, and d to show diagnostics")
+	cmd.PersistentFlags().BoolVar(
+		&jsonDisplay, "json", false,
+		"Emit the operation's events as a single JSON document once it completes, instead of displaying them")
+	cmd.PersistentFlags().BoolVar(
+		&jsonStream, "json-stream", false,
+		"Emit the operation's events as newline-delimited JSON as they occur, instead of displaying them")
+	cmd.PersistentFlags().StringVar(
+		&eventLogPath, "event-log", "",
+		"Log the operation's events as newline-delimited JSON to a file, or to a Unix domain socket if one exists at "+
+			"the given path")
 	cmd.PersistentFlags().BoolVar(
 		&nonInteractive, "non-interactive", false, "Disable interactive mode")
 	cmd.PersistentFlags().IntVarP(
 		&parallel, "parallel", "p", 0,
 		"Allow P resource operations to run in parallel at once (<=1 for no parallelism)")
+	cmd.PersistentFlags().StringVar(
+		&planPath, "plan", "",
+		"Constrain the update to the steps in a plan file saved by `pulumi preview --save-plan`, failing if it "+
+			"would do anything else")
+	cmd.PersistentFlags().StringArrayVar(
+		&reportSpecs, "report", []string{},
+		"Write a summary of the update to a file, given as <format>:<path> where format is markdown or junit; "+
+			"may be repeated")
 	cmd.PersistentFlags().BoolVar(
 		&showConfig, "show-config", false,
 		"Show configuration keys and variables")
@@ -17,12 +17,15 @@ package cmd
 import (
 	"bytes"
 	"context"
+	"encoding/json"
 	"fmt"
+	"io/ioutil"
 	"os"
 	"os/exec"
 	"os/signal"
 	"path/filepath"
 	"sort"
+	"time"
 
 	"github.com/opentracing/opentracing-go"
 	"github.com/pkg/errors"
@@ -30,6 +33,7 @@ import (
 	survey "gopkg.in/AlecAivazis/survey.v1"
 	surveycore "gopkg.in/AlecAivazis/survey.v1/core"
 
+	"github.com/pulumi/pulumi/pkg/apitype"
 	"github.com/pulumi/pulumi/pkg/backend"
 	"github.com/pulumi/pulumi/pkg/backend/cloud"
 	"github.com/pulumi/pulumi/pkg/backend/local"
@@ -37,6 +41,8 @@ import (
 	"github.com/pulumi/pulumi/pkg/diag"
 	"github.com/pulumi/pulumi/pkg/diag/colors"
 	"github.com/pulumi/pulumi/pkg/engine"
+	"github.com/pulumi/pulumi/pkg/resource/deploy"
+	"github.com/pulumi/pulumi/pkg/resource/stack"
 	"github.com/pulumi/pulumi/pkg/util/cancel"
 	"github.com/pulumi/pulumi/pkg/util/cmdutil"
 	"github.com/pulumi/pulumi/pkg/util/contract"
@@ -404,8 +410,13 @@ func getUpdateMetadata(msg, root string) (backend.UpdateMetadata, error) {
 	return m, nil
 }
 
+// defaultCancelTimeout is how long resource operations that are in progress when an update is canceled are given to
+// complete before they are interrupted, unless --cancel-timeout says otherwise.
+const defaultCancelTimeout = 5 * time.Minute
+
 type cancellationScope struct {
 	context *cancel.Context
+	source  *cancel.Source
 	sigint  chan os.Signal
 }
 
@@ -416,17 +427,27 @@ func (s *cancellationScope) Context() *cancel.Context {
 func (s *cancellationScope) Close() {
 	signal.Stop(s.sigint)
 	close(s.sigint)
+	s.source.Close()
 }
 
-type cancellationScopeSource int
+type cancellationScopeSource struct {
+	// cancelTimeout is how long resource operations that are in progress when the operation is canceled are given
+	// to complete before they are interrupted; zero waits for them indefinitely.
+	cancelTimeout time.Duration
+}
 
-var cancellationScopes = backend.CancellationScopeSource(cancellationScopeSource(0))
+// newCancellationScopeSource returns a source of cancellation scopes that give in-progress resource operations
+// cancelTimeout to complete after a ^C.
+func newCancellationScopeSource(cancelTimeout time.Duration) backend.CancellationScopeSource {
+	return cancellationScopeSource{cancelTimeout: cancelTimeout}
+}
 
-func (cancellationScopeSource) NewScope(events chan<- engine.Event, isPreview bool) backend.CancellationScope {
+func (s cancellationScopeSource) NewScope(events chan<- engine.Event, isPreview bool) backend.CancellationScope {
 	cancelContext, cancelSource := cancel.NewContext(context.Background())
 
 	c := &cancellationScope{
 		context: cancelContext,
+		source:  cancelSource,
 		sigint:  make(chan os.Signal),
 	}
 
@@ -437,6 +458,13 @@ func (cancellationScopeSource) NewScope(events chan<- engine.Event, isPreview bo
 			if cancelContext.CancelErr() == nil {
 				message := "^C received; cancelling. If you would like to terminate immediately, press ^C again.\n"
 				if !isPreview {
+					message += "No new resource operations will be started; "
+					if s.cancelTimeout > 0 {
+						message += fmt.Sprintf("those in progress have %v to complete before they are "+
+							"interrupted.\n", s.cancelTimeout)
+					} else {
+						message += "waiting for those in progress to complete.\n"
+					}
 					message += colors.BrightRed + "Note that terminating immediately may lead to orphaned resources " +
 						"and other inconsistencies.\n" + colors.Reset
 				}
@@ -448,7 +476,7 @@ func (cancellationScopeSource) NewScope(events chan<- engine.Event, isPreview bo
 					},
 				}
 
-				cancelSource.Cancel()
+				cancelSource.CancelGracefully(s.cancelTimeout)
 			} else {
 				message := colors.BrightRed + "^C received; terminating" + colors.Reset
 				events <- engine.Event{
@@ -487,3 +515,49 @@ func updateFlagsToOptions(interactive, skipPreview, yes bool) (backend.UpdateOpt
 		SkipPreview: skipPreview,
 	}, nil
 }
+
+// readPlan reads a saved plan from the plan file at the given path.
+func readPlan(path string) (*deploy.SavedPlan, error) {
+	b, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, errors.Wrap(err, "reading plan file")
+	}
+	var plan apitype.PlanV1
+	if err = json.Unmarshal(b, &plan); err != nil {
+		return nil, errors.Wrap(err, "parsing plan file")
+	}
+	return stack.DeserializePlan(plan)
+}
+
+// writePlan writes a saved plan to a plan file at the given path.
+func writePlan(path string, plan *deploy.SavedPlan) error {
+	b, err := json.MarshalIndent(stack.SerializePlan(plan), "", "    ")
+	if err != nil {
+		return err
+	}
+	return errors.Wrap(ioutil.WriteFile(path, b, 0600), "writing plan file")
+}
+
+// newReports creates the reports requested by the given `--report` flags.
+func newReports(specs []string) ([]*local.Report, error) {
+	var reports []*local.Report
+	for _, spec := range specs {
+		report, err := local.NewReport(spec)
+		if err != nil {
+			return nil, err
+		}
+		reports = append(reports, report)
+	}
+	return reports, nil
+}
+
+// writeReports writes each of the given reports, returning the first error encountered, if any.
+func writeReports(reports []*local.Report) error {
+	var result error
+	for _, report := range reports {
+		if err := report.Write(); err != nil && result == nil {
+			result = err
+		}
+	}
+	return result
+}
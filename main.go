@@ -21,12 +21,14 @@ import (
 	"runtime/debug"
 
 	"github.com/pulumi/pulumi/cmd"
+	"github.com/pulumi/pulumi/pkg/util/cmdutil"
 	"github.com/pulumi/pulumi/pkg/util/contract"
 	"github.com/pulumi/pulumi/pkg/version"
 )
 
 func panicHandler() {
 	if panicPayload := recover(); panicPayload != nil {
+		cmdutil.RestoreTerminals()
 		stack := string(debug.Stack())
 		fmt.Fprintln(os.Stderr, "================================================================================")
 		fmt.Fprintln(os.Stderr, "The Pulumi CLI encountered a fatal error. This is a bug!")
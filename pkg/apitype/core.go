@@ -68,6 +68,8 @@ type CheckpointV2 struct {
 	Config config.Map `json:"config,omitempty" yaml:"config,omitempty"`
 	// Latest is the latest/current deployment (if an update has occurred).
 	Latest *DeploymentV2 `json:"latest,omitempty" yaml:"latest,omitempty"`
+	// Tags contains an optional set of tags associated with the stack.
+	Tags map[StackTagName]string `json:"tags,omitempty" yaml:"tags,omitempty"`
 }
 
 // DeploymentV1 represents a deployment that has actually occurred. It is similar to the engine's snapshot structure,
@@ -159,6 +161,12 @@ type ResourceV2 struct {
 	Protect bool `json:"protect,omitempty" yaml:"protect,omitempty"`
 	// External is set to true when the lifecycle of this resource is not managed by Pulumi.
 	External bool `json:"external,omitempty" yaml:"external,omitempty"`
+	// RetainOnDelete is set to true when this resource should be dropped from the deployment, rather than deleted
+	// from its provider, when it is no longer needed.
+	RetainOnDelete bool `json:"retainOnDelete,omitempty" yaml:"retainOnDelete,omitempty"`
+	// Retries is the number of times to retry operations on this resource that fail transiently (0 for the default,
+	// <0 for none).
+	Retries int `json:"retries,omitempty" yaml:"retries,omitempty"`
 	// Dependencies contains the dependency edges to other resources that this depends on.
 	Dependencies []resource.URN `json:"dependencies" yaml:"dependencies,omitempty"`
 	// InitErrors is the set of errors encountered in the process of initializing resource (i.e.,
@@ -211,6 +219,9 @@ const (
 	// GitHubRepositoryNameTag is a tag that represents the name of a repository on GitHub that this stack
 	// may be associated with (inferred by the CLI based on git remote info).
 	GitHubRepositoryNameTag StackTagName = "gitHub:repo"
+	// GitRemoteTag is a tag that represents the URL of the git remote "origin" that this stack may be associated
+	// with (inferred by the CLI based on git remote info, with any credentials removed).
+	GitRemoteTag StackTagName = "git:remote"
 )
 
 // Stack describes a Stack running on a Pulumi Cloud.
@@ -0,0 +1,193 @@
+// Copyright 2016-2018, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package apitype
+
+// EngineEventSchemaVersionCurrent is the current version of the `EngineEvent` schema.
+const EngineEventSchemaVersionCurrent = 1
+
+// EngineEvent is the serialized form of an event emitted by the engine during an update.  Exactly one of the
+// event-specific fields is set.
+//
+// Should generally mirror engine.Event, but we clone it in this package to add flexibility in case there is a
+// breaking change in the engine-type.
+type EngineEvent struct {
+	// Version is the version of the EngineEvent schema this event conforms to.
+	Version int `json:"version"`
+	// Sequence is a unique, increasing number identifying this event within the update.
+	Sequence int `json:"sequence"`
+	// Timestamp is the Unix time, in seconds, at which the event was recorded.
+	Timestamp int64 `json:"timestamp"`
+
+	StdoutEvent      *StdoutEngineEvent `json:"stdoutEvent,omitempty"`
+	DiagnosticEvent  *DiagnosticEvent   `json:"diagnosticEvent,omitempty"`
+	PreludeEvent     *PreludeEvent      `json:"preludeEvent,omitempty"`
+	SummaryEvent     *SummaryEvent      `json:"summaryEvent,omitempty"`
+	ResourcePreEvent *ResourcePreEvent  `json:"resourcePreEvent,omitempty"`
+	ResOutputsEvent  *ResOutputsEvent   `json:"resOutputsEvent,omitempty"`
+	ResOpFailedEvent *ResOpFailedEvent  `json:"resOpFailedEvent,omitempty"`
+	ResDriftEvent    *ResDriftEvent     `json:"resDriftEvent,omitempty"`
+}
+
+// StdoutEngineEvent is emitted whenever a generic message is written, for example warnings from the pulumi CLI
+// itself.  Less common than DiagnosticEvent.
+type StdoutEngineEvent struct {
+	// Message is the message, without any color codes.
+	Message string `json:"message"`
+}
+
+// DiagnosticEvent is emitted whenever a diagnostic message is provided, for example errors from a cloud resource
+// provider while trying to create or update a resource.
+type DiagnosticEvent struct {
+	// URN is the resource the diagnostic is associated with, if any.
+	URN string `json:"urn,omitempty"`
+	// Prefix is the prefix to display before the message, without any color codes.
+	Prefix string `json:"prefix,omitempty"`
+	// Message is the message, without any color codes.
+	Message string `json:"message"`
+	// Severity is one of "debug", "info", "info#err", "warning", or "error".
+	Severity string `json:"severity"`
+	// StreamID identifies a stream of related diagnostics, if non-zero.
+	StreamID int `json:"streamID,omitempty"`
+}
+
+// PreludeEvent is emitted at the start of an update.
+type PreludeEvent struct {
+	// Preview is true if the update is a preview.
+	Preview bool `json:"preview"`
+	// Config contains the keys and values of the stack's configuration.  Secret values are blinded.
+	Config map[string]string `json:"config"`
+}
+
+// SummaryEvent is emitted at the end of an update, with a summary of the changes made.
+type SummaryEvent struct {
+	// Preview is true if the update was a preview.
+	Preview bool `json:"preview"`
+	// MaybeCorrupt is set if one or more of the resources is in an invalid state.
+	MaybeCorrupt bool `json:"maybeCorrupt"`
+	// DurationSeconds is the number of seconds the update was executing.
+	DurationSeconds int `json:"durationSeconds"`
+	// ResourceChanges contains the count for resource change by type.  The keys are deploy.StepOp values.
+	ResourceChanges map[OpType]int `json:"resourceChanges"`
+}
+
+// DiffKind describes the kind of change made to a property.
+type DiffKind string
+
+const (
+	// DiffAdd indicates that the property was added.
+	DiffAdd DiffKind = "add"
+	// DiffAddReplace indicates that the property was added and requires that the resource be replaced.
+	DiffAddReplace DiffKind = "add-replace"
+	// DiffDelete indicates that the property was deleted.
+	DiffDelete DiffKind = "delete"
+	// DiffDeleteReplace indicates that the property was deleted and requires that the resource be replaced.
+	DiffDeleteReplace DiffKind = "delete-replace"
+	// DiffUpdate indicates that the property was updated.
+	DiffUpdate DiffKind = "update"
+	// DiffUpdateReplace indicates that the property was updated and requires that the resource be replaced.
+	DiffUpdateReplace DiffKind = "update-replace"
+)
+
+// PropertyDiff describes the difference between a single property's old and new values.
+type PropertyDiff struct {
+	// Kind is the kind of difference.
+	Kind DiffKind `json:"diffKind"`
+}
+
+// StepEventMetadata describes a "step" within the Pulumi engine, which is any concrete action to migrate a set of
+// cloud resources from one state to another.
+type StepEventMetadata struct {
+	// Op is the operation being performed.
+	Op OpType `json:"op"`
+	// URN is the resource being operated on.
+	URN string `json:"urn"`
+	// Type is the type of the resource being operated on.
+	Type string `json:"type"`
+
+	// Old is the state of the resource before performing the step.
+	Old *StepEventStateMetadata `json:"old,omitempty"`
+	// New is the state of the resource after performing the step.
+	New *StepEventStateMetadata `json:"new,omitempty"`
+
+	// Keys causes resource replacement, only applicable for "create" and "replace" Ops.
+	Keys []string `json:"keys,omitempty"`
+	// Diffs contains the top-level keys of the properties that changed, if any.
+	Diffs []string `json:"diffs,omitempty"`
+	// DetailedDiff maps property paths (for example, `tags.name` or `ports[0]`) to the differences in the values at
+	// those paths, if any.  Array indices in these paths refer to the new values, so elements deleted from arrays
+	// are recorded in DeletedElements instead.
+	DetailedDiff map[string]PropertyDiff `json:"detailedDiff,omitempty"`
+	// DeletedElements maps the paths of array elements that were deleted to their differences, if any.  Array indices
+	// in these paths refer to the old values, so they may identify different elements than the same paths in
+	// DetailedDiff.
+	DeletedElements map[string]PropertyDiff `json:"deletedElements,omitempty"`
+	// Logical is set if the step is a logical operation in the program.
+	Logical bool `json:"logical,omitempty"`
+}
+
+// StepEventStateMetadata is the more detailed state information for a resource as it relates to a step(s) being
+// performed.
+type StepEventStateMetadata struct {
+	// Type is the type of the resource.
+	Type string `json:"type"`
+	// URN is the resource's URN.
+	URN string `json:"urn"`
+	// Custom indicates if the resource is managed by a plugin.
+	Custom bool `json:"custom,omitempty"`
+	// Delete is true when the resource is pending deletion due to a replacement.
+	Delete bool `json:"delete,omitempty"`
+	// ID is the resource's unique ID, assigned by the resource provider (or blank if none/uncreated).
+	ID string `json:"id,omitempty"`
+	// Parent is an optional parent URN that this resource belongs to.
+	Parent string `json:"parent,omitempty"`
+	// Protect is true to "protect" this resource (protected resources cannot be deleted).
+	Protect bool `json:"protect,omitempty"`
+	// Inputs contains the resource's input properties (as specified by the program), serialized as they are in a
+	// checkpoint.  Values are not filtered, so they may contain sensitive data.
+	Inputs map[string]interface{} `json:"inputs"`
+	// Outputs contains the resource's complete output state (as returned by the resource provider).
+	Outputs map[string]interface{} `json:"outputs"`
+}
+
+// ResourcePreEvent is emitted before a resource is modified.
+type ResourcePreEvent struct {
+	Metadata StepEventMetadata `json:"metadata"`
+	Planning bool              `json:"planning,omitempty"`
+}
+
+// ResOutputsEvent is emitted when a resource is finished being provisioned.
+type ResOutputsEvent struct {
+	Metadata StepEventMetadata `json:"metadata"`
+	Planning bool              `json:"planning,omitempty"`
+}
+
+// ResOpFailedEvent is emitted when a resource operation fails.  Typically a DiagnosticEvent is emitted before this
+// event, indicating the root cause of the error.
+type ResOpFailedEvent struct {
+	Metadata StepEventMetadata `json:"metadata"`
+	Status   int               `json:"status"`
+	Steps    int               `json:"steps"`
+}
+
+// ResDriftEvent is emitted by a refresh when a resource's live state differs from its state in the checkpoint.  The
+// metadata's diffs describe the differences between the checkpoint's outputs and the live outputs.
+type ResDriftEvent struct {
+	Metadata StepEventMetadata `json:"metadata"`
+	// Deleted is true if the resource no longer exists.
+	Deleted bool `json:"deleted,omitempty"`
+	// Keys are the outputs whose live values differ from the checkpoint's.
+	Keys     []string `json:"keys,omitempty"`
+	Planning bool     `json:"planning,omitempty"`
+}
@@ -0,0 +1,44 @@
+// Copyright 2016-2018, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package apitype
+
+import (
+	"github.com/pulumi/pulumi/pkg/resource"
+)
+
+// PlanSchemaVersionCurrent is the current version of the `Plan` schema.  Any plans newer than this version will be
+// rejected.
+const PlanSchemaVersionCurrent = 1
+
+// PlanV1 is the serialized form of the steps computed by a preview, which a later update may be constrained to.
+type PlanV1 struct {
+	// Version is the version of the Plan schema this plan conforms to.
+	Version int `json:"version"`
+	// Resources maps the URN of each resource the preview operated on to the steps planned for it.
+	Resources map[resource.URN]ResourcePlanV1 `json:"resources"`
+}
+
+// ResourcePlanV1 describes the steps planned for a single resource.
+type ResourcePlanV1 struct {
+	// Ops contains the operations planned for the resource, in the order in which they were planned.
+	Ops []OpType `json:"ops"`
+	// OldInputs contains the resource's input properties before the update, if it already existed.
+	OldInputs map[string]interface{} `json:"oldInputs,omitempty"`
+	// NewInputs contains the resource's input properties after the update, if it is not being deleted.  Values that
+	// were unknown during the preview are recorded as the unknown string sentinel.
+	NewInputs map[string]interface{} `json:"newInputs,omitempty"`
+	// ReplaceKeys contains the properties whose changes cause the resource to be replaced, if any.
+	ReplaceKeys []string `json:"replaceKeys,omitempty"`
+}
@@ -86,6 +86,12 @@ type GetStackResponse struct {
 	Deployment json.RawMessage `json:"deployment,omitempty"`
 }
 
+// StackRenameRequest defines the request body for renaming a stack.
+type StackRenameRequest struct {
+	// The new name for the stack.  The stack's owner and project are unchanged.
+	NewName string `json:"newName"`
+}
+
 // EncryptValueRequest defines the request body for encrypting a value.
 type EncryptValueRequest struct {
 	// The value to encrypt.
@@ -68,8 +68,13 @@ type Backend interface {
 	// still contains resources.  Otherwise, if the stack contains resources, a non-nil error is returned, and the
 	// first boolean return value will be set to true.
 	RemoveStack(ctx context.Context, stackRef StackReference, force bool) (bool, error)
+	// RenameStack renames the given stack to newName, rewriting the URNs of all of its resources to match.  It
+	// returns a reference to the stack under its new name.
+	RenameStack(ctx context.Context, stackRef StackReference, newName tokens.QName) (StackReference, error)
 	// ListStacks returns a list of stack summaries for all known stacks in the target backend.
 	ListStacks(ctx context.Context, projectFilter *tokens.PackageName) ([]Stack, error)
+	// UpdateStackTags replaces the full set of tags associated with the given stack.
+	UpdateStackTags(ctx context.Context, stackRef StackReference, tags map[apitype.StackTagName]string) error
 
 	// GetStackCrypter returns an encrypter/decrypter for the given stack's secret config values.
 	GetStackCrypter(stackRef StackReference) (config.Crypter, error)
@@ -116,6 +121,8 @@ type UpdateOptions struct {
 	AutoApprove bool
 	// SkipPreview, when true, causes the preview step to be skipped.
 	SkipPreview bool
+	// PreviewOnly, when true, causes only the preview step to be performed.
+	PreviewOnly bool
 }
 
 // CancellationScope provides a scoped source of cancellation and termination requests.
@@ -549,6 +549,33 @@ func (b *cloudBackend) ListStacks(ctx context.Context, projectFilter *tokens.Pac
 	return results, nil
 }
 
+func (b *cloudBackend) UpdateStackTags(ctx context.Context, stackRef backend.StackReference,
+	tags map[apitype.StackTagName]string) error {
+
+	stack, err := b.getCloudStackIdentifier(stackRef)
+	if err != nil {
+		return err
+	}
+
+	return b.client.UpdateStackTags(ctx, stack, tags)
+}
+
+func (b *cloudBackend) RenameStack(ctx context.Context, stackRef backend.StackReference,
+	newName tokens.QName) (backend.StackReference, error) {
+
+	stack, err := b.getCloudStackIdentifier(stackRef)
+	if err != nil {
+		return nil, err
+	}
+
+	// The service takes care of rewriting the URNs in the stack's checkpoint and moving its update history.
+	if err = b.client.RenameStack(ctx, stack, string(newName)); err != nil {
+		return nil, err
+	}
+
+	return cloudBackendReference{name: newName, owner: stack.Owner, b: b}, nil
+}
+
 func (b *cloudBackend) RemoveStack(ctx context.Context, stackRef backend.StackReference, force bool) (bool, error) {
 	stack, err := b.getCloudStackIdentifier(stackRef)
 	if err != nil {
@@ -695,7 +722,7 @@ func (b *cloudBackend) PreviewThenPrompt(
 	}
 
 	// If there are no changes, or we're auto-approving or just previewing, we can skip the confirmation prompt.
-	if opts.AutoApprove || updateKind == client.UpdateKindPreview {
+	if opts.AutoApprove || opts.PreviewOnly || updateKind == client.UpdateKindPreview {
 		return changes, nil
 	}
 
@@ -768,16 +795,22 @@ func (b *cloudBackend) PreviewThenPromptThenExecute(
 	if !stack.(Stack).RunLocally() &&
 		(updateKind == client.UpdateKindDestroy || updateKind == client.UpdateKindRefresh) {
 		// The service does not support previews for PPC stacks, other than for updates.  So skip the preview.
+		if opts.PreviewOnly {
+			return nil, errors.Errorf("previewing a %s is not supported for stacks managed by the Pulumi Service",
+				updateKind)
+		}
 		opts.SkipPreview = true
 	}
 
 	// Preview the operation to the user and ask them if they want to proceed.
 	changes, err := b.PreviewThenPrompt(ctx, updateKind, stack, pkg, root, m, opts, scopes)
-	if err != nil || updateKind == client.UpdateKindPreview {
+	if err != nil || opts.PreviewOnly || updateKind == client.UpdateKindPreview {
 		return changes, err
 	}
 
-	// Now do the real operation.  We don't care about the events it issues, so just pass a nil channel along.
+	// Now do the real operation.  We don't care about the events it issues, so just pass a nil channel along.  If the
+	// preview ran, it has already reported any drift.
+	opts.Engine.Previewed = !opts.SkipPreview
 	return b.updateStack(ctx, updateKind, stack, pkg, root, m, opts, nil, false /*dryRun*/, scopes)
 }
 
@@ -837,12 +870,17 @@ func (b *cloudBackend) createAndStartUpdate(
 	}
 
 	// Start the update. We use this opportunity to pass new tags to the service, to pick up any
-	// metadata changes.
+	// metadata changes. Since the service replaces the stack's tags wholesale, we merge in the existing
+	// tags so that any user-defined tags are preserved.
 	tags, err := backend.GetStackTags()
 	if err != nil {
 		return client.UpdateIdentifier{}, 0, "", errors.Wrap(err, "getting stack tags")
 	}
-	version, token, err := b.client.StartUpdate(ctx, update, tags)
+	apistack, err := b.client.GetStack(ctx, stack)
+	if err != nil {
+		return client.UpdateIdentifier{}, 0, "", errors.Wrap(err, "getting stack tags")
+	}
+	version, token, err := b.client.StartUpdate(ctx, update, backend.MergeStackTags(apistack.Tags, tags))
 	if err != nil {
 		return client.UpdateIdentifier{}, 0, "", err
 	}
@@ -860,11 +898,22 @@ func (b *cloudBackend) updateStack(
 	callerEventsOpt chan<- engine.Event, dryRun bool,
 	scopes backend.CancellationScopeSource) (engine.ResourceChanges, error) {
 
-	// Print a banner so it's clear this is going to the cloud.
+	// Stacks managed by a PPC report their progress as text, so we can't display their events as JSON.
+	if opts.Display.JSONDisplay && !stack.(Stack).RunLocally() {
+		return nil, errors.New("JSON display is not supported for stacks managed by a PPC")
+	}
+	if (opts.Engine.Plan != nil || opts.Engine.SavePlan != nil) && !stack.(Stack).RunLocally() {
+		return nil, errors.New("saved plans are not supported for stacks managed by a PPC")
+	}
+
+	// Print a banner so it's clear this is going to the cloud.  This is omitted when displaying JSON, so that stdout
+	// contains only JSON.
 	actionLabel := getActionLabel(string(action), dryRun)
-	fmt.Printf(
-		opts.Display.Color.Colorize(colors.BrightMagenta+"%s stack '%s'"+colors.Reset+"\n"),
-		actionLabel, stack.Name())
+	if !opts.Display.JSONDisplay {
+		fmt.Printf(
+			opts.Display.Color.Colorize(colors.BrightMagenta+"%s stack '%s'"+colors.Reset+"\n"),
+			actionLabel, stack.Name())
+	}
 
 	// Create an update object (except if this won't yield an update; i.e., doing a local preview).
 	var update client.UpdateIdentifier
@@ -878,7 +927,7 @@ func (b *cloudBackend) updateStack(
 		return nil, err
 	}
 
-	if version != 0 {
+	if version != 0 && !opts.Display.JSONDisplay {
 		// Print a URL afterwards to redirect to the version URL.
 		base := b.cloudConsoleStackPath(update.StackIdentifier)
 		if link := b.CloudConsoleURL(base, "updates", strconv.Itoa(version)); link != "" {
@@ -1060,6 +1109,14 @@ func (b *cloudBackend) GetHistory(ctx context.Context, stackRef backend.StackRef
 			return nil, errors.Wrap(err, "converting configuration")
 		}
 
+		var deployment *apitype.UntypedDeployment
+		if len(update.Deployment) > 0 {
+			deployment = &apitype.UntypedDeployment{
+				Version:    apitype.DeploymentSchemaVersionCurrent,
+				Deployment: update.Deployment,
+			}
+		}
+
 		beUpdates = append(beUpdates, backend.UpdateInfo{
 			Kind:            backend.UpdateKind(update.Kind),
 			Message:         update.Message,
@@ -1069,6 +1126,8 @@ func (b *cloudBackend) GetHistory(ctx context.Context, stackRef backend.StackRef
 			StartTime:       update.StartTime,
 			EndTime:         update.EndTime,
 			ResourceChanges: convertResourceChanges(update.ResourceChanges),
+			Version:         update.Version,
+			Deployment:      deployment,
 		})
 	}
 
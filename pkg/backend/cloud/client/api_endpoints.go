@@ -87,6 +87,8 @@ func init() {
 	addEndpoint("POST", "/api/stacks/{orgName}/{stackName}/encrypt", "encryptValue")
 	addEndpoint("POST", "/api/stacks/{orgName}/{stackName}/decrypt", "decryptValue")
 	addEndpoint("GET", "/api/stacks/{orgName}/{stackName}/logs", "getStackLogs")
+	addEndpoint("POST", "/api/stacks/{orgName}/{stackName}/rename", "renameStack")
+	addEndpoint("PATCH", "/api/stacks/{orgName}/{stackName}/tags", "updateStackTags")
 	addEndpoint("GET", "/api/stacks/{orgName}/{stackName}/updates", "getStackUpdates")
 	addEndpoint("GET", "/api/stacks/{orgName}/{stackName}/updates/latest", "getLatestStackUpdate")
 	addEndpoint("GET", "/api/stacks/{orgName}/{stackName}/updates/{version}", "getStackUpdate")
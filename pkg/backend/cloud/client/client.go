@@ -239,6 +239,28 @@ func (pc *Client) CreateStack(
 	return stack, nil
 }
 
+// RenameStack renames the indicated stack to newName, within the same owner.
+func (pc *Client) RenameStack(ctx context.Context, stack StackIdentifier, newName string) error {
+	if err := backend.ValidateStackProperties(newName, nil); err != nil {
+		return errors.Wrap(err, "validating stack properties")
+	}
+
+	req := apitype.StackRenameRequest{NewName: newName}
+	return pc.restCall(ctx, "POST", getStackPath(stack, "rename"), nil, &req, nil)
+}
+
+// UpdateStackTags updates the stacks's tags, replacing all existing tags.
+func (pc *Client) UpdateStackTags(
+	ctx context.Context, stack StackIdentifier, tags map[apitype.StackTagName]string) error {
+
+	// Validate stack tags.
+	if err := backend.ValidateStackProperties(stack.Stack, tags); err != nil {
+		return errors.Wrap(err, "validating stack properties")
+	}
+
+	return pc.restCall(ctx, "PATCH", getStackPath(stack, "tags"), nil, tags, nil)
+}
+
 // DeleteStack deletes the indicated stack. If force is true, the stack is deleted even if it contains resources.
 func (pc *Client) DeleteStack(ctx context.Context, stack StackIdentifier, force bool) (bool, error) {
 	path := getStackPath(stack)
@@ -353,7 +375,7 @@ func (pc *Client) CreateUpdate(
 
 	updateRequest := apitype.UpdateProgramRequest{
 		Name:        string(pkg.Name),
-		Runtime:     pkg.Runtime,
+		Runtime:     pkg.Runtime.Name,
 		Main:        main,
 		Description: description,
 		Config:      wireConfig,
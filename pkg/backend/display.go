@@ -14,7 +14,12 @@
 
 package backend
 
-import "github.com/pulumi/pulumi/pkg/diag/colors"
+import (
+	"github.com/pkg/errors"
+
+	"github.com/pulumi/pulumi/pkg/diag/colors"
+	"github.com/pulumi/pulumi/pkg/engine"
+)
 
 // DisplayOptions controls how the output of events are rendered
 type DisplayOptions struct {
@@ -25,5 +30,57 @@ type DisplayOptions struct {
 	SummaryDiff          bool                // If the diff display should be summarized
 	IsInteractive        bool                // If we should display things interactively
 	DiffDisplay          bool                // true if we should display things as a rich diff
+	JSONDisplay          bool                // true if we should display events as JSON
+	JSONStream           bool                // true if JSON events should be written one per line as they occur
+	EventLog             EventLog            // if non-nil, receives every event, regardless of how it is displayed
+	Filter               DisplayFilter       // the resources the progress display starts out showing
 	Debug                bool
 }
+
+// DisplayFilter selects which resources the progress display shows.
+type DisplayFilter string
+
+const (
+	// DisplayFilterAll shows every resource that the display would otherwise show.
+	DisplayFilterAll DisplayFilter = "all"
+	// DisplayFilterChanges hides unchanged resources, and collapses components whose children are all unchanged.
+	DisplayFilterChanges DisplayFilter = "changes"
+	// DisplayFilterFailed shows only the resources that failed or reported errors, along with their parents.
+	DisplayFilterFailed DisplayFilter = "failed"
+)
+
+// DisplayFilters lists the display filters, in the order in which the interactive display cycles through them.
+var DisplayFilters = []DisplayFilter{DisplayFilterAll, DisplayFilterChanges, DisplayFilterFailed}
+
+// ParseDisplayFilter parses the name of a display filter.  The empty string is treated as DisplayFilterAll.
+func ParseDisplayFilter(s string) (DisplayFilter, error) {
+	if s == "" {
+		return DisplayFilterAll, nil
+	}
+	for _, filter := range DisplayFilters {
+		if DisplayFilter(s) == filter {
+			return filter, nil
+		}
+	}
+	return "", errors.Errorf("unrecognized display filter '%s'; must be one of all, changes or failed", s)
+}
+
+// EventLog records engine events for consumption by external tools.
+type EventLog interface {
+	// LogEvent records a single event.
+	LogEvent(e engine.Event) error
+}
+
+// EventLogs is an EventLog that records each event in all of the logs it contains.
+type EventLogs []EventLog
+
+// LogEvent records the event in each log, returning the first error encountered, if any.
+func (logs EventLogs) LogEvent(e engine.Event) error {
+	var result error
+	for _, log := range logs {
+		if err := log.LogEvent(e); err != nil && result == nil {
+			result = err
+		}
+	}
+	return result
+}
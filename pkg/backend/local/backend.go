@@ -35,6 +35,7 @@ import (
 	"github.com/pulumi/pulumi/pkg/operations"
 	"github.com/pulumi/pulumi/pkg/resource/config"
 	"github.com/pulumi/pulumi/pkg/resource/deploy"
+	"github.com/pulumi/pulumi/pkg/resource/edit"
 	"github.com/pulumi/pulumi/pkg/resource/stack"
 	"github.com/pulumi/pulumi/pkg/tokens"
 	"github.com/pulumi/pulumi/pkg/util/contract"
@@ -116,7 +117,7 @@ func (b *localBackend) CreateStack(ctx context.Context, stackRef backend.StackRe
 		return nil, errors.New("invalid empty stack name")
 	}
 
-	if _, _, _, err := b.getStack(stackName); err == nil {
+	if _, _, _, _, err := b.getStack(stackName); err == nil {
 		return nil, &backend.StackAlreadyExistsError{StackName: string(stackName)}
 	}
 
@@ -128,12 +129,12 @@ func (b *localBackend) CreateStack(ctx context.Context, stackRef backend.StackRe
 		return nil, errors.Wrap(err, "validating stack properties")
 	}
 
-	file, err := b.saveStack(stackName, nil, nil)
+	file, err := b.saveCheckpoint(stackName, nil, tags, nil)
 	if err != nil {
 		return nil, err
 	}
 
-	stack := newStack(stackRef, file, nil, nil, b)
+	stack := newStack(stackRef, file, nil, tags, nil, b)
 	fmt.Printf("Created stack '%s'.\n", stack.Name())
 
 	return stack, nil
@@ -141,15 +142,15 @@ func (b *localBackend) CreateStack(ctx context.Context, stackRef backend.StackRe
 
 func (b *localBackend) GetStack(ctx context.Context, stackRef backend.StackReference) (backend.Stack, error) {
 	stackName := stackRef.StackName()
-	config, snapshot, path, err := b.getStack(stackName)
+	config, tags, snapshot, path, err := b.getStack(stackName)
 	switch {
 	case os.IsNotExist(errors.Cause(err)):
 		return nil, nil
 	case err != nil:
 		return nil, err
-	default:
-		return newStack(stackRef, path, config, snapshot, b), nil
 	}
+
+	return newStack(stackRef, path, config, tags, snapshot, b), nil
 }
 
 func (b *localBackend) ListStacks(ctx context.Context, projectFilter *tokens.PackageName) ([]backend.Stack, error) {
@@ -170,9 +171,26 @@ func (b *localBackend) ListStacks(ctx context.Context, projectFilter *tokens.Pac
 	return results, nil
 }
 
+func (b *localBackend) UpdateStackTags(ctx context.Context, stackRef backend.StackReference,
+	tags map[apitype.StackTagName]string) error {
+
+	stackName := stackRef.StackName()
+	if err := backend.ValidateStackProperties(string(stackName), tags); err != nil {
+		return errors.Wrap(err, "validating stack properties")
+	}
+
+	config, _, snapshot, _, err := b.getStack(stackName)
+	if err != nil {
+		return err
+	}
+
+	_, err = b.saveCheckpoint(stackName, config, tags, snapshot)
+	return err
+}
+
 func (b *localBackend) RemoveStack(ctx context.Context, stackRef backend.StackReference, force bool) (bool, error) {
 	stackName := stackRef.StackName()
-	_, snapshot, _, err := b.getStack(stackName)
+	_, _, snapshot, _, err := b.getStack(stackName)
 	if err != nil {
 		return false, err
 	}
@@ -185,6 +203,45 @@ func (b *localBackend) RemoveStack(ctx context.Context, stackRef backend.StackRe
 	return false, b.removeStack(stackName)
 }
 
+func (b *localBackend) RenameStack(ctx context.Context, stackRef backend.StackReference,
+	newName tokens.QName) (backend.StackReference, error) {
+
+	stackName := stackRef.StackName()
+	config, tags, snapshot, _, err := b.getStack(stackName)
+	if err != nil {
+		return nil, err
+	}
+
+	// Ensure the new name is valid and not already taken.
+	if err = backend.ValidateStackProperties(string(newName), tags); err != nil {
+		return nil, errors.Wrap(err, "validating stack properties")
+	}
+	if _, err = b.getCheckpoint(newName); err == nil {
+		return nil, &backend.StackAlreadyExistsError{StackName: string(newName)}
+	} else if !os.IsNotExist(errors.Cause(err)) {
+		return nil, errors.Wrapf(err, "checking for an existing stack named '%s'", newName)
+	}
+
+	// Rewrite all of the stack's URNs to refer to the new name, and write the result out under that name.
+	if snapshot != nil {
+		edit.RenameStack(snapshot, newName)
+	}
+	if _, err = b.saveCheckpoint(newName, config, tags, snapshot); err != nil {
+		return nil, err
+	}
+
+	// Now retire the old checkpoint (leaving a backup behind, as with removal), and move any history and backups.
+	backupTarget(b.stackPath(stackName))
+	if err = renameIfExists(b.historyDirectory(stackName), b.historyDirectory(newName)); err != nil {
+		return nil, errors.Wrap(err, "moving stack history")
+	}
+	if err = renameIfExists(b.backupDirectory(stackName), b.backupDirectory(newName)); err != nil {
+		return nil, errors.Wrap(err, "moving stack backups")
+	}
+
+	return localBackendReference{name: newName}, nil
+}
+
 func (b *localBackend) GetStackCrypter(stackRef backend.StackReference) (config.Crypter, error) {
 	return symmetricCrypter(stackRef.StackName())
 }
@@ -211,22 +268,46 @@ func (b *localBackend) Preview(
 }
 
 func (b *localBackend) Update(
-	_ context.Context, stackRef backend.StackReference, proj *workspace.Project, root string, m backend.UpdateMetadata,
-	opts backend.UpdateOptions, scopes backend.CancellationScopeSource) (engine.ResourceChanges, error) {
+	ctx context.Context, stackRef backend.StackReference, proj *workspace.Project, root string,
+	m backend.UpdateMetadata, opts backend.UpdateOptions,
+	scopes backend.CancellationScopeSource) (engine.ResourceChanges, error) {
 
-	// The Pulumi Service will pick up changes to a stack's tags on each update. (e.g. changing the description
-	// in Pulumi.yaml.) While this isn't necessary for local updates, we do the validation here to keep
-	// parity with stacks managed by the Pulumi Service.
+	// Like the Pulumi Service, pick up changes to a stack's computed tags on each update (e.g. changing the
+	// description in Pulumi.yaml), while preserving any tags the user has set explicitly.
 	tags, err := backend.GetStackTags()
 	if err != nil {
 		return nil, errors.Wrap(err, "getting stack tags")
 	}
-	stackName := stackRef.StackName()
-	if err = backend.ValidateStackProperties(string(stackName), tags); err != nil {
-		return nil, errors.Wrap(err, "validating stack properties")
+	if err = b.refreshStackTags(ctx, stackRef, tags); err != nil {
+		return nil, errors.Wrap(err, "updating stack tags")
 	}
+
 	return b.performEngineOp("updating", backend.DeployUpdate,
-		stackName, proj, root, m, opts, scopes, engine.Update)
+		stackRef.StackName(), proj, root, m, opts, scopes, engine.Update)
+}
+
+// refreshStackTags merges the given computed tags into the stack's existing ones, saving them only if they changed.
+func (b *localBackend) refreshStackTags(ctx context.Context, stackRef backend.StackReference,
+	computed map[apitype.StackTagName]string) error {
+
+	existing, err := b.getStackTags(stackRef.StackName())
+	if err != nil {
+		return err
+	}
+	merged := backend.MergeStackTags(existing, computed)
+	if len(merged) == len(existing) {
+		changed := false
+		for k, v := range merged {
+			if old, has := existing[k]; !has || old != v {
+				changed = true
+				break
+			}
+		}
+		if !changed {
+			return nil
+		}
+	}
+	return b.UpdateStackTags(ctx, stackRef, merged)
 }
 
 func (b *localBackend) Refresh(
@@ -255,7 +336,7 @@ func (b *localBackend) performEngineOp(op string, kind backend.UpdateKind,
 	}
 
 	events := make(chan engine.Event)
-	dryRun := (kind == backend.PreviewUpdate)
+	dryRun := (kind == backend.PreviewUpdate || opts.PreviewOnly)
 
 	cancelScope := scopes.NewScope(events, dryRun)
 	defer cancelScope.Close()
@@ -358,7 +439,7 @@ func (b *localBackend) ExportDeployment(ctx context.Context,
 	stackRef backend.StackReference) (*apitype.UntypedDeployment, error) {
 
 	stackName := stackRef.StackName()
-	_, snap, _, err := b.getStack(stackName)
+	_, _, snap, _, err := b.getStack(stackName)
 	if err != nil {
 		return nil, err
 	}
@@ -382,7 +463,7 @@ func (b *localBackend) ImportDeployment(ctx context.Context, stackRef backend.St
 	deployment *apitype.UntypedDeployment) error {
 
 	stackName := stackRef.StackName()
-	config, _, _, err := b.getStack(stackName)
+	config, tags, _, _, err := b.getStack(stackName)
 	if err != nil {
 		return err
 	}
@@ -392,7 +473,7 @@ func (b *localBackend) ImportDeployment(ctx context.Context, stackRef backend.St
 		return err
 	}
 
-	_, err = b.saveStack(stackName, config, snap)
+	_, err = b.saveCheckpoint(stackName, config, tags, snap)
 	return err
 }
 
@@ -430,7 +511,7 @@ func (b *localBackend) getLocalStacks() ([]tokens.QName, error) {
 
 		// Read in this stack's information.
 		name := tokens.QName(stackfn[:len(stackfn)-len(ext)])
-		_, _, _, err := b.getStack(name)
+		_, _, _, _, err := b.getStack(name)
 		if err != nil {
 			logging.V(5).Infof("error reading stack: %v (%v) skipping", name, err)
 			continue // failure reading the stack information.
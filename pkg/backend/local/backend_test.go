@@ -0,0 +1,149 @@
+// Copyright 2016-2018, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package local
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/pulumi/pulumi/pkg/apitype"
+	"github.com/pulumi/pulumi/pkg/backend"
+	"github.com/pulumi/pulumi/pkg/tokens"
+)
+
+func TestStackTagsPersistAcrossUpdates(t *testing.T) {
+	root, err := ioutil.TempDir("", "local-backend-test")
+	if !assert.NoError(t, err) {
+		return
+	}
+	defer os.RemoveAll(root)
+	b := &localBackend{stateRoot: root}
+	name := tokens.QName("test")
+	ref := localBackendReference{name: name}
+	ctx := context.Background()
+
+	_, err = b.saveCheckpoint(name, nil, nil, nil)
+	assert.NoError(t, err)
+	assert.NoError(t, b.UpdateStackTags(ctx, ref, map[apitype.StackTagName]string{
+		"owner":                "me",
+		apitype.ProjectNameTag: "old",
+	}))
+
+	// An update refreshes the computed tags and keeps the user's.
+	computed := map[apitype.StackTagName]string{apitype.ProjectNameTag: "proj"}
+	assert.NoError(t, b.refreshStackTags(ctx, ref, computed))
+	tags, err := b.getStackTags(name)
+	assert.NoError(t, err)
+	assert.Equal(t, map[apitype.StackTagName]string{"owner": "me", apitype.ProjectNameTag: "proj"}, tags)
+
+	// An update that changes no tags doesn't rewrite the checkpoint.
+	then := time.Now().Add(-time.Hour).Truncate(time.Second)
+	assert.NoError(t, os.Chtimes(b.stackPath(name), then, then))
+	assert.NoError(t, b.refreshStackTags(ctx, ref, computed))
+	info, err := os.Stat(b.stackPath(name))
+	if assert.NoError(t, err) {
+		assert.True(t, info.ModTime().Equal(then))
+	}
+	tags, err = b.getStackTags(name)
+	assert.NoError(t, err)
+	assert.Equal(t, "me", tags["owner"])
+}
+
+func TestRemovedComputedStackTagsAreDropped(t *testing.T) {
+	root, err := ioutil.TempDir("", "local-backend-test")
+	if !assert.NoError(t, err) {
+		return
+	}
+	defer os.RemoveAll(root)
+	b := &localBackend{stateRoot: filepath.Join(root, "state")}
+	name := tokens.QName("test")
+	ref := localBackendReference{name: name}
+	ctx := context.Background()
+
+	// GetStackTags reads the project in the current directory.
+	cwd, err := os.Getwd()
+	if !assert.NoError(t, err) {
+		return
+	}
+	defer func() { assert.NoError(t, os.Chdir(cwd)) }()
+	projDir := filepath.Join(root, "proj")
+	assert.NoError(t, os.MkdirAll(projDir, 0700))
+	assert.NoError(t, os.Chdir(projDir))
+
+	update := func(project string) map[apitype.StackTagName]string {
+		assert.NoError(t, ioutil.WriteFile(filepath.Join(projDir, "Pulumi.yaml"), []byte(project), 0600))
+		computed, err := backend.GetStackTags()
+		assert.NoError(t, err)
+		assert.NoError(t, b.refreshStackTags(ctx, ref, computed))
+		tags, err := b.getStackTags(name)
+		assert.NoError(t, err)
+		return tags
+	}
+
+	_, err = b.saveCheckpoint(name, nil, nil, nil)
+	assert.NoError(t, err)
+	assert.NoError(t, b.UpdateStackTags(ctx, ref, map[apitype.StackTagName]string{"owner": "me"}))
+
+	tags := update("name: proj\nruntime: nodejs\ndescription: A project\n")
+	assert.Equal(t, "A project", tags[apitype.ProjectDescriptionTag])
+
+	// Once the description is removed from Pulumi.yaml, the next update removes its tag, but keeps the user's.
+	tags = update("name: proj\nruntime: nodejs\n")
+	_, has := tags[apitype.ProjectDescriptionTag]
+	assert.False(t, has)
+	assert.Equal(t, "proj", tags[apitype.ProjectNameTag])
+	assert.Equal(t, "me", tags["owner"])
+}
+
+func TestRenameStackOntoUnreadableStack(t *testing.T) {
+	root, err := ioutil.TempDir("", "local-backend-test")
+	if !assert.NoError(t, err) {
+		return
+	}
+	defer os.RemoveAll(root)
+	b := &localBackend{stateRoot: root}
+	ctx := context.Background()
+
+	_, err = b.saveCheckpoint("old", nil, nil, nil)
+	assert.NoError(t, err)
+
+	// A stack that exists is never overwritten, even if its checkpoint can't be read.
+	_, err = b.saveCheckpoint("taken", nil, nil, nil)
+	assert.NoError(t, err)
+	_, err = b.RenameStack(ctx, localBackendReference{name: "old"}, "taken")
+	assert.IsType(t, &backend.StackAlreadyExistsError{}, err)
+
+	assert.NoError(t, ioutil.WriteFile(b.stackPath("taken"), []byte("{not json"), 0600))
+	_, err = b.RenameStack(ctx, localBackendReference{name: "old"}, "taken")
+	if assert.Error(t, err) {
+		_, exists := err.(*backend.StackAlreadyExistsError)
+		assert.False(t, exists)
+	}
+	contents, err := ioutil.ReadFile(b.stackPath("taken"))
+	assert.NoError(t, err)
+	assert.Equal(t, "{not json", string(contents))
+
+	// A stack that doesn't exist yet can be renamed onto.
+	ref, err := b.RenameStack(ctx, localBackendReference{name: "old"}, "new")
+	if assert.NoError(t, err) {
+		assert.Equal(t, tokens.QName("new"), ref.StackName())
+	}
+}
@@ -22,6 +22,7 @@ import (
 	"os"
 	"sort"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/pulumi/pulumi/pkg/backend"
@@ -41,7 +42,13 @@ func DisplayEvents(
 	action string, events <-chan engine.Event,
 	done chan<- bool, opts backend.DisplayOptions) {
 
-	if opts.DiffDisplay {
+	if opts.EventLog != nil {
+		events = teeEventsToLog(events, opts.EventLog)
+	}
+
+	if opts.JSONDisplay {
+		DisplayJSONEvents(events, done, opts)
+	} else if opts.DiffDisplay {
 		DisplayDiffEvents(action, events, done, opts)
 	} else {
 		// in progress display, we can't show separate create/delete for a single resource.
@@ -127,6 +134,8 @@ func RenderDiffEvent(
 		return renderResourceOutputsEvent(event.Payload.(engine.ResourceOutputsEventPayload), seen, opts)
 	case engine.ResourcePreEvent:
 		return renderResourcePreEvent(event.Payload.(engine.ResourcePreEventPayload), seen, opts)
+	case engine.ResourceDriftEvent:
+		return renderResourceDriftEvent(event.Payload.(engine.ResourceDriftEventPayload), opts)
 	case engine.StdoutColorEvent:
 		return renderStdoutColorEvent(event.Payload.(engine.StdoutEventPayload), opts)
 	case engine.DiagEvent:
@@ -296,6 +305,24 @@ func renderResourceOutputsEvent(
 	return out.String()
 }
 
+// renderResourceDriftEvent notes that a refresh found a resource's live state to differ from the checkpoint.  The
+// differences themselves have already been shown for the resource's step.
+func renderResourceDriftEvent(payload engine.ResourceDriftEventPayload, opts backend.DisplayOptions) string {
+	var description string
+	if payload.Deleted {
+		description = "no longer exists"
+	} else {
+		keys := make([]string, len(payload.Keys))
+		for i, k := range payload.Keys {
+			keys[i] = string(k)
+		}
+		description = fmt.Sprintf("has drifted (%s)", strings.Join(keys, ", "))
+	}
+
+	return opts.Color.Colorize(fmt.Sprintf("%vdrift%v: %s %s\n",
+		colors.SpecWarning, colors.Reset, payload.Metadata.URN.Name(), description))
+}
+
 // isRootStack returns true if the step pertains to the rootmost stack component.
 func isRootStack(step engine.StepEventMetadata) bool {
 	return isRootURN(step.URN)
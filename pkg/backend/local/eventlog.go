@@ -0,0 +1,182 @@
+// Copyright 2016-2018, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package local
+
+import (
+	"encoding/json"
+	"io"
+	"net"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/pulumi/pulumi/pkg/backend"
+	"github.com/pulumi/pulumi/pkg/engine"
+	"github.com/pulumi/pulumi/pkg/util/logging"
+)
+
+// eventLogQueueSize is the number of encoded events that may be waiting to be written to an event log before the
+// log is considered to have stalled.
+const eventLogQueueSize = 1024
+
+// eventLogWriteTimeout bounds each write to an event log socket, so that a consumer that stops reading can't hold
+// the log open indefinitely.
+var eventLogWriteTimeout = 10 * time.Second
+
+// FileEventLog is a backend.EventLog that writes events as newline-delimited JSON, in the same schema used by the
+// JSON display, to a file or a Unix domain socket.  Sequence numbers increase across all of the operations that are
+// logged, so a single log may record, for example, both a preview and the update that follows it.
+//
+// Events are written by a separate goroutine, so that a slow consumer never blocks the operation being logged.  If a
+// write fails, or the consumer falls too far behind, the log is turned off and later events are dropped.
+type FileEventLog struct {
+	w        io.WriteCloser
+	sequence int
+	queue    chan []byte   // encoded events waiting to be written.
+	done     chan struct{} // closed once the writer has finished.
+	failed   bool          // true once the log has been turned off.
+	closed   bool          // true once the queue has been closed.
+	m        sync.Mutex
+}
+
+var _ backend.EventLog = (*FileEventLog)(nil)
+
+// OpenEventLog opens an event log at the given path.  If the path refers to an existing Unix domain socket, the log
+// connects to it; otherwise, the file at the path is created or truncated.
+func OpenEventLog(path string) (*FileEventLog, error) {
+	var w io.WriteCloser
+	if info, err := os.Stat(path); err == nil && info.Mode()&os.ModeSocket != 0 {
+		conn, err := net.Dial("unix", path)
+		if err != nil {
+			return nil, errors.Wrapf(err, "connecting to event log socket %s", path)
+		}
+		w = conn
+	} else {
+		f, err := os.Create(path)
+		if err != nil {
+			return nil, errors.Wrapf(err, "creating event log %s", path)
+		}
+		w = f
+	}
+
+	l := &FileEventLog{
+		w:     w,
+		queue: make(chan []byte, eventLogQueueSize),
+		done:  make(chan struct{}),
+	}
+	go l.write()
+	return l, nil
+}
+
+// write writes each queued event to the underlying file or socket until the queue is closed.
+func (l *FileEventLog) write() {
+	defer close(l.done)
+
+	conn, isConn := l.w.(net.Conn)
+	for line := range l.queue {
+		if l.isFailed() {
+			continue // drain the queue so that Close doesn't wait on a broken log.
+		}
+		if isConn {
+			if err := conn.SetWriteDeadline(time.Now().Add(eventLogWriteTimeout)); err != nil {
+				l.fail(err)
+				continue
+			}
+		}
+		if _, err := l.w.Write(line); err != nil {
+			l.fail(err)
+		}
+	}
+}
+
+func (l *FileEventLog) isFailed() bool {
+	l.m.Lock()
+	defer l.m.Unlock()
+	return l.failed
+}
+
+// fail turns the log off after a write error.
+func (l *FileEventLog) fail(err error) {
+	l.m.Lock()
+	defer l.m.Unlock()
+	if !l.failed {
+		l.failed = true
+		logging.V(3).Infof("failed to write to event log, no further events will be logged: %v", err)
+	}
+}
+
+// LogEvent queues a single event to be written to the log, stamped with the next sequence number and the current
+// time.  Once the log has been turned off, events are silently dropped.
+func (l *FileEventLog) LogEvent(e engine.Event) error {
+	// Log everything, including debug diagnostics; consumers can filter as they see fit.
+	apiEvent, ok := ConvertEngineEvent(e, backend.DisplayOptions{Debug: true})
+	if !ok {
+		return nil
+	}
+
+	l.m.Lock()
+	defer l.m.Unlock()
+	if l.failed || l.closed {
+		return nil
+	}
+
+	apiEvent.Sequence = l.sequence + 1
+	apiEvent.Timestamp = time.Now().Unix()
+	line, err := json.Marshal(apiEvent)
+	if err != nil {
+		return err
+	}
+
+	select {
+	case l.queue <- append(line, '\n'):
+		l.sequence++
+		return nil
+	default:
+		l.failed = true
+		return errors.New("event log consumer is not keeping up; no further events will be logged")
+	}
+}
+
+// Close waits for any queued events to be written, then closes the underlying file or socket.
+func (l *FileEventLog) Close() error {
+	l.m.Lock()
+	if !l.closed {
+		l.closed = true
+		close(l.queue)
+	}
+	l.m.Unlock()
+
+	<-l.done
+	return l.w.Close()
+}
+
+// teeEventsToLog returns a channel that receives each of the given events after it has been logged.  The returned
+// channel is closed once the given channel is closed.
+func teeEventsToLog(events <-chan engine.Event, log backend.EventLog) <-chan engine.Event {
+	out := make(chan engine.Event)
+	go func() {
+		defer close(out)
+		for e := range events {
+			// A broken event log shouldn't interrupt the operation it is recording.
+			if err := log.LogEvent(e); err != nil {
+				logging.V(3).Infof("failed to write to event log: %v", err)
+			}
+			out <- e
+		}
+	}()
+	return out
+}
@@ -0,0 +1,320 @@
+// Copyright 2016-2018, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package local
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"regexp"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/pulumi/pulumi/pkg/apitype"
+	"github.com/pulumi/pulumi/pkg/backend"
+	"github.com/pulumi/pulumi/pkg/diag"
+	"github.com/pulumi/pulumi/pkg/diag/colors"
+	"github.com/pulumi/pulumi/pkg/engine"
+	"github.com/pulumi/pulumi/pkg/resource"
+	"github.com/pulumi/pulumi/pkg/resource/stack"
+	"github.com/pulumi/pulumi/pkg/util/contract"
+)
+
+// DisplayJSONEvents displays the engine events as JSON.  If opts.JSONStream is set, each event is written on its own
+// line as soon as it is received; otherwise, all of the events are written as a single JSON array once the channel
+// is closed.
+func DisplayJSONEvents(events <-chan engine.Event, done chan<- bool, opts backend.DisplayOptions) {
+	defer func() {
+		done <- true
+	}()
+
+	var all []apitype.EngineEvent
+	sequence := 0
+	for event := range events {
+		if event.Type == engine.CancelEvent {
+			break
+		}
+
+		apiEvent, ok := ConvertEngineEvent(event, opts)
+		if !ok {
+			continue
+		}
+		sequence++
+		apiEvent.Sequence = sequence
+		apiEvent.Timestamp = time.Now().Unix()
+
+		if opts.JSONStream {
+			b, err := json.Marshal(apiEvent)
+			contract.IgnoreError(err)
+			fprintfIgnoreError(os.Stdout, "%s\n", b)
+		} else {
+			all = append(all, apiEvent)
+		}
+	}
+
+	if !opts.JSONStream {
+		if all == nil {
+			all = []apitype.EngineEvent{}
+		}
+		b, err := json.MarshalIndent(all, "", "    ")
+		contract.IgnoreError(err)
+		fprintfIgnoreError(os.Stdout, "%s\n", b)
+	}
+}
+
+// ConvertEngineEvent converts an engine event into its serialized form, leaving its sequence number and timestamp
+// unset.  It returns false if the event has no serialized form, or should not be shown given the display options.
+func ConvertEngineEvent(e engine.Event, opts backend.DisplayOptions) (apitype.EngineEvent, bool) {
+	apiEvent := apitype.EngineEvent{Version: apitype.EngineEventSchemaVersionCurrent}
+
+	switch e.Type {
+	case engine.CancelEvent:
+		return apitype.EngineEvent{}, false
+
+	case engine.StdoutColorEvent:
+		p := e.Payload.(engine.StdoutEventPayload)
+		apiEvent.StdoutEvent = &apitype.StdoutEngineEvent{
+			Message: colors.Never.Colorize(p.Message),
+		}
+
+	case engine.DiagEvent:
+		p := e.Payload.(engine.DiagEventPayload)
+		if p.Severity == diag.Debug && !opts.Debug {
+			return apitype.EngineEvent{}, false
+		}
+		apiEvent.DiagnosticEvent = &apitype.DiagnosticEvent{
+			URN:      string(p.URN),
+			Prefix:   colors.Never.Colorize(p.Prefix),
+			Message:  colors.Never.Colorize(p.Message),
+			Severity: string(p.Severity),
+			StreamID: int(p.StreamID),
+		}
+
+	case engine.PreludeEvent:
+		p := e.Payload.(engine.PreludeEventPayload)
+		cfg := make(map[string]string)
+		for k, v := range p.Config {
+			cfg[k] = v
+		}
+		apiEvent.PreludeEvent = &apitype.PreludeEvent{
+			Preview: p.IsPreview,
+			Config:  cfg,
+		}
+
+	case engine.SummaryEvent:
+		p := e.Payload.(engine.SummaryEventPayload)
+		changes := make(map[apitype.OpType]int)
+		for op, count := range p.ResourceChanges {
+			changes[apitype.OpType(op)] = count
+		}
+		apiEvent.SummaryEvent = &apitype.SummaryEvent{
+			Preview:         p.IsPreview,
+			MaybeCorrupt:    p.MaybeCorrupt,
+			DurationSeconds: int(p.Duration.Seconds()),
+			ResourceChanges: changes,
+		}
+
+	case engine.ResourcePreEvent:
+		p := e.Payload.(engine.ResourcePreEventPayload)
+		apiEvent.ResourcePreEvent = &apitype.ResourcePreEvent{
+			Metadata: convertStepEventMetadata(p.Metadata),
+			Planning: p.Planning,
+		}
+
+	case engine.ResourceOutputsEvent:
+		p := e.Payload.(engine.ResourceOutputsEventPayload)
+		apiEvent.ResOutputsEvent = &apitype.ResOutputsEvent{
+			Metadata: convertStepEventMetadata(p.Metadata),
+			Planning: p.Planning,
+		}
+
+	case engine.ResourceOperationFailed:
+		p := e.Payload.(engine.ResourceOperationFailedPayload)
+		apiEvent.ResOpFailedEvent = &apitype.ResOpFailedEvent{
+			Metadata: convertStepEventMetadata(p.Metadata),
+			Status:   int(p.Status),
+			Steps:    p.Steps,
+		}
+
+	case engine.ResourceDriftEvent:
+		p := e.Payload.(engine.ResourceDriftEventPayload)
+		apiEvent.ResDriftEvent = &apitype.ResDriftEvent{
+			Metadata: convertStepEventMetadata(p.Metadata),
+			Deleted:  p.Deleted,
+			Keys:     convertPropertyKeys(p.Keys),
+			Planning: p.Planning,
+		}
+
+	default:
+		contract.Failf("unknown event type '%s'", e.Type)
+	}
+
+	return apiEvent, true
+}
+
+// convertStepEventMetadata converts the metadata for a step into its serialized form, including the differences
+// between the old and new states of the resource, if both are present.
+func convertStepEventMetadata(md engine.StepEventMetadata) apitype.StepEventMetadata {
+	result := apitype.StepEventMetadata{
+		Op:      apitype.OpType(md.Op),
+		URN:     string(md.URN),
+		Type:    string(md.Type),
+		Old:     convertStepEventStateMetadata(md.Old),
+		New:     convertStepEventStateMetadata(md.New),
+		Keys:    convertPropertyKeys(md.Keys),
+		Logical: md.Logical,
+	}
+
+	// Like the diff display, compare outputs if the new state has them, and inputs otherwise.
+	if md.Old != nil && md.New != nil {
+		olds, news := md.Old.Inputs, md.New.Inputs
+		if len(md.New.Outputs) > 0 {
+			olds, news = md.Old.Outputs, md.New.Outputs
+		}
+		if diff := olds.Diff(news); diff != nil {
+			replaces := make(map[resource.PropertyKey]bool)
+			for _, k := range md.Keys {
+				replaces[k] = true
+			}
+
+			diffs := detailedDiff{
+				changes: make(map[string]apitype.PropertyDiff),
+				deletes: make(map[string]apitype.PropertyDiff),
+			}
+			for _, k := range diff.Keys() {
+				if diff.Same(k) {
+					continue
+				}
+				result.Diffs = append(result.Diffs, string(k))
+				path := propertyPath("", string(k))
+				diffs.addPropertyDiffs(path, path, *diff, k, replaces[k])
+			}
+			sort.Strings(result.Diffs)
+
+			result.DetailedDiff = diffs.changes
+			if len(diffs.deletes) > 0 {
+				result.DeletedElements = diffs.deletes
+			}
+		}
+	}
+
+	return result
+}
+
+// convertPropertyKeys converts a list of property keys into their serialized form.
+func convertPropertyKeys(keys []resource.PropertyKey) []string {
+	result := make([]string, len(keys))
+	for i, k := range keys {
+		result[i] = string(k)
+	}
+	return result
+}
+
+func convertStepEventStateMetadata(md *engine.StepEventStateMetadata) *apitype.StepEventStateMetadata {
+	if md == nil {
+		return nil
+	}
+
+	return &apitype.StepEventStateMetadata{
+		Type:    string(md.Type),
+		URN:     string(md.URN),
+		Custom:  md.Custom,
+		Delete:  md.Delete,
+		ID:      string(md.ID),
+		Parent:  string(md.Parent),
+		Protect: md.Protect,
+		Inputs:  stack.SerializeProperties(md.Inputs),
+		Outputs: stack.SerializeProperties(md.Outputs),
+	}
+}
+
+// detailedDiff collects the differences between two values, property by property and element by element.
+type detailedDiff struct {
+	changes map[string]apitype.PropertyDiff // differences, by their path in the new value.
+	deletes map[string]apitype.PropertyDiff // array elements that were deleted, by their path in the old value.
+}
+
+// addPropertyDiffs records the differences for the property k of an object diff, whose paths in the old and new
+// values are oldPath and newPath.  Objects and arrays that were updated are recorded element by element.
+func (d detailedDiff) addPropertyDiffs(oldPath, newPath string, diff resource.ObjectDiff,
+	k resource.PropertyKey, replace bool) {
+
+	if _, isadd := diff.Adds[k]; isadd {
+		d.changes[newPath] = newPropertyDiff(apitype.DiffAdd, replace)
+	} else if _, isdelete := diff.Deletes[k]; isdelete {
+		d.changes[newPath] = newPropertyDiff(apitype.DiffDelete, replace)
+	} else if update, isupdate := diff.Updates[k]; isupdate {
+		d.addValueDiffs(oldPath, newPath, update, replace)
+	}
+}
+
+func (d detailedDiff) addValueDiffs(oldPath, newPath string, diff resource.ValueDiff, replace bool) {
+	switch {
+	case diff.Object != nil:
+		for _, k := range diff.Object.Keys() {
+			d.addPropertyDiffs(propertyPath(oldPath, string(k)), propertyPath(newPath, string(k)), *diff.Object, k,
+				replace)
+		}
+	case diff.Array != nil:
+		// Adds, moves and updates are identified by their index in the new array, and deletes by their index in the
+		// old array.
+		oldIndices := make(map[int]int)
+		for _, edit := range diff.Array.Edits {
+			if edit.Old >= 0 && edit.New >= 0 {
+				oldIndices[edit.New] = edit.Old
+			}
+		}
+		for i := range diff.Array.Adds {
+			d.changes[fmt.Sprintf("%s[%d]", newPath, i)] = newPropertyDiff(apitype.DiffAdd, replace)
+		}
+		for i := range diff.Array.Moves {
+			d.changes[fmt.Sprintf("%s[%d]", newPath, i)] = newPropertyDiff(apitype.DiffUpdate, replace)
+		}
+		for i, update := range diff.Array.Updates {
+			old, has := oldIndices[i]
+			if !has {
+				old = i
+			}
+			d.addValueDiffs(fmt.Sprintf("%s[%d]", oldPath, old), fmt.Sprintf("%s[%d]", newPath, i), update, replace)
+		}
+		for i := range diff.Array.Deletes {
+			d.deletes[fmt.Sprintf("%s[%d]", oldPath, i)] = newPropertyDiff(apitype.DiffDelete, replace)
+		}
+	default:
+		d.changes[newPath] = newPropertyDiff(apitype.DiffUpdate, replace)
+	}
+}
+
+func newPropertyDiff(kind apitype.DiffKind, replace bool) apitype.PropertyDiff {
+	if replace {
+		kind += "-replace"
+	}
+	return apitype.PropertyDiff{Kind: kind}
+}
+
+// simplePropertyKeyRegexp matches property keys that can appear unquoted in property paths.
+var simplePropertyKeyRegexp = regexp.MustCompile("^[A-Za-z_][A-Za-z0-9_]*$")
+
+// propertyPath appends the property key to the path, quoting it if it isn't a simple name.
+func propertyPath(path string, key string) string {
+	if !simplePropertyKeyRegexp.MatchString(key) {
+		return fmt.Sprintf("%s[%s]", path, strconv.Quote(key))
+	}
+	if path == "" {
+		return key
+	}
+	return path + "." + key
+}
@@ -0,0 +1,274 @@
+// Copyright 2016-2018, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package local
+
+import (
+	"bufio"
+	"encoding/json"
+	"io/ioutil"
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/pulumi/pulumi/pkg/apitype"
+	"github.com/pulumi/pulumi/pkg/backend"
+	"github.com/pulumi/pulumi/pkg/diag"
+	"github.com/pulumi/pulumi/pkg/diag/colors"
+	"github.com/pulumi/pulumi/pkg/engine"
+	"github.com/pulumi/pulumi/pkg/resource"
+	"github.com/pulumi/pulumi/pkg/resource/deploy"
+	"github.com/pulumi/pulumi/pkg/util/contract"
+)
+
+func TestConvertStepEventMetadata(t *testing.T) {
+	olds := resource.NewPropertyMapFromMap(map[string]interface{}{
+		"name":    "a",
+		"tags":    map[string]interface{}{"env": "dev", "team": "x"},
+		"ports":   []interface{}{80, 443},
+		"a.b":     "old",
+		"removed": true,
+	})
+	news := resource.NewPropertyMapFromMap(map[string]interface{}{
+		"name":  "b",
+		"tags":  map[string]interface{}{"env": "prod", "owner": "y"},
+		"ports": []interface{}{80, 8443, 9000},
+		"a.b":   "new",
+	})
+
+	md := convertStepEventMetadata(engine.StepEventMetadata{
+		Op:   deploy.OpReplace,
+		URN:  "urn:pulumi:stack::proj::test:index:Resource::res",
+		Type: "test:index:Resource",
+		Old:  &engine.StepEventStateMetadata{Inputs: olds},
+		New:  &engine.StepEventStateMetadata{Inputs: news},
+		Keys: []resource.PropertyKey{"name"},
+	})
+
+	assert.Equal(t, apitype.OpReplace, md.Op)
+	assert.Equal(t, []string{"name"}, md.Keys)
+	assert.Equal(t, []string{"a.b", "name", "ports", "removed", "tags"}, md.Diffs)
+	assert.Equal(t, map[string]apitype.PropertyDiff{
+		`["a.b"]`:    {Kind: apitype.DiffUpdate},
+		"name":       {Kind: apitype.DiffUpdateReplace},
+		"ports[1]":   {Kind: apitype.DiffUpdate},
+		"ports[2]":   {Kind: apitype.DiffAdd},
+		"removed":    {Kind: apitype.DiffDelete},
+		"tags.env":   {Kind: apitype.DiffUpdate},
+		"tags.owner": {Kind: apitype.DiffAdd},
+		"tags.team":  {Kind: apitype.DiffDelete},
+	}, md.DetailedDiff)
+	assert.Equal(t, "b", md.New.Inputs["name"])
+}
+
+func TestConvertArrayDeletes(t *testing.T) {
+	olds := resource.NewPropertyMapFromMap(map[string]interface{}{
+		"list":   []interface{}{"a", "b", "c"},
+		"nested": []interface{}{"x", "k", map[string]interface{}{"items": []interface{}{"p", "q"}}},
+	})
+	news := resource.NewPropertyMapFromMap(map[string]interface{}{
+		"list":   []interface{}{"c", "d"},
+		"nested": []interface{}{"k", map[string]interface{}{"items": []interface{}{"q"}}},
+	})
+
+	md := convertStepEventMetadata(engine.StepEventMetadata{
+		Op:   deploy.OpUpdate,
+		URN:  "urn:pulumi:stack::proj::test:index:Resource::res",
+		Type: "test:index:Resource",
+		Old:  &engine.StepEventStateMetadata{Inputs: olds},
+		New:  &engine.StepEventStateMetadata{Inputs: news},
+	})
+
+	// Deletes are identified by their index in the old array, so they never collide with adds or updates, and the
+	// paths of elements deleted from nested arrays lead through the old array as well.
+	assert.Equal(t, map[string]apitype.PropertyDiff{
+		"list[1]": {Kind: apitype.DiffAdd},
+	}, md.DetailedDiff)
+	assert.Equal(t, map[string]apitype.PropertyDiff{
+		"list[0]":            {Kind: apitype.DiffDelete},
+		"list[1]":            {Kind: apitype.DiffDelete},
+		"nested[0]":          {Kind: apitype.DiffDelete},
+		"nested[2].items[0]": {Kind: apitype.DiffDelete},
+	}, md.DeletedElements)
+}
+
+func TestConvertEngineEvent(t *testing.T) {
+	opts := backend.DisplayOptions{Color: colors.Always}
+
+	e, ok := ConvertEngineEvent(engine.Event{
+		Type: engine.DiagEvent,
+		Payload: engine.DiagEventPayload{
+			Message:  colors.Red + "boom" + colors.Reset,
+			Severity: diag.Error,
+		},
+	}, opts)
+	assert.True(t, ok)
+	assert.Equal(t, apitype.EngineEventSchemaVersionCurrent, e.Version)
+	if assert.NotNil(t, e.DiagnosticEvent) {
+		assert.Equal(t, "boom", e.DiagnosticEvent.Message)
+		assert.Equal(t, "error", e.DiagnosticEvent.Severity)
+	}
+
+	// Debug diagnostics are only included when debugging.
+	debug := engine.Event{Type: engine.DiagEvent, Payload: engine.DiagEventPayload{Severity: diag.Debug}}
+	_, ok = ConvertEngineEvent(debug, opts)
+	assert.False(t, ok)
+	opts.Debug = true
+	_, ok = ConvertEngineEvent(debug, opts)
+	assert.True(t, ok)
+
+	// Drift is described by the differences between the checkpoint's outputs and the live outputs.
+	e, ok = ConvertEngineEvent(engine.Event{
+		Type: engine.ResourceDriftEvent,
+		Payload: engine.ResourceDriftEventPayload{
+			Metadata: engine.StepEventMetadata{
+				Op:  deploy.OpUpdate,
+				URN: "urn:pulumi:stack::proj::test:index:Resource::res",
+				Old: &engine.StepEventStateMetadata{
+					Outputs: resource.NewPropertyMapFromMap(map[string]interface{}{"size": 1}),
+				},
+				New: &engine.StepEventStateMetadata{
+					Outputs: resource.NewPropertyMapFromMap(map[string]interface{}{"size": 2}),
+				},
+			},
+			Keys: []resource.PropertyKey{"size"},
+		},
+	}, opts)
+	assert.True(t, ok)
+	if assert.NotNil(t, e.ResDriftEvent) {
+		assert.False(t, e.ResDriftEvent.Deleted)
+		assert.Equal(t, []string{"size"}, e.ResDriftEvent.Keys)
+		assert.Equal(t, []string{"size"}, e.ResDriftEvent.Metadata.Diffs)
+	}
+
+	// Cancellation marks the end of the events, and isn't serialized.
+	_, ok = ConvertEngineEvent(engine.Event{Type: engine.CancelEvent}, opts)
+	assert.False(t, ok)
+}
+
+func TestFileEventLog(t *testing.T) {
+	dir, err := ioutil.TempDir("", "pulumi-event-log")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "events.json")
+
+	log, err := OpenEventLog(path)
+	assert.NoError(t, err)
+
+	// Events pass through the tee unchanged, and are logged in order.
+	events := make(chan engine.Event)
+	teed := teeEventsToLog(events, log)
+	go func() {
+		events <- engine.Event{Type: engine.PreludeEvent, Payload: engine.PreludeEventPayload{IsPreview: true}}
+		events <- engine.Event{Type: engine.DiagEvent, Payload: engine.DiagEventPayload{Severity: diag.Debug}}
+		events <- engine.Event{Type: engine.CancelEvent}
+		close(events)
+	}()
+	var received []engine.EventType
+	for e := range teed {
+		received = append(received, e.Type)
+	}
+	assert.Equal(t, []engine.EventType{engine.PreludeEvent, engine.DiagEvent, engine.CancelEvent}, received)
+	assert.NoError(t, log.Close())
+
+	f, err := os.Open(path)
+	assert.NoError(t, err)
+	defer f.Close()
+	var logged []apitype.EngineEvent
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		var e apitype.EngineEvent
+		assert.NoError(t, json.Unmarshal(scanner.Bytes(), &e))
+		logged = append(logged, e)
+	}
+	if assert.Len(t, logged, 2) {
+		assert.Equal(t, 1, logged[0].Sequence)
+		assert.NotNil(t, logged[0].PreludeEvent)
+		assert.Equal(t, 2, logged[1].Sequence)
+		assert.NotNil(t, logged[1].DiagnosticEvent)
+	}
+}
+
+func TestEventLogStalledSocket(t *testing.T) {
+	dir, err := ioutil.TempDir("", "pulumi-event-log")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "events.sock")
+
+	// A consumer that connects but never reads.
+	listener, err := net.Listen("unix", path)
+	if !assert.NoError(t, err) {
+		return
+	}
+	defer contract.IgnoreClose(listener)
+	go func() {
+		if conn, acceptErr := listener.Accept(); acceptErr == nil {
+			defer contract.IgnoreClose(conn)
+			time.Sleep(time.Minute)
+		}
+	}()
+
+	oldTimeout := eventLogWriteTimeout
+	eventLogWriteTimeout = 100 * time.Millisecond
+	defer func() { eventLogWriteTimeout = oldTimeout }()
+
+	log, err := OpenEventLog(path)
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	// Logging far more than the socket and queue can buffer must neither block the events nor fail them.
+	message := strings.Repeat("x", 4096)
+	events := make(chan engine.Event)
+	teed := teeEventsToLog(events, log)
+	go func() {
+		for i := 0; i < 4*eventLogQueueSize; i++ {
+			events <- engine.Event{Type: engine.DiagEvent, Payload: engine.DiagEventPayload{Message: message}}
+		}
+		close(events)
+	}()
+	received := 0
+	finished := make(chan struct{})
+	go func() {
+		defer close(finished)
+		for range teed {
+			received++
+		}
+	}()
+	select {
+	case <-finished:
+	case <-time.After(30 * time.Second):
+		t.Fatal("events blocked on a stalled event log")
+	}
+	assert.Equal(t, 4*eventLogQueueSize, received)
+
+	// The log has been turned off, and closing it doesn't wait on the consumer.
+	assert.True(t, log.isFailed())
+	assert.NoError(t, log.LogEvent(engine.Event{Type: engine.DiagEvent, Payload: engine.DiagEventPayload{}}))
+	closed := make(chan struct{})
+	go func() {
+		contract.IgnoreClose(log)
+		close(closed)
+	}()
+	select {
+	case <-closed:
+	case <-time.After(30 * time.Second):
+		t.Fatal("closing a stalled event log blocked")
+	}
+}
@@ -149,6 +149,30 @@ type ProgressDisplay struct {
 	// Cache of lines we've already printed.  We don't print a progress message again if it hasn't
 	// changed between the last time we printed and now.
 	printedProgressCache map[string]Progress
+
+	// The number of lines the tree-view last printed.  If the tree shrinks (for example, because
+	// a component was collapsed), the lines it no longer needs are cleared.
+	linesPrinted int
+
+	// The filter that determines which rows are shown.
+	filter backend.DisplayFilter
+
+	// The components the user has explicitly collapsed (true) or expanded (false), overriding
+	// whatever the filter would otherwise do, and whether each component was collapsed the last
+	// time the tree was displayed.
+	collapseOverrides map[resource.URN]bool
+	collapsed         map[resource.URN]bool
+
+	// Whether we are reading key presses from the user.  If so, the user can select a row, and
+	// show the diagnostics for it.
+	keysEnabled    bool
+	escapeState    int
+	selectedURN    resource.URN
+	diagnosticsURN resource.URN
+
+	// The URNs of the rows shown the last time the tree was displayed, in order, so that the
+	// selection can be moved between them.
+	displayedURNs []resource.URN
 }
 
 var (
@@ -175,6 +199,9 @@ func getEventUrnAndMetadata(event engine.Event) (resource.URN, *engine.StepEvent
 	} else if event.Type == engine.ResourceOperationFailed {
 		payload := event.Payload.(engine.ResourceOperationFailedPayload)
 		return payload.Metadata.URN, &payload.Metadata
+	} else if event.Type == engine.ResourceDriftEvent {
+		payload := event.Payload.(engine.ResourceDriftEventPayload)
+		return payload.Metadata.URN, &payload.Metadata
 	} else if event.Type == engine.DiagEvent {
 		return event.Payload.(engine.DiagEventPayload).URN, nil
 	}
@@ -248,6 +275,12 @@ func DisplayProgressEvents(
 		printedProgressCache:   make(map[string]Progress),
 		displayOrderCounter:    1,
 		nonInteractiveSpinner:  spinner,
+		filter:                 opts.Filter,
+		collapseOverrides:      make(map[resource.URN]bool),
+		collapsed:              make(map[resource.URN]bool),
+	}
+	if display.filter == "" {
+		display.filter = backend.DisplayFilterAll
 	}
 
 	// display.writeSimpleMessage(fmt.Sprintf("Max suffix length %v", display.maxSuffixLength))
@@ -260,8 +293,25 @@ func DisplayProgressEvents(
 	display.isTerminal = opts.IsInteractive
 	display.terminalWidth = terminalWidth
 
+	// In a terminal, let the user navigate the tree-view with the keyboard, if we can.
+	var keyReader *cmdutil.KeyReader
+	var keys <-chan byte
+	if display.isTerminal && terminal.IsTerminal(int(os.Stdin.Fd())) {
+		if keyReader, err = cmdutil.NewKeyReader(int(os.Stdin.Fd())); err == nil {
+			keys = keyReader.Keys()
+			display.keysEnabled = true
+
+			// Make sure the terminal is restored even if displaying panics.
+			defer func() {
+				if keyReader != nil {
+					contract.IgnoreClose(keyReader)
+				}
+			}()
+		}
+	}
+
 	go func() {
-		display.processEvents(ticker, events)
+		display.processEvents(ticker, events, keys)
 
 		// no more progress events from this point on.  By closing the pipe, this will then cause
 		// DisplayJSONMessagesToStream to finish once it processes the last message is receives from
@@ -273,6 +323,12 @@ func DisplayProgressEvents(
 
 	ticker.Stop()
 
+	// Stop reading keys before we're done, so that we don't steal input from any prompt that follows.
+	if keyReader != nil {
+		contract.IgnoreClose(keyReader)
+		keyReader = nil
+	}
+
 	// let our caller know we're done.
 	done <- true
 }
@@ -390,6 +446,7 @@ func (display *ProgressDisplay) updateTerminalWidth() {
 }
 
 type treeNode struct {
+	urn resource.URN
 	row Row
 
 	colorizedColumns []string
@@ -407,6 +464,7 @@ func (display *ProgressDisplay) getOrCreateTreeNode(
 	}
 
 	node = &treeNode{
+		urn:              urn,
 		row:              row,
 		colorizedColumns: row.ColorizedColumns(),
 		colorizedSuffix:  row.ColorizedSuffix(),
@@ -566,6 +624,8 @@ func (display *ProgressDisplay) refreshAllRowsIfInTerminal() {
 		rootNodes := display.generateTreeNodes()
 		rootNodes = display.filterOutUnnecessaryNodesAndSetDisplayTimes(rootNodes)
 		sortNodes(rootNodes)
+		rootNodes = display.applyDisplayFilter(rootNodes)
+		display.markSelectedNode(rootNodes)
 		display.addIndentations(rootNodes, true /*isRoot*/, "")
 
 		maxSuffixLength := 0
@@ -620,6 +680,20 @@ func (display *ProgressDisplay) refreshAllRowsIfInTerminal() {
 				systemID++
 			}
 		}
+
+		// Show the diagnostics the user asked for, and a reminder of the keys they can press.
+		for _, line := range display.getInteractiveLines() {
+			display.colorizeAndWriteProgress(makeActionProgress(fmt.Sprintf("%v", systemID), line))
+			systemID++
+		}
+
+		// Clear any lines we printed last time that we no longer need.
+		for id := systemID; id < display.linesPrinted; id++ {
+			display.colorizeAndWriteProgress(makeActionProgress(fmt.Sprintf("%v", id), " "))
+		}
+		if systemID > display.linesPrinted {
+			display.linesPrinted = systemID
+		}
 	}
 }
 
@@ -636,7 +710,7 @@ func (display *ProgressDisplay) processEndSteps() {
 		if !v.Done() {
 			v.SetDone()
 
-			if !display.isTerminal {
+			if !display.isTerminal && display.shouldPrintRow(v) {
 				display.refreshSingleRow("", v, nil)
 			}
 		} else {
@@ -894,6 +968,8 @@ func (display *ProgressDisplay) processNormalEvent(event engine.Event) {
 	} else if event.Type == engine.ResourceOperationFailed {
 		row.SetDone()
 		row.SetFailed()
+	} else if event.Type == engine.ResourceDriftEvent {
+		row.SetDrifted()
 	} else if event.Type == engine.DiagEvent {
 		// also record this diagnostic so we print it at the end.
 		row.RecordDiagEvent(event)
@@ -904,7 +980,7 @@ func (display *ProgressDisplay) processNormalEvent(event engine.Event) {
 	if display.isTerminal {
 		// if we're in a terminal, then refresh everything so that all our columns line up
 		display.refreshAllRowsIfInTerminal()
-	} else {
+	} else if isRootEvent || display.shouldPrintRow(row) {
 		// otherwise, just print out this single row.
 		display.refreshSingleRow("", row, nil)
 	}
@@ -952,7 +1028,7 @@ func (display *ProgressDisplay) ensureHeaderAndStackRows() {
 	display.resourceRows = append(display.resourceRows, stackRow)
 }
 
-func (display *ProgressDisplay) processEvents(ticker *time.Ticker, events <-chan engine.Event) {
+func (display *ProgressDisplay) processEvents(ticker *time.Ticker, events <-chan engine.Event, keys <-chan byte) {
 	// Main processing loop.  The purpose of this func is to read in events from the engine
 	// and translate them into Status objects and progress messages to be presented to the
 	// command line.
@@ -962,6 +1038,9 @@ func (display *ProgressDisplay) processEvents(ticker *time.Ticker, events <-chan
 		case <-ticker.C:
 			display.processTick()
 
+		case key := <-keys:
+			display.processKey(key)
+
 		case event := <-events:
 			if event.Type == "" || event.Type == engine.CancelEvent {
 				// Engine finished sending events.  Do all the final processing and return
@@ -1114,6 +1193,24 @@ func (display *ProgressDisplay) getStepInProgressDescription(step engine.StepEve
 	return op.Color() + getDescription() + colors.Reset
 }
 
+// writeDiffKeys writes the label followed by the keys of the properties that changed, if there are any.
+func writeDiffKeys(b *bytes.Buffer, label string, diff *resource.ObjectDiff) {
+	if diff == nil {
+		return
+	}
+
+	writeString(b, label)
+
+	updates := make(resource.PropertyMap)
+	for k := range diff.Updates {
+		updates[k] = resource.PropertyValue{}
+	}
+
+	writePropertyKeys(b, diff.Adds, deploy.OpCreate)
+	writePropertyKeys(b, diff.Deletes, deploy.OpDelete)
+	writePropertyKeys(b, updates, deploy.OpUpdate)
+}
+
 func writePropertyKeys(b *bytes.Buffer, propMap resource.PropertyMap, op deploy.StepOp) {
 	if len(propMap) > 0 {
 		writeString(b, " ")
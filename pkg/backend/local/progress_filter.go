@@ -0,0 +1,267 @@
+// Copyright 2016-2018, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package local
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/pulumi/pulumi/pkg/backend"
+	"github.com/pulumi/pulumi/pkg/diag/colors"
+	"github.com/pulumi/pulumi/pkg/engine"
+	"github.com/pulumi/pulumi/pkg/resource/deploy"
+)
+
+// rowChanged returns true if the row's resource is being changed, or has reported errors or warnings.
+func rowChanged(row Row) bool {
+	resourceRow, ok := row.(ResourceRow)
+	if !ok {
+		return true
+	}
+	diagInfo := resourceRow.DiagInfo()
+	return resourceRow.Step().Op != deploy.OpSame || rowFailed(row) || diagInfo.WarningCount > 0
+}
+
+// rowFailed returns true if the row's resource failed, or has reported errors.
+func rowFailed(row Row) bool {
+	resourceRow, ok := row.(ResourceRow)
+	if !ok {
+		return true
+	}
+	return resourceRow.Failed() || resourceRow.DiagInfo().ErrorCount > 0
+}
+
+// subtreeChanged returns true if the node's row, or any of its descendants' rows, changed.
+func subtreeChanged(node *treeNode) bool {
+	if rowChanged(node.row) {
+		return true
+	}
+	for _, child := range node.childNodes {
+		if subtreeChanged(child) {
+			return true
+		}
+	}
+	return false
+}
+
+func countDescendants(node *treeNode) int {
+	count := len(node.childNodes)
+	for _, child := range node.childNodes {
+		count += countDescendants(child)
+	}
+	return count
+}
+
+// shouldPrintRow returns true if the display filter allows the row to be printed.  This is used outside of a terminal,
+// where rows are printed one at a time as they change, rather than as a tree.
+func (display *ProgressDisplay) shouldPrintRow(row Row) bool {
+	switch display.filter {
+	case backend.DisplayFilterChanges:
+		return rowChanged(row)
+	case backend.DisplayFilterFailed:
+		return rowFailed(row)
+	default:
+		return true
+	}
+}
+
+// applyDisplayFilter removes the nodes that the display filter hides, and the children of collapsed components,
+// noting in each collapsed component's row how many resources it hides.  The header and stack rows are always shown.
+func (display *ProgressDisplay) applyDisplayFilter(nodes []*treeNode) []*treeNode {
+	result := []*treeNode{}
+	for _, node := range nodes {
+		alwaysShow := node.row == display.headerRow || node.urn == display.stackUrn
+
+		// The changes filter hides unchanged resources, and collapses components none of whose children changed.
+		autoCollapse := false
+		if display.filter == backend.DisplayFilterChanges && !alwaysShow && !subtreeChanged(node) {
+			if len(node.childNodes) == 0 {
+				continue
+			}
+			autoCollapse = true
+		}
+
+		hidden := countDescendants(node)
+		collapsed := false
+		if hidden > 0 && node.row != display.headerRow {
+			var has bool
+			if collapsed, has = display.collapseOverrides[node.urn]; !has {
+				collapsed = autoCollapse
+			}
+			display.collapsed[node.urn] = collapsed
+		}
+
+		switch {
+		case collapsed:
+			node.childNodes = nil
+			info := fmt.Sprintf("%s(%d %s collapsed)%s",
+				colors.BrightBlack, hidden, plural("resource", hidden), colors.Reset)
+			if node.colorizedColumns[infoColumn] != "" {
+				info = node.colorizedColumns[infoColumn] + " " + info
+			}
+			node.colorizedColumns[infoColumn] = info
+		case autoCollapse:
+			// The user expanded a component that the filter collapsed, so show all of its children.
+		default:
+			node.childNodes = display.applyDisplayFilter(node.childNodes)
+
+			// Hide a resource the filter doesn't show, unless one of its children is shown.
+			if !alwaysShow && len(node.childNodes) == 0 && !display.shouldPrintRow(node.row) {
+				continue
+			}
+		}
+
+		result = append(result, node)
+	}
+	return result
+}
+
+// markSelectedNode records the URNs of the rows about to be displayed, in order, and marks the row the user has
+// selected, if any.
+func (display *ProgressDisplay) markSelectedNode(nodes []*treeNode) {
+	display.displayedURNs = nil
+
+	var visit func(nodes []*treeNode)
+	visit = func(nodes []*treeNode) {
+		for _, node := range nodes {
+			if node.row != display.headerRow {
+				display.displayedURNs = append(display.displayedURNs, node.urn)
+				if display.keysEnabled && !display.Done && node.urn == display.selectedURN {
+					node.colorizedColumns[opColumn] = colors.BrightCyan + ">" + colors.Reset +
+						node.colorizedColumns[opColumn]
+				}
+			}
+			visit(node.childNodes)
+		}
+	}
+	visit(nodes)
+}
+
+// getInteractiveLines returns the lines to show below the tree-view when the user can interact with it: the
+// diagnostics for the row they asked about, if any, and a reminder of the keys they can press.
+func (display *ProgressDisplay) getInteractiveLines() []string {
+	if !display.keysEnabled || display.Done {
+		return nil
+	}
+
+	var lines []string
+	if row, has := display.eventUrnToResourceRow[display.diagnosticsURN]; has && display.diagnosticsURN != "" {
+		columns := row.ColorizedColumns()
+		lines = append(lines, " ", "Diagnostics for "+columns[typeColumn]+": "+columns[nameColumn])
+
+		// Order the streams so that the lines don't move around as the display is refreshed.
+		diagInfo := row.DiagInfo()
+		var ids []int
+		for id := range diagInfo.StreamIDToDiagPayloads {
+			ids = append(ids, int(id))
+		}
+		sort.Ints(ids)
+
+		var payloads []engine.DiagEventPayload
+		for _, id := range ids {
+			streamPayloads := diagInfo.StreamIDToDiagPayloads[int32(id)]
+			if id != 0 && len(streamPayloads) > 0 {
+				streamPayloads = []engine.DiagEventPayload{display.mergeStreamPayloadsToSinglePayload(streamPayloads)}
+			}
+			payloads = append(payloads, streamPayloads...)
+		}
+		if len(payloads) == 0 {
+			lines = append(lines, "  (none)")
+		}
+		for _, payload := range payloads {
+			for _, line := range splitIntoDisplayableLines(display.renderProgressDiagEvent(payload, true)) {
+				lines = append(lines, "  "+strings.TrimRightFunc(line, unicode.IsSpace))
+			}
+		}
+	}
+
+	lines = append(lines, " ", fmt.Sprintf(
+		"%s↑/↓ select, space collapse/expand, f filter (%s), d diagnostics%s",
+		colors.BrightBlack, display.filter, colors.Reset))
+	return lines
+}
+
+// processKey handles a key pressed by the user, and refreshes the tree-view to reflect it.
+func (display *ProgressDisplay) processKey(key byte) {
+	// Arrow keys arrive as the escape sequences ESC [ A (up), ESC [ B (down), ESC [ C (right) and ESC [ D (left).
+	// Translate them into the equivalent vi-style keys.
+	switch display.escapeState {
+	case 1:
+		display.escapeState = 0
+		if key == '[' {
+			display.escapeState = 2
+			return
+		}
+	case 2:
+		display.escapeState = 0
+		arrows := map[byte]byte{'A': 'k', 'B': 'j', 'C': 'l', 'D': 'h'}
+		if key = arrows[key]; key == 0 {
+			return
+		}
+	}
+
+	switch key {
+	case 0x1b:
+		display.escapeState = 1
+		return
+	case 'k':
+		display.moveSelection(-1)
+	case 'j':
+		display.moveSelection(1)
+	case ' ', '\r', '\n':
+		display.collapseOverrides[display.selectedURN] = !display.collapsed[display.selectedURN]
+	case 'h':
+		display.collapseOverrides[display.selectedURN] = true
+	case 'l':
+		display.collapseOverrides[display.selectedURN] = false
+	case 'f':
+		for i, filter := range backend.DisplayFilters {
+			if filter == display.filter {
+				display.filter = backend.DisplayFilters[(i+1)%len(backend.DisplayFilters)]
+				break
+			}
+		}
+	case 'd':
+		if display.diagnosticsURN == display.selectedURN {
+			display.diagnosticsURN = ""
+		} else {
+			display.diagnosticsURN = display.selectedURN
+		}
+	default:
+		return
+	}
+
+	display.refreshAllRowsIfInTerminal()
+}
+
+// moveSelection moves the selection up (negative) or down (positive) the displayed rows.  If no displayed row is
+// selected, the first one is.
+func (display *ProgressDisplay) moveSelection(delta int) {
+	urns := display.displayedURNs
+	if len(urns) == 0 {
+		return
+	}
+	for i, urn := range urns {
+		if urn == display.selectedURN {
+			if j := i + delta; j >= 0 && j < len(urns) {
+				display.selectedURN = urns[j]
+			}
+			return
+		}
+	}
+	display.selectedURN = urns[0]
+}
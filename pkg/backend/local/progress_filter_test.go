@@ -0,0 +1,118 @@
+// Copyright 2016-2018, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package local
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/pulumi/pulumi/pkg/backend"
+	"github.com/pulumi/pulumi/pkg/engine"
+	"github.com/pulumi/pulumi/pkg/resource"
+	"github.com/pulumi/pulumi/pkg/resource/deploy"
+)
+
+func TestParseDisplayFilter(t *testing.T) {
+	for s, expected := range map[string]backend.DisplayFilter{
+		"":        backend.DisplayFilterAll,
+		"all":     backend.DisplayFilterAll,
+		"changes": backend.DisplayFilterChanges,
+		"failed":  backend.DisplayFilterFailed,
+	} {
+		filter, err := backend.ParseDisplayFilter(s)
+		assert.NoError(t, err)
+		assert.Equal(t, expected, filter)
+	}
+
+	_, err := backend.ParseDisplayFilter("same")
+	assert.Error(t, err)
+}
+
+func testNode(urn resource.URN, op deploy.StepOp, failed bool, children ...*treeNode) *treeNode {
+	return &treeNode{
+		urn: urn,
+		row: &resourceRowData{
+			step:     engine.StepEventMetadata{Op: op, URN: urn},
+			failed:   failed,
+			diagInfo: &DiagInfo{},
+		},
+		colorizedColumns: make([]string, 5),
+		childNodes:       children,
+	}
+}
+
+// testTree returns a stack containing a component of unchanged resources, a component with an updated resource, and
+// a resource that failed to be created.
+func testTree() []*treeNode {
+	return []*treeNode{
+		testNode("stack", deploy.OpSame, false,
+			testNode("unchanged", deploy.OpSame, false,
+				testNode("unchanged-a", deploy.OpSame, false),
+				testNode("unchanged-b", deploy.OpSame, false)),
+			testNode("changed", deploy.OpSame, false,
+				testNode("changed-a", deploy.OpSame, false),
+				testNode("changed-b", deploy.OpUpdate, false)),
+			testNode("failed", deploy.OpCreate, true)),
+	}
+}
+
+func displayedURNs(display *ProgressDisplay, filter backend.DisplayFilter) []resource.URN {
+	display.filter = filter
+	display.markSelectedNode(display.applyDisplayFilter(testTree()))
+	return display.displayedURNs
+}
+
+func TestApplyDisplayFilter(t *testing.T) {
+	display := &ProgressDisplay{
+		stackUrn:          "stack",
+		collapseOverrides: make(map[resource.URN]bool),
+		collapsed:         make(map[resource.URN]bool),
+	}
+
+	assert.Equal(t, []resource.URN{
+		"stack", "unchanged", "unchanged-a", "unchanged-b", "changed", "changed-a", "changed-b", "failed",
+	}, displayedURNs(display, backend.DisplayFilterAll))
+
+	// Unchanged resources are hidden, and the component with no changes is collapsed.
+	assert.Equal(t, []resource.URN{"stack", "unchanged", "changed", "changed-b", "failed"},
+		displayedURNs(display, backend.DisplayFilterChanges))
+	assert.True(t, display.collapsed["unchanged"])
+	assert.False(t, display.collapsed["changed"])
+
+	assert.Equal(t, []resource.URN{"stack", "failed"}, displayedURNs(display, backend.DisplayFilterFailed))
+
+	// The user can expand a component that the filter collapsed, and collapse one it didn't.
+	display.collapseOverrides["unchanged"] = false
+	display.collapseOverrides["changed"] = true
+	assert.Equal(t, []resource.URN{"stack", "unchanged", "unchanged-a", "unchanged-b", "changed", "failed"},
+		displayedURNs(display, backend.DisplayFilterChanges))
+}
+
+func TestShouldPrintRow(t *testing.T) {
+	display := &ProgressDisplay{}
+	same := testNode("same", deploy.OpSame, false).row
+	update := testNode("update", deploy.OpUpdate, false).row
+	failed := testNode("failed", deploy.OpCreate, true).row
+
+	display.filter = backend.DisplayFilterAll
+	assert.True(t, display.shouldPrintRow(same))
+	display.filter = backend.DisplayFilterChanges
+	assert.False(t, display.shouldPrintRow(same))
+	assert.True(t, display.shouldPrintRow(update))
+	display.filter = backend.DisplayFilterFailed
+	assert.False(t, display.shouldPrintRow(update))
+	assert.True(t, display.shouldPrintRow(failed))
+}
@@ -0,0 +1,334 @@
+// Copyright 2016-2018, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package local
+
+import (
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"io/ioutil"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/pulumi/pulumi/pkg/apitype"
+	"github.com/pulumi/pulumi/pkg/backend"
+	"github.com/pulumi/pulumi/pkg/diag"
+	"github.com/pulumi/pulumi/pkg/diag/colors"
+	"github.com/pulumi/pulumi/pkg/engine"
+	"github.com/pulumi/pulumi/pkg/resource"
+	"github.com/pulumi/pulumi/pkg/resource/deploy"
+)
+
+// ReportFormat is the format of an update report.
+type ReportFormat string
+
+const (
+	// MarkdownReport is a Markdown summary of the changes made by an update, suitable for pull request comments.
+	MarkdownReport ReportFormat = "markdown"
+	// JUnitReport is a JUnit XML report with one test case per resource step, suitable for CI systems.
+	JUnitReport ReportFormat = "junit"
+)
+
+// Report is a backend.EventLog that summarizes an operation's events, and writes that summary to a file in a given
+// format.  If several operations are recorded -- for example, the preview that precedes an update, and the update
+// itself -- the report describes the last of them.
+type Report struct {
+	format ReportFormat
+	path   string
+
+	preview     bool                        // true if the operation is a preview.
+	steps       []*reportStep               // the steps performed, in the order they began.
+	summary     *engine.SummaryEventPayload // the operation's summary, once it has finished.
+	errors      map[resource.URN][]string   // the error messages for each resource.
+	diagnostics []engine.DiagEventPayload   // the errors and warnings reported by the operation.
+	m           sync.Mutex
+}
+
+// reportStep records a single step and whether it failed.
+type reportStep struct {
+	metadata engine.StepEventMetadata
+	failed   bool
+}
+
+var _ backend.EventLog = (*Report)(nil)
+
+// NewReport creates a report from a specification of the form `<format>:<path>`, where format is either `markdown`
+// (or `md`) or `junit`.  The report is not written until Write is called.
+func NewReport(spec string) (*Report, error) {
+	parts := strings.SplitN(spec, ":", 2)
+	if len(parts) != 2 || parts[1] == "" {
+		return nil, errors.Errorf("report '%s' must be of the form <format>:<path>", spec)
+	}
+
+	format := ReportFormat(parts[0])
+	switch format {
+	case MarkdownReport, "md":
+		format = MarkdownReport
+	case JUnitReport:
+	default:
+		return nil, errors.Errorf("unrecognized report format '%s'; must be one of markdown or junit", parts[0])
+	}
+
+	r := &Report{format: format, path: parts[1]}
+	r.reset(false)
+	return r, nil
+}
+
+func (r *Report) reset(preview bool) {
+	r.preview = preview
+	r.steps = nil
+	r.summary = nil
+	r.errors = make(map[resource.URN][]string)
+	r.diagnostics = nil
+}
+
+// LogEvent records a single event in the report.
+func (r *Report) LogEvent(e engine.Event) error {
+	r.m.Lock()
+	defer r.m.Unlock()
+
+	switch e.Type {
+	case engine.PreludeEvent:
+		// Each operation begins with a prelude, so start afresh.
+		r.reset(e.Payload.(engine.PreludeEventPayload).IsPreview)
+	case engine.SummaryEvent:
+		summary := e.Payload.(engine.SummaryEventPayload)
+		r.summary = &summary
+	case engine.ResourcePreEvent:
+		r.steps = append(r.steps, &reportStep{metadata: e.Payload.(engine.ResourcePreEventPayload).Metadata})
+	case engine.ResourceOperationFailed:
+		md := e.Payload.(engine.ResourceOperationFailedPayload).Metadata
+		for i := len(r.steps) - 1; i >= 0; i-- {
+			if s := r.steps[i]; s.metadata.URN == md.URN && s.metadata.Op == md.Op {
+				s.failed = true
+				break
+			}
+		}
+	case engine.DiagEvent:
+		p := e.Payload.(engine.DiagEventPayload)
+		if p.Severity != diag.Error && p.Severity != diag.Warning {
+			break
+		}
+		p.Message = strings.TrimSpace(colors.Never.Colorize(p.Message))
+		r.diagnostics = append(r.diagnostics, p)
+		if p.Severity == diag.Error && p.URN != "" {
+			r.errors[p.URN] = append(r.errors[p.URN], p.Message)
+		}
+	}
+	return nil
+}
+
+// Write writes the report to its file.
+func (r *Report) Write() error {
+	r.m.Lock()
+	defer r.m.Unlock()
+
+	var b []byte
+	var err error
+	switch r.format {
+	case MarkdownReport:
+		b = r.markdown()
+	case JUnitReport:
+		b, err = r.junit()
+	}
+	if err != nil {
+		return err
+	}
+	return errors.Wrapf(ioutil.WriteFile(r.path, b, 0644), "writing report %s", r.path)
+}
+
+func (r *Report) operation() string {
+	if r.preview {
+		return "preview"
+	}
+	return "update"
+}
+
+// failureMessage returns the message for a failed step, built from the errors reported for its resource.
+func (r *Report) failureMessage(s *reportStep) string {
+	if msgs := r.errors[s.metadata.URN]; len(msgs) > 0 {
+		return strings.Join(msgs, "\n")
+	}
+	return fmt.Sprintf("failed to %s resource", s.metadata.Op)
+}
+
+// markdown renders the report as Markdown, grouping the resources by the operation performed on them.
+func (r *Report) markdown() []byte {
+	var b bytes.Buffer
+	op := r.operation()
+	fmt.Fprintf(&b, "# Pulumi %s summary\n\n", op)
+
+	if r.summary == nil {
+		fmt.Fprintf(&b, "**The %s did not complete.**\n\n", op)
+	} else {
+		if r.summary.MaybeCorrupt {
+			b.WriteString("**One or more resources may be in an invalid state.**\n\n")
+		}
+		b.WriteString("| Operation | Resources |\n|---|---|\n")
+		for _, stepOp := range deploy.StepOps {
+			if count := r.summary.ResourceChanges[stepOp]; count > 0 {
+				fmt.Fprintf(&b, "| %s | %d |\n", stepOp, count)
+			}
+		}
+		if !r.preview {
+			fmt.Fprintf(&b, "\nDuration: %s\n", r.summary.Duration.Round(time.Second))
+		}
+		b.WriteString("\n")
+	}
+
+	// List the resources that were changed, grouped by operation, with the properties that changed.
+	for _, stepOp := range deploy.StepOps {
+		if stepOp == deploy.OpSame {
+			continue
+		}
+		var steps []*reportStep
+		for _, s := range r.steps {
+			if s.metadata.Op == stepOp && s.metadata.Logical {
+				steps = append(steps, s)
+			}
+		}
+		if len(steps) == 0 {
+			continue
+		}
+
+		fmt.Fprintf(&b, "## %s (%d)\n\n", stepOp, len(steps))
+		for _, s := range steps {
+			fmt.Fprintf(&b, "- `%s` (`%s`)", s.metadata.URN.Name(), s.metadata.Type)
+			if s.failed {
+				b.WriteString(" **failed**")
+			}
+			b.WriteString("\n")
+
+			md := convertStepEventMetadata(s.metadata)
+			writeMarkdownDiffs(&b, md.DetailedDiff, "")
+			writeMarkdownDiffs(&b, md.DeletedElements, " (old)")
+		}
+		b.WriteString("\n")
+	}
+
+	if len(r.diagnostics) > 0 {
+		b.WriteString("## Diagnostics\n\n")
+		for _, d := range r.diagnostics {
+			fmt.Fprintf(&b, "- **%s**", d.Severity)
+			if d.URN != "" {
+				fmt.Fprintf(&b, " `%s`", d.URN.Name())
+			}
+			// Indent any continuation lines so that they remain part of the list item.
+			fmt.Fprintf(&b, ": %s\n", strings.Replace(d.Message, "\n", "\n  ", -1))
+		}
+		b.WriteString("\n")
+	}
+
+	return b.Bytes()
+}
+
+type junitTestSuites struct {
+	XMLName xml.Name         `xml:"testsuites"`
+	Suites  []junitTestSuite `xml:"testsuite"`
+}
+
+type junitTestSuite struct {
+	Name      string          `xml:"name,attr"`
+	Tests     int             `xml:"tests,attr"`
+	Failures  int             `xml:"failures,attr"`
+	Time      string          `xml:"time,attr"`
+	TestCases []junitTestCase `xml:"testcase"`
+}
+
+type junitTestCase struct {
+	Name      string        `xml:"name,attr"`
+	ClassName string        `xml:"classname,attr"`
+	Failure   *junitFailure `xml:"failure,omitempty"`
+}
+
+type junitFailure struct {
+	Message string `xml:"message,attr"`
+	Type    string `xml:"type,attr"`
+	Text    string `xml:",chardata"`
+}
+
+// junit renders the report as JUnit XML, with one test case per resource step.  Errors that are not associated with a
+// resource, or an operation that did not complete, are reported as an additional failed test case for the operation.
+func (r *Report) junit() ([]byte, error) {
+	op := r.operation()
+	suite := junitTestSuite{Name: "pulumi " + op, Time: "0"}
+	if r.summary != nil {
+		suite.Time = fmt.Sprintf("%.3f", r.summary.Duration.Seconds())
+	}
+
+	for _, s := range r.steps {
+		tc := junitTestCase{
+			Name:      fmt.Sprintf("%s %s", s.metadata.Op, s.metadata.URN),
+			ClassName: string(s.metadata.Type),
+		}
+		if s.failed {
+			msg := r.failureMessage(s)
+			tc.Failure = &junitFailure{Message: firstLine(msg), Type: string(s.metadata.Op), Text: msg}
+		}
+		suite.TestCases = append(suite.TestCases, tc)
+	}
+
+	var errs []string
+	for _, d := range r.diagnostics {
+		if d.Severity == diag.Error && d.URN == "" {
+			errs = append(errs, d.Message)
+		}
+	}
+	if r.summary == nil {
+		errs = append(errs, fmt.Sprintf("the %s did not complete", op))
+	}
+	if len(errs) > 0 {
+		msg := strings.Join(errs, "\n")
+		suite.TestCases = append(suite.TestCases, junitTestCase{
+			Name:      op,
+			ClassName: "pulumi",
+			Failure:   &junitFailure{Message: firstLine(msg), Type: "error", Text: msg},
+		})
+	}
+
+	suite.Tests = len(suite.TestCases)
+	for _, tc := range suite.TestCases {
+		if tc.Failure != nil {
+			suite.Failures++
+		}
+	}
+
+	b, err := xml.MarshalIndent(junitTestSuites{Suites: []junitTestSuite{suite}}, "", "    ")
+	if err != nil {
+		return nil, err
+	}
+	return append([]byte(xml.Header), append(b, '\n')...), nil
+}
+
+func firstLine(s string) string {
+	return strings.SplitN(s, "\n", 2)[0]
+}
+
+// writeMarkdownDiffs writes a list item for each of the given property diffs, in order of their paths.  The suffix
+// follows each path, to tell apart paths into the old and new values.
+func writeMarkdownDiffs(b *bytes.Buffer, diffs map[string]apitype.PropertyDiff, suffix string) {
+	paths := make([]string, 0, len(diffs))
+	for path := range diffs {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+	for _, path := range paths {
+		fmt.Fprintf(b, "    - `%s`%s: %s\n", path, suffix, diffs[path].Kind)
+	}
+}
@@ -0,0 +1,146 @@
+// Copyright 2016-2018, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package local
+
+import (
+	"encoding/xml"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/pulumi/pulumi/pkg/diag"
+	"github.com/pulumi/pulumi/pkg/engine"
+	"github.com/pulumi/pulumi/pkg/resource"
+	"github.com/pulumi/pulumi/pkg/resource/deploy"
+)
+
+func TestNewReport(t *testing.T) {
+	r, err := NewReport("md:out/report.md")
+	assert.NoError(t, err)
+	assert.Equal(t, MarkdownReport, r.format)
+	assert.Equal(t, "out/report.md", r.path)
+
+	r, err = NewReport(`junit:C:\report.xml`)
+	assert.NoError(t, err)
+	assert.Equal(t, JUnitReport, r.format)
+	assert.Equal(t, `C:\report.xml`, r.path)
+
+	for _, spec := range []string{"junit", "junit:", "html:report.html"} {
+		_, err = NewReport(spec)
+		assert.Error(t, err, spec)
+	}
+}
+
+// logReportEvents records a preview followed by an update in which one resource fails to be updated.
+func logReportEvents(t *testing.T, r *Report) {
+	bucket := engine.StepEventMetadata{
+		Op:      deploy.OpUpdate,
+		URN:     "urn:pulumi:stack::proj::test:index:Bucket::bucket",
+		Type:    "test:index:Bucket",
+		Old:     &engine.StepEventStateMetadata{Inputs: resource.PropertyMap{"acl": resource.NewStringProperty("a")}},
+		New:     &engine.StepEventStateMetadata{Inputs: resource.PropertyMap{"acl": resource.NewStringProperty("b")}},
+		Logical: true,
+	}
+	queue := engine.StepEventMetadata{
+		Op:      deploy.OpCreate,
+		URN:     "urn:pulumi:stack::proj::test:index:Queue::queue",
+		Type:    "test:index:Queue",
+		Logical: true,
+	}
+
+	events := []engine.Event{
+		{Type: engine.PreludeEvent, Payload: engine.PreludeEventPayload{IsPreview: true}},
+		{Type: engine.ResourcePreEvent, Payload: engine.ResourcePreEventPayload{Metadata: queue}},
+		{Type: engine.PreludeEvent, Payload: engine.PreludeEventPayload{IsPreview: false}},
+		{Type: engine.ResourcePreEvent, Payload: engine.ResourcePreEventPayload{Metadata: bucket}},
+		{Type: engine.ResourcePreEvent, Payload: engine.ResourcePreEventPayload{Metadata: queue}},
+		{Type: engine.DiagEvent, Payload: engine.DiagEventPayload{
+			URN: bucket.URN, Severity: diag.Error, Message: "access denied\n"}},
+		{Type: engine.ResourceOperationFailed, Payload: engine.ResourceOperationFailedPayload{Metadata: bucket}},
+		{Type: engine.SummaryEvent, Payload: engine.SummaryEventPayload{
+			Duration:        3 * time.Second,
+			ResourceChanges: engine.ResourceChanges{deploy.OpCreate: 1},
+		}},
+		{Type: engine.CancelEvent},
+	}
+	for _, e := range events {
+		assert.NoError(t, r.LogEvent(e))
+	}
+}
+
+func TestMarkdownReport(t *testing.T) {
+	r, err := NewReport("markdown:unused")
+	assert.NoError(t, err)
+	logReportEvents(t, r)
+
+	assert.Equal(t, "# Pulumi update summary\n"+
+		"\n"+
+		"| Operation | Resources |\n"+
+		"|---|---|\n"+
+		"| create | 1 |\n"+
+		"\n"+
+		"Duration: 3s\n"+
+		"\n"+
+		"## create (1)\n"+
+		"\n"+
+		"- `queue` (`test:index:Queue`)\n"+
+		"\n"+
+		"## update (1)\n"+
+		"\n"+
+		"- `bucket` (`test:index:Bucket`) **failed**\n"+
+		"    - `acl`: update\n"+
+		"\n"+
+		"## Diagnostics\n"+
+		"\n"+
+		"- **error** `bucket`: access denied\n"+
+		"\n", string(r.markdown()))
+}
+
+func TestJUnitReport(t *testing.T) {
+	dir, err := ioutil.TempDir("", "report")
+	assert.NoError(t, err)
+	defer func() { assert.NoError(t, os.RemoveAll(dir)) }()
+
+	path := filepath.Join(dir, "report.xml")
+	r, err := NewReport("junit:" + path)
+	assert.NoError(t, err)
+	logReportEvents(t, r)
+	assert.NoError(t, r.Write())
+
+	b, err := ioutil.ReadFile(path)
+	assert.NoError(t, err)
+	var suites junitTestSuites
+	assert.NoError(t, xml.Unmarshal(b, &suites))
+	if assert.Len(t, suites.Suites, 1) {
+		suite := suites.Suites[0]
+		assert.Equal(t, "pulumi update", suite.Name)
+		assert.Equal(t, 2, suite.Tests)
+		assert.Equal(t, 1, suite.Failures)
+		assert.Equal(t, "3.000", suite.Time)
+		if assert.Len(t, suite.TestCases, 2) {
+			failed := suite.TestCases[0]
+			assert.Equal(t, "update urn:pulumi:stack::proj::test:index:Bucket::bucket", failed.Name)
+			assert.Equal(t, "test:index:Bucket", failed.ClassName)
+			if assert.NotNil(t, failed.Failure) {
+				assert.Equal(t, "access denied", failed.Failure.Message)
+			}
+			assert.Nil(t, suite.TestCases[1].Failure)
+		}
+	}
+}
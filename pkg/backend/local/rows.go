@@ -22,7 +22,6 @@ import (
 	"github.com/pulumi/pulumi/pkg/diag"
 	"github.com/pulumi/pulumi/pkg/diag/colors"
 	"github.com/pulumi/pulumi/pkg/engine"
-	"github.com/pulumi/pulumi/pkg/resource"
 	"github.com/pulumi/pulumi/pkg/resource/deploy"
 )
 
@@ -50,8 +49,11 @@ type ResourceRow interface {
 	Done() bool
 	SetDone()
 
+	Failed() bool
 	SetFailed()
 
+	SetDrifted()
+
 	DiagInfo() *DiagInfo
 	RecordDiagEvent(diagEvent engine.Event)
 }
@@ -123,6 +125,9 @@ type resourceRowData struct {
 	// If we failed this operation for any reason.
 	failed bool
 
+	// If a refresh found that the resource's live state differs from its checkpointed state.
+	drifted bool
+
 	diagInfo *DiagInfo
 
 	// If this row should be hidden by default.  We will hide unless we have any child nodes
@@ -186,6 +191,10 @@ func (data *resourceRowData) SetFailed() {
 	data.failed = true
 }
 
+func (data *resourceRowData) SetDrifted() {
+	data.drifted = true
+}
+
 func (data *resourceRowData) DiagInfo() *DiagInfo {
 	return data.diagInfo
 }
@@ -296,21 +305,15 @@ func (data *resourceRowData) getInfoColumn() string {
 	step := data.step
 	changesBuf := &bytes.Buffer{}
 
-	if step.Old != nil && step.New != nil && step.Old.Inputs != nil && step.New.Inputs != nil {
-		diff := step.Old.Inputs.Diff(step.New.Inputs)
-
-		if diff != nil {
-			writeString(changesBuf, "changes:")
-
-			updates := make(resource.PropertyMap)
-			for k := range diff.Updates {
-				updates[k] = resource.PropertyValue{}
-			}
-
-			writePropertyKeys(changesBuf, diff.Adds, deploy.OpCreate)
-			writePropertyKeys(changesBuf, diff.Deletes, deploy.OpDelete)
-			writePropertyKeys(changesBuf, updates, deploy.OpUpdate)
+	if data.drifted {
+		// A refresh leaves inputs alone, so show which outputs differ from the checkpoint instead.
+		if step.New == nil {
+			writeString(changesBuf, "no longer exists")
+		} else if step.Old != nil {
+			writeDiffKeys(changesBuf, "drift:", step.Old.Outputs.Diff(step.New.Outputs))
 		}
+	} else if step.Old != nil && step.New != nil && step.Old.Inputs != nil && step.New.Inputs != nil {
+		writeDiffKeys(changesBuf, "changes:", step.Old.Inputs.Diff(step.New.Inputs))
 	}
 
 	fprintIgnoreError(changesBuf, colors.Reset)
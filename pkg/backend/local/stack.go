@@ -23,6 +23,7 @@ import (
 	"github.com/pulumi/pulumi/pkg/operations"
 	"github.com/pulumi/pulumi/pkg/resource/config"
 	"github.com/pulumi/pulumi/pkg/resource/deploy"
+	"github.com/pulumi/pulumi/pkg/tokens"
 	"github.com/pulumi/pulumi/pkg/workspace"
 )
 
@@ -34,19 +35,21 @@ type Stack interface {
 
 // localStack is a local stack descriptor.
 type localStack struct {
-	name     backend.StackReference // the stack's name.
-	path     string                 // a path to the stack's checkpoint file on disk.
-	config   config.Map             // the stack's config bag.
-	snapshot *deploy.Snapshot       // a snapshot representing the latest deployment state.
-	b        *localBackend          // a pointer to the backend this stack belongs to.
+	name     backend.StackReference          // the stack's name.
+	path     string                          // a path to the stack's checkpoint file on disk.
+	config   config.Map                      // the stack's config bag.
+	tags     map[apitype.StackTagName]string // the stack's tags.
+	snapshot *deploy.Snapshot                // a snapshot representing the latest deployment state.
+	b        *localBackend                   // a pointer to the backend this stack belongs to.
 }
 
-func newStack(name backend.StackReference, path string, config config.Map,
+func newStack(name backend.StackReference, path string, config config.Map, tags map[apitype.StackTagName]string,
 	snapshot *deploy.Snapshot, b *localBackend) Stack {
 	return &localStack{
 		name:     name,
 		path:     path,
 		config:   config,
+		tags:     tags,
 		snapshot: snapshot,
 		b:        b,
 	}
@@ -55,6 +58,7 @@ func newStack(name backend.StackReference, path string, config config.Map,
 func (s *localStack) Name() backend.StackReference                           { return s.name }
 func (s *localStack) Config() config.Map                                     { return s.config }
 func (s *localStack) Snapshot(ctx context.Context) (*deploy.Snapshot, error) { return s.snapshot, nil }
+func (s *localStack) Tags() map[apitype.StackTagName]string                  { return s.tags }
 func (s *localStack) Backend() backend.Backend                               { return s.b }
 func (s *localStack) Path() string                                           { return s.path }
 
@@ -62,6 +66,10 @@ func (s *localStack) Remove(ctx context.Context, force bool) (bool, error) {
 	return backend.RemoveStack(ctx, s, force)
 }
 
+func (s *localStack) Rename(ctx context.Context, newName tokens.QName) (backend.StackReference, error) {
+	return backend.RenameStack(ctx, s, newName)
+}
+
 func (s *localStack) Preview(ctx context.Context, proj *workspace.Project, root string, m backend.UpdateMetadata,
 	opts backend.UpdateOptions, scopes backend.CancellationScopeSource) (engine.ResourceChanges, error) {
 	return backend.PreviewStack(ctx, s, proj, root, m, opts, scopes)
@@ -94,7 +94,7 @@ func (b *localBackend) getTarget(stackName tokens.QName) (*deploy.Target, error)
 	if err != nil {
 		return nil, err
 	}
-	_, snapshot, _, err := b.getStack(stackName)
+	_, _, snapshot, _, err := b.getStack(stackName)
 	if err != nil {
 		return nil, err
 	}
@@ -106,33 +106,35 @@ func (b *localBackend) getTarget(stackName tokens.QName) (*deploy.Target, error)
 	}, nil
 }
 
-func (b *localBackend) getStack(name tokens.QName) (config.Map, *deploy.Snapshot, string, error) {
+// getStack loads the config, tags and snapshot stored in the given stack's checkpoint, along with its file path.
+func (b *localBackend) getStack(name tokens.QName) (config.Map, map[apitype.StackTagName]string,
+	*deploy.Snapshot, string, error) {
 	if name == "" {
-		return nil, nil, "", errors.New("invalid empty stack name")
+		return nil, nil, nil, "", errors.New("invalid empty stack name")
 	}
 
 	file := b.stackPath(name)
 
 	chk, err := b.getCheckpoint(name)
 	if err != nil {
-		return nil, nil, file, errors.Wrap(err, "failed to load checkpoint")
+		return nil, nil, nil, file, errors.Wrap(err, "failed to load checkpoint")
 	}
 
 	// Materialize an actual snapshot object.
 	snapshot, err := stack.DeserializeCheckpoint(chk)
 	if err != nil {
-		return nil, nil, "", err
+		return nil, nil, nil, "", err
 	}
 
 	// Ensure the snapshot passes verification before returning it, to catch bugs early.
 	if !DisableIntegrityChecking {
 		if verifyerr := snapshot.VerifyIntegrity(); verifyerr != nil {
-			return nil, nil, file,
+			return nil, nil, nil, file,
 				errors.Wrapf(verifyerr, "%s: snapshot integrity failure; refusing to use it", file)
 		}
 	}
 
-	return chk.Config, snapshot, file, nil
+	return chk.Config, chk.Tags, snapshot, file, nil
 }
 
 // GetCheckpoint loads a checkpoint file for the given stack in this project, from the current project workspace.
@@ -146,8 +148,17 @@ func (b *localBackend) getCheckpoint(stackName tokens.QName) (*apitype.Checkpoin
 	return stack.UnmarshalVersionedCheckpointToLatestCheckpoint(bytes)
 }
 
-func (b *localBackend) saveStack(name tokens.QName,
-	config map[config.Key]config.Value, snap *deploy.Snapshot) (string, error) {
+// getStackTags loads the tags stored alongside the given stack's checkpoint.
+func (b *localBackend) getStackTags(name tokens.QName) (map[apitype.StackTagName]string, error) {
+	chk, err := b.getCheckpoint(name)
+	if err != nil {
+		return nil, err
+	}
+	return chk.Tags, nil
+}
+
+func (b *localBackend) saveCheckpoint(name tokens.QName, config map[config.Key]config.Value,
+	tags map[apitype.StackTagName]string, snap *deploy.Snapshot) (string, error) {
 	// Make a serializable stack and then use the encoder to encode it.
 	file := b.stackPath(name)
 	m, ext := encoding.Detect(file)
@@ -157,7 +168,7 @@ func (b *localBackend) saveStack(name tokens.QName,
 	if filepath.Ext(file) == "" {
 		file = file + ext
 	}
-	chk := stack.SerializeCheckpoint(name, config, snap)
+	chk := stack.SerializeCheckpoint(name, config, tags, snap)
 	byts, err := m.Marshal(chk)
 	if err != nil {
 		return "", errors.Wrap(err, "An IO error occurred during the current operation")
@@ -222,6 +233,18 @@ func backupTarget(file string) string {
 	return bck
 }
 
+// renameIfExists moves the file or directory at src to dst, creating dst's parent directory if needed.  If src does
+// not exist, this is a no-op.
+func renameIfExists(src, dst string) error {
+	if _, err := os.Stat(src); os.IsNotExist(err) {
+		return nil
+	}
+	if err := os.MkdirAll(filepath.Dir(dst), 0700); err != nil {
+		return err
+	}
+	return os.Rename(src, dst)
+}
+
 // backupStack copies the current Checkpoint file to ~/.pulumi/backups.
 func (b *localBackend) backupStack(name tokens.QName) error {
 	contract.Require(name != "", "name")
@@ -290,35 +313,79 @@ func (b *localBackend) getHistory(name tokens.QName) ([]backend.UpdateInfo, erro
 		return nil, err
 	}
 
-	var updates []backend.UpdateInfo
-
 	// os.ReadDir returns the array sorted by file name, but because of how we name files, older updates come before
-	// newer ones. Loop backwards so we added the newest updates to the array we will return first.
-	for i := len(allFiles) - 1; i >= 0; i-- {
-		file := allFiles[i]
-		filepath := path.Join(dir, file.Name())
-
-		// Open all of the history files, ignoring the checkpoints.
-		if !strings.HasSuffix(filepath, ".history.json") {
-			continue
+	// newer ones.  Each update's version is its history file's position in this order, starting at 1 for the oldest,
+	// so that skipping an unreadable file doesn't renumber the updates before it.
+	var historyFiles []string
+	for _, file := range allFiles {
+		if strings.HasSuffix(file.Name(), ".history.json") {
+			historyFiles = append(historyFiles, path.Join(dir, file.Name()))
 		}
+	}
+
+	// Loop backwards so we added the newest updates to the array we will return first.
+	var updates []backend.UpdateInfo
+	for i := len(historyFiles) - 1; i >= 0; i-- {
+		filepath := historyFiles[i]
 
+		// A single unreadable history file shouldn't prevent the rest of the history from being listed.
 		var update backend.UpdateInfo
 		b, err := ioutil.ReadFile(filepath)
-		if err != nil {
-			return nil, errors.Wrapf(err, "reading history file %s", filepath)
+		if err == nil {
+			err = json.Unmarshal(b, &update)
 		}
-		err = json.Unmarshal(b, &update)
 		if err != nil {
-			return nil, errors.Wrapf(err, "reading history file %s", filepath)
+			logging.Warningf("skipping unreadable history file %s: %v", filepath, err)
+			continue
 		}
 
+		// The copy of the checkpoint that was made alongside the history file, if any, can be large, so it is
+		// only read if it is asked for.
+		checkpointFile := strings.TrimSuffix(filepath, ".history.json") + ".checkpoint.json"
+		update.LoadDeployment = func() (*apitype.UntypedDeployment, error) {
+			deployment, err := readHistoricalDeployment(checkpointFile)
+			if err != nil {
+				return nil, errors.Wrapf(err, "reading checkpoint file %s", checkpointFile)
+			}
+			return deployment, nil
+		}
+
+		update.Version = i + 1
 		updates = append(updates, update)
 	}
 
 	return updates, nil
 }
 
+// readHistoricalDeployment reads the deployment from a checkpoint file saved by addToHistory.  If the file doesn't
+// exist, or contains no deployment, nil is returned.
+func readHistoricalDeployment(checkpointFile string) (*apitype.UntypedDeployment, error) {
+	b, err := ioutil.ReadFile(checkpointFile)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	chk, err := stack.UnmarshalVersionedCheckpointToLatestCheckpoint(b)
+	if err != nil {
+		return nil, err
+	}
+	if chk.Latest == nil {
+		return nil, nil
+	}
+
+	deployment, err := json.Marshal(chk.Latest)
+	if err != nil {
+		return nil, err
+	}
+	return &apitype.UntypedDeployment{
+		Version:    apitype.DeploymentSchemaVersionCurrent,
+		Deployment: deployment,
+	}, nil
+}
+
 // addToHistory saves the UpdateInfo and makes a copy of the current Checkpoint file.
 func (b *localBackend) addToHistory(name tokens.QName, update backend.UpdateInfo) error {
 	contract.Require(name != "", "name")
@@ -0,0 +1,81 @@
+// Copyright 2016-2018, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package local
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/pulumi/pulumi/pkg/backend"
+	"github.com/pulumi/pulumi/pkg/resource"
+	"github.com/pulumi/pulumi/pkg/resource/deploy"
+	"github.com/pulumi/pulumi/pkg/resource/stack"
+	"github.com/pulumi/pulumi/pkg/tokens"
+)
+
+func TestGetHistory(t *testing.T) {
+	root, err := ioutil.TempDir("", "local-backend-test")
+	if !assert.NoError(t, err) {
+		return
+	}
+	defer os.RemoveAll(root)
+	b := &localBackend{stateRoot: root}
+	name := tokens.QName("test")
+
+	// Record two updates, each with a copy of the checkpoint at the time.
+	urn := resource.NewURN(name, "proj", "", "test:index:Resource", "res")
+	for _, message := range []string{"first", "second"} {
+		res := resource.NewState("test:index:Resource", urn, true, false, resource.ID(message),
+			resource.PropertyMap{}, resource.PropertyMap{}, "", false, false, 0, nil, nil)
+		snap := deploy.NewSnapshot(deploy.Manifest{}, []*resource.State{res})
+		_, err = b.saveCheckpoint(name, nil, nil, snap)
+		assert.NoError(t, err)
+		assert.NoError(t, b.addToHistory(name, backend.UpdateInfo{Kind: backend.DeployUpdate, Message: message}))
+	}
+
+	// A corrupt history file between the two is skipped rather than failing the listing, and it keeps its version,
+	// so that the versions of the other updates don't change.
+	histories, err := filepath.Glob(filepath.Join(b.historyDirectory(name), "*.history.json"))
+	if !assert.NoError(t, err) || !assert.Len(t, histories, 2) {
+		return
+	}
+	corrupt := strings.TrimSuffix(histories[0], ".history.json") + "5.history.json"
+	assert.NoError(t, ioutil.WriteFile(corrupt, []byte("{not json"), 0600))
+
+	updates, err := b.getHistory(name)
+	if !assert.NoError(t, err) || !assert.Len(t, updates, 2) {
+		return
+	}
+	assert.Equal(t, "second", updates[0].Message)
+	assert.Equal(t, 3, updates[0].Version)
+	assert.Equal(t, "first", updates[1].Message)
+	assert.Equal(t, 1, updates[1].Version)
+
+	// Checkpoints are only read when they are asked for.
+	assert.Nil(t, updates[1].Deployment)
+	deployment, err := updates[1].GetDeployment()
+	if assert.NoError(t, err) && assert.NotNil(t, deployment) {
+		snap, err := stack.DeserializeDeployment(deployment)
+		assert.NoError(t, err)
+		if assert.Len(t, snap.Resources, 1) {
+			assert.Equal(t, resource.ID("first"), snap.Resources[0].ID)
+		}
+	}
+}
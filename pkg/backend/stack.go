@@ -26,6 +26,7 @@ import (
 	"github.com/pulumi/pulumi/pkg/operations"
 	"github.com/pulumi/pulumi/pkg/resource/config"
 	"github.com/pulumi/pulumi/pkg/resource/deploy"
+	"github.com/pulumi/pulumi/pkg/tokens"
 	"github.com/pulumi/pulumi/pkg/util/gitutil"
 	"github.com/pulumi/pulumi/pkg/workspace"
 )
@@ -35,6 +36,7 @@ type Stack interface {
 	Name() StackReference                                   // this stack's identity.
 	Config() config.Map                                     // the current config map.
 	Snapshot(ctx context.Context) (*deploy.Snapshot, error) // the latest deployment snapshot.
+	Tags() map[apitype.StackTagName]string                  // the stack's tags.
 	Backend() Backend                                       // the backend this stack belongs to.
 
 	// Preview changes to this stack.
@@ -52,6 +54,8 @@ type Stack interface {
 
 	// remove this stack.
 	Remove(ctx context.Context, force bool) (bool, error)
+	// rename this stack.
+	Rename(ctx context.Context, newName tokens.QName) (StackReference, error)
 	// list log entries for this stack.
 	GetLogs(ctx context.Context, query operations.LogQuery) ([]operations.LogEntry, error)
 	// export this stack's deployment.
@@ -65,6 +69,11 @@ func RemoveStack(ctx context.Context, s Stack, force bool) (bool, error) {
 	return s.Backend().RemoveStack(ctx, s.Name(), force)
 }
 
+// RenameStack renames the stack, or returns an error if it cannot.
+func RenameStack(ctx context.Context, s Stack, newName tokens.QName) (StackReference, error) {
+	return s.Backend().RenameStack(ctx, s.Name(), newName)
+}
+
 // PreviewStack previews changes to this stack.
 func PreviewStack(ctx context.Context, s Stack, proj *workspace.Project, root string, m UpdateMetadata,
 	opts UpdateOptions, scopes CancellationScopeSource) (engine.ResourceChanges, error) {
@@ -114,6 +123,11 @@ func ImportStackDeployment(ctx context.Context, s Stack, deployment *apitype.Unt
 	return s.Backend().ImportDeployment(ctx, s.Name(), deployment)
 }
 
+// UpdateStackTags replaces the full set of tags associated with the given stack.
+func UpdateStackTags(ctx context.Context, s Stack, tags map[apitype.StackTagName]string) error {
+	return s.Backend().UpdateStackTags(ctx, s.Name(), tags)
+}
+
 // GetStackTags returns the set of tags for the "current" stack, based on the environment
 // and Pulumi.yaml file.
 func GetStackTags() (map[apitype.StackTagName]string, error) {
@@ -130,7 +144,7 @@ func GetStackTags() (map[apitype.StackTagName]string, error) {
 			return nil, errors.Wrapf(err, "error loading project %q", projPath)
 		}
 		tags[apitype.ProjectNameTag] = proj.Name.String()
-		tags[apitype.ProjectRuntimeTag] = proj.Runtime
+		tags[apitype.ProjectRuntimeTag] = proj.Runtime.Name
 		if proj.Description != nil {
 			tags[apitype.ProjectDescriptionTag] = *proj.Description
 		}
@@ -139,12 +153,44 @@ func GetStackTags() (map[apitype.StackTagName]string, error) {
 			tags[apitype.GitHubOwnerNameTag] = owner
 			tags[apitype.GitHubRepositoryNameTag] = repo
 		}
-
+		if remote, err := gitutil.GetGitRemoteURLForOrigin(filepath.Dir(projPath)); err == nil && remote != "" {
+			tags[apitype.GitRemoteTag] = remote
+		}
 	}
 
 	return tags, nil
 }
 
+// IsComputedStackTag returns true if the given tag is one of those that GetStackTags computes automatically.  These are
+// refreshed on every update, so they may not be set or removed by hand.
+func IsComputedStackTag(name apitype.StackTagName) bool {
+	switch name {
+	case apitype.ProjectNameTag, apitype.ProjectRuntimeTag, apitype.ProjectDescriptionTag,
+		apitype.GitHubOwnerNameTag, apitype.GitHubRepositoryNameTag, apitype.GitRemoteTag:
+		return true
+	default:
+		return false
+	}
+}
+
+// MergeStackTags returns the union of a stack's existing tags and the given set of automatically computed tags
+// (see GetStackTags).  Computed tags take precedence, so that changes to, e.g., Pulumi.yaml are picked up, while
+// any user-defined tags are preserved.  Computed tags that are no longer present in computed are dropped, since they
+// can't be removed by hand.
+func MergeStackTags(existing, computed map[apitype.StackTagName]string) map[apitype.StackTagName]string {
+	merged := make(map[apitype.StackTagName]string)
+	for k, v := range existing {
+		if _, has := computed[k]; IsComputedStackTag(k) && !has {
+			continue
+		}
+		merged[k] = v
+	}
+	for k, v := range computed {
+		merged[k] = v
+	}
+	return merged
+}
+
 // validateStackName checks if s is a valid stack name, otherwise returns a descritive error.
 // This should match the stack naming rules enforced by the Pulumi Service.
 func validateStackName(s string) error {
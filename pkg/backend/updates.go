@@ -15,6 +15,7 @@
 package backend
 
 import (
+	"github.com/pulumi/pulumi/pkg/apitype"
 	"github.com/pulumi/pulumi/pkg/engine"
 	"github.com/pulumi/pulumi/pkg/resource/config"
 )
@@ -96,4 +97,21 @@ type UpdateInfo struct {
 	Result          UpdateResult           `json:"result"`
 	EndTime         int64                  `json:"endTime"`
 	ResourceChanges engine.ResourceChanges `json:"resourceChanges,omitempty"`
+
+	// Version is the version of the stack produced by the update, starting at 1 for the stack's first update.
+	Version int `json:"version,omitempty"`
+	// Deployment is the stack's deployment after the update, if it is available.
+	Deployment *apitype.UntypedDeployment `json:"deployment,omitempty"`
+	// LoadDeployment, if non-nil, reads the stack's deployment after the update on demand, for backends where doing
+	// so for every update would be expensive.  Use GetDeployment rather than calling it directly.
+	LoadDeployment func() (*apitype.UntypedDeployment, error) `json:"-"`
+}
+
+// GetDeployment returns the stack's deployment after the update, loading it if necessary.  If it is not available,
+// nil is returned.
+func (u UpdateInfo) GetDeployment() (*apitype.UntypedDeployment, error) {
+	if u.Deployment == nil && u.LoadDeployment != nil {
+		return u.LoadDeployment()
+	}
+	return u.Deployment, nil
 }
@@ -23,6 +23,16 @@ func newError(urn resource.URN, id ID, message string) *Diag {
 	return &Diag{URN: urn, ID: id, Message: message}
 }
 
+// newWarning registers a new warning message underneath the given id.
+func newWarning(urn resource.URN, id ID, message string) *Diag {
+	return &Diag{URN: urn, ID: id, Message: message}
+}
+
+// newMessage registers a new informational message underneath the given id.
+func newMessage(urn resource.URN, id ID, message string) *Diag {
+	return &Diag{URN: urn, ID: id, Message: message}
+}
+
 // Plan and apply errors are in the [2000,3000) range.
 
 func GetPlanApplyFailedError(urn resource.URN) *Diag {
@@ -52,3 +62,15 @@ func GetAnalyzeResourceFailureError(urn resource.URN) *Diag {
 func GetPreviewFailedError(urn resource.URN) *Diag {
 	return newError(urn, 2005, "Preview failed: %v")
 }
+
+func GetResourceSkippedWarning(urn resource.URN) *Diag {
+	return newWarning(urn, 2006, "Skipped because '%v' failed or was skipped")
+}
+
+func GetResourceOperationRetryWarning(urn resource.URN) *Diag {
+	return newWarning(urn, 2007, "%v failed transiently (attempt %d of %d); retrying in %v: %v")
+}
+
+func GetResourceRetainedMessage(urn resource.URN) *Diag {
+	return newMessage(urn, 2008, "Retained '%v' in its provider because it has retainOnDelete set; it is no longer managed")
+}
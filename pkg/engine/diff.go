@@ -16,6 +16,7 @@ package engine
 
 import (
 	"bytes"
+	"encoding/json"
 	"fmt"
 	"reflect"
 	"sort"
@@ -458,21 +459,36 @@ func printPropertyValueDiff(
 		writeVerbatim(b, op, "[\n")
 
 		a := diff.Array
-		for i := 0; i < a.Len(); i++ {
+		elided := elidedArrayEdits(a)
+		for i, edit := range a.Edits {
+			index := edit.New
+			if index < 0 {
+				index = edit.Old
+			}
 			elemTitleFunc := func(eop deploy.StepOp, eprefix bool) {
-				writeWithIndent(b, indent+1, eop, eprefix, "[%d]: ", i)
+				writeWithIndent(b, indent+1, eop, eprefix, "[%d]: ", index)
 			}
-			if add, isadd := a.Adds[i]; isadd {
+			if add, isadd := a.Adds[edit.New]; isadd && edit.Old < 0 {
 				printAdd(b, add, elemTitleFunc, planning, indent+2, debug)
-			} else if delete, isdelete := a.Deletes[i]; isdelete {
+			} else if delete, isdelete := a.Deletes[edit.Old]; isdelete && edit.New < 0 {
 				printDelete(b, delete, elemTitleFunc, planning, indent+2, debug)
-			} else if update, isupdate := a.Updates[i]; isupdate {
+			} else if from, ismove := a.Moves[edit.New]; ismove {
+				writeWithIndent(b, indent+1, op, true, "[%d->%d]: ", from, edit.New)
+				printPropertyValue(b, diff.New.ArrayValue()[edit.New], planning, indent+2, op, false, debug)
+			} else if update, isupdate := a.Updates[edit.New]; isupdate {
 				printPropertyValueDiff(
 					b, elemTitleFunc, update, causedReplace, planning,
 					indent+2, summary, debug)
 			} else if !summary {
+				// Only show the unchanged elements near those that changed.
+				if elided[i] {
+					if i == 0 || !elided[i-1] {
+						writeWithIndentNoPrefix(b, indent+1, deploy.OpSame, "...\n")
+					}
+					continue
+				}
 				elemTitleFunc(deploy.OpSame, false)
-				printPropertyValue(b, a.Sames[i], planning, indent+2, deploy.OpSame, false, debug)
+				printPropertyValue(b, a.Sames[edit.New], planning, indent+2, deploy.OpSame, false, debug)
 			}
 		}
 		writeWithIndentNoPrefix(b, indent, op, "]\n")
@@ -496,6 +512,16 @@ func printPropertyValueDiff(
 				return
 			}
 
+			if diff.Old.IsString() && diff.New.IsString() {
+				if oldText, newText, format, ok := textToDiff(diff.Old.StringValue(), diff.New.StringValue()); ok {
+					titleFunc(deploy.OpUpdate, true /*indent*/)
+					write(b, op, "%s {\n", format)
+					writeString(b, diffToPrettyString(diffLines(oldText, newText), indent+1))
+					writeWithIndentNoPrefix(b, indent, op, "}\n")
+					return
+				}
+			}
+
 			if isPrimitive(diff.Old) && isPrimitive(diff.New) {
 				titleFunc(deploy.OpUpdate, true /*indent*/)
 				printPrimitivePropertyValue(b, diff.Old, planning, deploy.OpDelete)
@@ -517,6 +543,92 @@ func printPropertyValueDiff(
 	}
 }
 
+// arrayDiffContext is the number of unchanged elements shown either side of the elements of an array that changed.
+const arrayDiffContext = 2
+
+// elidedArrayEdits returns, for each of an array diff's edits, whether it is an unchanged element too far from any
+// changed element to be worth showing.
+func elidedArrayEdits(a *resource.ArrayDiff) []bool {
+	changed := func(edit resource.ArrayEdit) bool {
+		_, isupdate := a.Updates[edit.New]
+		_, ismove := a.Moves[edit.New]
+		return edit.Old < 0 || edit.New < 0 || isupdate || ismove
+	}
+
+	// Find the distance of each edit from the nearest changed edit, looking both backwards and forwards.
+	n := len(a.Edits)
+	distances := make([]int, n)
+	last := -n - arrayDiffContext - 1
+	for i, edit := range a.Edits {
+		if changed(edit) {
+			last = i
+		}
+		distances[i] = i - last
+	}
+	last = 2*n + arrayDiffContext + 1
+	for i := n - 1; i >= 0; i-- {
+		if changed(a.Edits[i]) {
+			last = i
+		}
+		if last-i < distances[i] {
+			distances[i] = last - i
+		}
+	}
+
+	elided := make([]bool, n)
+	for i, distance := range distances {
+		elided[i] = distance > arrayDiffContext
+	}
+	return elided
+}
+
+// textToDiff returns the text to show a line-by-line diff of two strings for, and a description of its format.  If
+// both strings are JSON objects or arrays, they are diffed after being consistently formatted; otherwise, strings are
+// only diffed line-by-line if either of them spans multiple lines.
+func textToDiff(old string, new string) (string, string, string, bool) {
+	if oldJSON, ok := formatJSON(old); ok {
+		if newJSON, ok := formatJSON(new); ok {
+			return oldJSON, newJSON, "json", true
+		}
+	}
+	if strings.Contains(old, "\n") || strings.Contains(new, "\n") {
+		return old, new, "text", true
+	}
+	return "", "", "", false
+}
+
+// formatJSON indents a string containing a JSON object or array, returning false if it doesn't contain one.
+func formatJSON(s string) (string, bool) {
+	trimmed := strings.TrimSpace(s)
+	if !strings.HasPrefix(trimmed, "{") && !strings.HasPrefix(trimmed, "[") {
+		return "", false
+	}
+
+	var value interface{}
+	if err := json.Unmarshal([]byte(trimmed), &value); err != nil {
+		return "", false
+	}
+
+	var buff bytes.Buffer
+	encoder := json.NewEncoder(&buff)
+	encoder.SetEscapeHTML(false)
+	encoder.SetIndent("", "    ")
+	if err := encoder.Encode(value); err != nil {
+		return "", false
+	}
+	return buff.String(), true
+}
+
+// diffLines computes a line-by-line diff of two pieces of text.
+func diffLines(old string, new string) []diffmatchpatch.Diff {
+	differ := diffmatchpatch.New()
+	differ.DiffTimeout = 0
+
+	hashed1, hashed2, lineArray := differ.DiffLinesToChars(old, new)
+	diffs := differ.DiffMain(hashed1, hashed2, false)
+	return differ.DiffCharsToLines(diffs, lineArray)
+}
+
 func isPrimitive(value resource.PropertyValue) bool {
 	return value.IsNull() || value.IsString() || value.IsNumber() ||
 		value.IsBool() || value.IsComputed() || value.IsOutput()
@@ -765,14 +877,7 @@ func printAssetDiff(
 			massagedOldText := resource.MassageIfUserProgramCodeAsset(oldAsset, debug).Text
 			massagedNewText := resource.MassageIfUserProgramCodeAsset(newAsset, debug).Text
 
-			differ := diffmatchpatch.New()
-			differ.DiffTimeout = 0
-
-			hashed1, hashed2, lineArray := differ.DiffLinesToChars(massagedOldText, massagedNewText)
-			diffs1 := differ.DiffMain(hashed1, hashed2, false)
-			diffs2 := differ.DiffCharsToLines(diffs1, lineArray)
-
-			writeString(b, diffToPrettyString(diffs2, indent+1))
+			writeString(b, diffToPrettyString(diffLines(massagedOldText, massagedNewText), indent+1))
 
 			writeWithIndentNoPrefix(b, indent, op, "}\n")
 			return
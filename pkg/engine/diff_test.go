@@ -0,0 +1,143 @@
+// Copyright 2016-2018, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package engine
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/pulumi/pulumi/pkg/diag/colors"
+	"github.com/pulumi/pulumi/pkg/resource"
+	"github.com/pulumi/pulumi/pkg/resource/deploy"
+)
+
+func stringArray(vs ...string) resource.PropertyValue {
+	var arr []resource.PropertyValue
+	for _, v := range vs {
+		arr = append(arr, resource.NewStringProperty(v))
+	}
+	return resource.NewArrayProperty(arr)
+}
+
+// renderValueDiff renders the difference between two property values, without colors.
+func renderValueDiff(t *testing.T, old, new resource.PropertyValue) string {
+	diff := old.Diff(new)
+	if !assert.NotNil(t, diff) {
+		return ""
+	}
+
+	var b bytes.Buffer
+	titleFunc := func(op deploy.StepOp, prefix bool) {
+		writeWithIndent(&b, 1, op, prefix, "prop: ")
+	}
+	printPropertyValueDiff(&b, titleFunc, *diff, false, true, 1, false, false)
+	return colors.Never.Colorize(b.String())
+}
+
+func TestElidedArrayEdits(t *testing.T) {
+	t.Parallel()
+
+	old := stringArray("a", "b", "c", "d", "e", "f", "g", "h", "i")
+	diff := old.Diff(stringArray("a", "b", "c", "d", "x", "f", "g", "h", "i"))
+	assert.NotNil(t, diff)
+	assert.Equal(t, []bool{true, true, false, false, false, false, false, true, true}, elidedArrayEdits(diff.Array))
+
+	// Unchanged elements at the ends of the array are shown if they are close enough to a change.
+	diff = old.Diff(stringArray("x", "b", "c", "d", "e", "f", "g", "h", "y"))
+	assert.NotNil(t, diff)
+	assert.Equal(t, []bool{false, false, false, true, true, true, false, false, false}, elidedArrayEdits(diff.Array))
+}
+
+func TestPrintArrayDiff(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t,
+		"  ~ prop: [\n"+
+			"      ~ [2->0]: \"c\"\n"+
+			"        [1]: \"a\"\n"+
+			"        [2]: \"b\"\n"+
+			"    ]\n",
+		renderValueDiff(t, stringArray("a", "b", "c"), stringArray("c", "a", "b")))
+
+	assert.Equal(t,
+		"  ~ prop: [\n"+
+			"        ...\n"+
+			"        [2]: \"c\"\n"+
+			"        [3]: \"d\"\n"+
+			"      ~ [4]: \"e\" => \"x\"\n"+
+			"        [5]: \"f\"\n"+
+			"        [6]: \"g\"\n"+
+			"        ...\n"+
+			"    ]\n",
+		renderValueDiff(t,
+			stringArray("a", "b", "c", "d", "e", "f", "g", "h", "i"),
+			stringArray("a", "b", "c", "d", "x", "f", "g", "h", "i")))
+}
+
+func TestTextToDiff(t *testing.T) {
+	t.Parallel()
+
+	// JSON objects and arrays are diffed after formatting, whatever their original layout.
+	old, new, format, ok := textToDiff(`{"a":1,"b":[1,2]}`, `{"b": [1, 3], "a": 1}`)
+	assert.True(t, ok)
+	assert.Equal(t, "json", format)
+	assert.Equal(t, "{\n    \"a\": 1,\n    \"b\": [\n        1,\n        2\n    ]\n}\n", old)
+	assert.Equal(t, "{\n    \"a\": 1,\n    \"b\": [\n        1,\n        3\n    ]\n}\n", new)
+
+	// Multi-line strings are diffed as they are.
+	old, new, format, ok = textToDiff("one\ntwo", "one")
+	assert.True(t, ok)
+	assert.Equal(t, "text", format)
+	assert.Equal(t, "one\ntwo", old)
+	assert.Equal(t, "one", new)
+
+	// If only one string is JSON, or neither string spans lines, there is nothing to diff line by line.
+	_, _, _, ok = textToDiff(`{"a": 1}`, "a")
+	assert.False(t, ok)
+	_, _, _, ok = textToDiff("a", "b")
+	assert.False(t, ok)
+}
+
+func TestFormatJSON(t *testing.T) {
+	t.Parallel()
+
+	formatted, ok := formatJSON(" [1, \"<a>\"] ")
+	assert.True(t, ok)
+	assert.Equal(t, "[\n    1,\n    \"<a>\"\n]\n", formatted)
+
+	for _, s := range []string{"", "1", `"a"`, "{", "[1,", "{} trailing"} {
+		_, ok = formatJSON(s)
+		assert.False(t, ok, s)
+	}
+}
+
+func TestPrintTextDiff(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t,
+		"  ~ prop: text {\n"+
+			"        one\n"+
+			"      - two\n"+
+			"      + 2\n"+
+			"        three\n"+
+			"    }\n",
+		renderValueDiff(t, resource.NewStringProperty("one\ntwo\nthree"), resource.NewStringProperty("one\n2\nthree")))
+
+	// Single-line strings are shown as a simple replacement.
+	assert.Equal(t, "  ~ prop: \"a\" => \"b\"\n",
+		renderValueDiff(t, resource.NewStringProperty("a"), resource.NewStringProperty("b")))
+}
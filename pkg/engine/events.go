@@ -47,6 +47,7 @@ const (
 	ResourcePreEvent        EventType = "resource-pre"
 	ResourceOutputsEvent    EventType = "resource-outputs"
 	ResourceOperationFailed EventType = "resource-operationfailed"
+	ResourceDriftEvent      EventType = "resource-drift"
 )
 
 func cancelEvent() Event {
@@ -86,6 +87,15 @@ type ResourceOperationFailedPayload struct {
 	Steps    int
 }
 
+// ResourceDriftEventPayload is the payload for an event with type `resource-drift`, which a refresh emits for each
+// resource whose live state differs from its state in the checkpoint.
+type ResourceDriftEventPayload struct {
+	Metadata StepEventMetadata
+	Deleted  bool                   // true if the resource no longer exists.
+	Keys     []resource.PropertyKey // the outputs whose live values differ from the checkpoint's.
+	Planning bool
+}
+
 type ResourceOutputsEventPayload struct {
 	Metadata StepEventMetadata
 	Planning bool
@@ -325,6 +335,37 @@ func (e *eventEmitter) resourceOperationFailedEvent(
 	}
 }
 
+func (e *eventEmitter) resourceDriftEvent(step deploy.Step, planning bool, debug bool) {
+	contract.Requiref(e != nil, "e", "!= nil")
+
+	// Only a refresh can observe drift, which shows up as updates to resources that changed and deletes of resources
+	// that no longer exist.
+	if !step.Plan().IsRefresh() || (step.Op() != deploy.OpUpdate && step.Op() != deploy.OpDelete) {
+		return
+	}
+
+	var keys []resource.PropertyKey
+	if step.Old() != nil && step.New() != nil {
+		if diff := step.Old().Outputs.Diff(step.New().Outputs); diff != nil {
+			for _, k := range diff.Keys() {
+				if diff.Changed(k) {
+					keys = append(keys, k)
+				}
+			}
+		}
+	}
+
+	e.Chan <- Event{
+		Type: ResourceDriftEvent,
+		Payload: ResourceDriftEventPayload{
+			Metadata: makeStepEventMetadata(step, debug),
+			Deleted:  step.Op() == deploy.OpDelete,
+			Keys:     keys,
+			Planning: planning,
+		},
+	}
+}
+
 func (e *eventEmitter) resourceOutputsEvent(
 	step deploy.Step, planning bool, debug bool) {
 
@@ -191,6 +191,12 @@ func (res *planResult) Walk(ctx *Context, events deploy.Events, preview bool) (d
 	opts := deploy.Options{
 		Events:   events,
 		Parallel: res.Options.Parallel,
+		Plan:     res.Options.Plan,
+
+		ContinueOnError: res.Options.ContinueOnError,
+	}
+	if preview {
+		opts.SavePlan = res.Options.SavePlan
 	}
 
 	// Fetch a plan iterator and keep walking it until we are done.
@@ -219,9 +225,14 @@ func (res *planResult) Walk(ctx *Context, events deploy.Events, preview bool) (d
 			close(done)
 		}()
 
+		var applied []deploy.Step
 		for step != nil {
-			// Check for cancellation and termination.
+			// Check for cancellation and termination.  No further steps are started once the update is canceled, but
+			// the step that was in flight, if any, has been allowed to complete.
 			if cancelErr := ctx.Cancel.CancelErr(); cancelErr != nil {
+				if !preview {
+					res.reportCancellation(applied, nil, false)
+				}
 				rst, err = resource.StatusOK, cancelErr
 				return
 			}
@@ -244,11 +255,17 @@ func (res *planResult) Walk(ctx *Context, events deploy.Events, preview bool) (d
 			// Perform any per-step actions.
 			rst, err = iter.Apply(step, preview)
 
-			// If an error occurred, exit early.
+			// If an error occurred, exit early.  Note that when continuing past errors, step failures are recorded by
+			// the iterator rather than returned here.
 			if err != nil {
+				if !preview && ctx.Cancel.CancelErr() != nil {
+					// The step only saw the cancellation if it was still in flight when the grace period ended.
+					res.reportCancellation(applied, step, ctx.Cancel.InterruptErr() != nil)
+				}
 				return
 			}
 			contract.Assert(rst == resource.StatusOK)
+			applied = append(applied, step)
 
 			step, err = iter.Next()
 			if err != nil {
@@ -258,12 +275,15 @@ func (res *planResult) Walk(ctx *Context, events deploy.Events, preview bool) (d
 
 		// Finally, return a summary and the resulting plan information.
 		rst, err = resource.StatusOK, nil
+		if failures := iter.Failures(); len(failures) > 0 {
+			rst, err = summarizeFailures(failures, len(iter.Skipped()), preview)
+		}
 	}()
 
 	// Asynchronously listen for cancellation, and deliver that signal to plan.
 	go func() {
 		select {
-		case <-ctx.Cancel.Canceled():
+		case <-ctx.Cancel.Interrupted():
 			cancelErr := res.Plan.SignalCancellation()
 			if cancelErr != nil {
 				glog.V(3).Infof("Attempted to signal cancellation to resource providers, but failed: %s",
@@ -283,6 +303,77 @@ func (res *planResult) Walk(ctx *Context, events deploy.Events, preview bool) (d
 	}
 }
 
+// reportCancellation tells the user exactly which resources a canceled update did and did not modify, given the steps
+// that were applied before the update stopped, and the step that failed as the update stopped, if any, along with
+// whether it failed because it was interrupted.
+func (res *planResult) reportCancellation(applied []deploy.Step, failed deploy.Step, interrupted bool) {
+	var msg bytes.Buffer
+	msg.WriteString("The update was canceled; no further resource operations were started.\n")
+
+	modified := make(map[resource.URN]bool)
+	var changes bytes.Buffer
+	for _, step := range applied {
+		if step.Op() != deploy.OpSame {
+			modified[step.URN()] = true
+			fmt.Fprintf(&changes, "    %s %s%s\n", step.Op().Prefix(), step.URN(), colors.Reset)
+		}
+	}
+	if failed != nil {
+		reason := "failed"
+		if interrupted {
+			reason = "interrupted"
+		}
+		modified[failed.URN()] = true
+		fmt.Fprintf(&changes, "    %s%s %s (%s; may be partially modified)%s\n", colors.SpecError,
+			failed.Op().RawPrefix(), failed.URN(), reason, colors.Reset)
+	}
+	if changes.Len() > 0 {
+		msg.WriteString("These resources were modified:\n")
+		msg.Write(changes.Bytes())
+	} else {
+		msg.WriteString("No resources were modified.\n")
+	}
+
+	var unchanged bytes.Buffer
+	if prev := res.Plan.Prev(); prev != nil {
+		for _, old := range prev.Resources {
+			if !old.Delete && !modified[old.URN] {
+				fmt.Fprintf(&unchanged, "    %s\n", old.URN)
+			}
+		}
+	}
+	if unchanged.Len() > 0 {
+		msg.WriteString("These resources were not modified:\n")
+		msg.Write(unchanged.Bytes())
+	}
+
+	res.Options.Diag.Warningf(diag.RawMessage("", msg.String()))
+}
+
+// summarizeFailures produces the error that ends a plan in which steps failed while continuing past errors, along with
+// the resulting status: unknown if any of the failures may have left a resource in an unknown state.
+func summarizeFailures(failures []deploy.StepFailure, skipped int, preview bool) (resource.Status, error) {
+	kind := "update"
+	if preview {
+		kind = "preview"
+	}
+
+	rst := resource.StatusOK
+	var msg bytes.Buffer
+	fmt.Fprintf(&msg, "%s failed: %d step(s) failed", kind, len(failures))
+	if skipped > 0 {
+		fmt.Fprintf(&msg, " and %d dependent resource(s) were skipped", skipped)
+	}
+	msg.WriteString(":")
+	for _, failure := range failures {
+		fmt.Fprintf(&msg, "\n    %s %s: %v", failure.Step.Op(), failure.Step.URN(), failure.Err)
+		if failure.Status == resource.StatusUnknown {
+			rst = resource.StatusUnknown
+		}
+	}
+	return rst, errors.New(msg.String())
+}
+
 func (res *planResult) Close() error {
 	return res.Plugctx.Close()
 }
@@ -328,6 +419,7 @@ func newPlanActions(opts planOptions) *planActions {
 func (acts *planActions) OnResourceStepPre(step deploy.Step) (interface{}, error) {
 	acts.Seen[step.URN()] = step
 	acts.Opts.Events.resourcePreEvent(step, true /*planning*/, acts.Opts.Debug)
+	acts.Opts.Events.resourceDriftEvent(step, true /*planning*/, acts.Opts.Debug)
 	return nil, nil
 }
 
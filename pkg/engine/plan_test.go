@@ -0,0 +1,136 @@
+// Copyright 2016-2018, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package engine
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/blang/semver"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/pulumi/pulumi/pkg/diag"
+	"github.com/pulumi/pulumi/pkg/diag/colors"
+	"github.com/pulumi/pulumi/pkg/resource"
+	"github.com/pulumi/pulumi/pkg/resource/deploy"
+	"github.com/pulumi/pulumi/pkg/resource/plugin"
+	"github.com/pulumi/pulumi/pkg/tokens"
+	"github.com/pulumi/pulumi/pkg/util/cancel"
+)
+
+// testHost is a plugin host that serves a single provider.  Only the methods a plan walk uses are implemented.
+type testHost struct {
+	plugin.Host
+	provider plugin.Provider
+}
+
+func (host *testHost) Provider(pkg tokens.Package, version *semver.Version) (plugin.Provider, error) {
+	return host.provider, nil
+}
+func (host *testHost) SignalCancellation() error { return nil }
+func (host *testHost) Close() error              { return nil }
+
+// testProvider is a resource provider that can only delete resources.
+type testProvider struct {
+	plugin.Provider
+	delete func(resource.URN) (resource.Status, error)
+}
+
+func (prov *testProvider) Delete(urn resource.URN, id resource.ID,
+	props resource.PropertyMap) (resource.Status, error) {
+	return prov.delete(urn)
+}
+func (prov *testProvider) SignalCancellation() error { return nil }
+
+// walkDeletes walks an update that deletes resources a and b, in that order, calling the given function to delete
+// each one.  It returns the warnings that were reported and the error that ended the walk.
+func walkDeletes(t *testing.T, cancelContext *cancel.Context,
+	delete func(resource.URN) (resource.Status, error)) (string, error) {
+
+	var stderr bytes.Buffer
+	sink := diag.DefaultSink(&stderr, &stderr, diag.FormatOptions{Color: colors.Never})
+	plugctx, err := plugin.NewContext(sink, &testHost{provider: &testProvider{delete: delete}}, nil, nil, "", nil)
+	if !assert.NoError(t, err) {
+		return "", err
+	}
+	defer func() { assert.NoError(t, plugctx.Close()) }()
+
+	// Resources are deleted in the reverse of the order they were created in.
+	typ := tokens.Type("test:index:Thing")
+	var prev []*resource.State
+	for _, name := range []tokens.QName{"b", "a"} {
+		prev = append(prev, resource.NewState(typ, resource.NewURN("test", "proj", "", typ, name), true, false,
+			resource.ID(name), resource.PropertyMap{}, resource.PropertyMap{}, "", false, false, 0, nil, nil))
+	}
+	target := &deploy.Target{Name: "test"}
+	res := &planResult{
+		Plugctx: plugctx,
+		Plan: deploy.NewPlan(plugctx, target, deploy.NewSnapshot(deploy.Manifest{}, prev),
+			deploy.NewFixedSource("proj", nil), nil, false),
+		Options: planOptions{Diag: sink},
+	}
+
+	_, _, _, err = res.Walk(&Context{Cancel: cancelContext}, nil, false)
+	return stderr.String(), err
+}
+
+func TestWalkCancellation(t *testing.T) {
+	t.Parallel()
+
+	// Once the update is canceled, the step in flight completes and no further steps are started.
+	cancelContext, source := cancel.NewContext(context.Background())
+	defer source.Close()
+	var deleted []resource.URN
+	warnings, err := walkDeletes(t, cancelContext, func(urn resource.URN) (resource.Status, error) {
+		deleted = append(deleted, urn)
+		source.CancelGracefully(0)
+		return resource.StatusOK, nil
+	})
+	assert.Equal(t, context.Canceled, err)
+	assert.Equal(t, []resource.URN{"urn:pulumi:test::proj::test:index:Thing::a"}, deleted)
+	assert.Contains(t, warnings, "These resources were modified:\n"+
+		"    -  urn:pulumi:test::proj::test:index:Thing::a\n")
+	assert.Contains(t, warnings, "These resources were not modified:\n"+
+		"    urn:pulumi:test::proj::test:index:Thing::b\n")
+}
+
+func TestWalkCancellationFailure(t *testing.T) {
+	t.Parallel()
+
+	// A step that fails on its own during the grace period is reported as having failed.
+	cancelContext, source := cancel.NewContext(context.Background())
+	defer source.Close()
+	warnings, err := walkDeletes(t, cancelContext, func(urn resource.URN) (resource.Status, error) {
+		source.CancelGracefully(0)
+		return resource.StatusOK, errors.New("delete failed")
+	})
+	assert.Error(t, err)
+	assert.Contains(t, warnings,
+		"-  urn:pulumi:test::proj::test:index:Thing::a (failed; may be partially modified)")
+	assert.NotContains(t, warnings, "interrupted")
+
+	// On the other hand, a step that fails once the cancellation interrupts it is reported as having been interrupted.
+	cancelContext, source = cancel.NewContext(context.Background())
+	defer source.Close()
+	warnings, err = walkDeletes(t, cancelContext, func(urn resource.URN) (resource.Status, error) {
+		source.Cancel()
+		return resource.StatusUnknown, errors.New("delete interrupted")
+	})
+	assert.Error(t, err)
+	assert.Contains(t, warnings,
+		"-  urn:pulumi:test::proj::test:index:Thing::a (interrupted; may be partially modified)")
+}
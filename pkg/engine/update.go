@@ -35,6 +35,18 @@ type UpdateOptions struct {
 
 	// true if debugging output it enabled
 	Debug bool
+
+	// an optional saved plan that the update's steps must conform to.
+	Plan *deploy.SavedPlan
+
+	// an optional saved plan into which a preview records the steps it computes.
+	SavePlan *deploy.SavedPlan
+
+	// true to keep applying steps whose dependencies succeeded after a step fails.
+	ContinueOnError bool
+
+	// true if a preview of this update has already been shown, in which case it reported any drift.
+	Previewed bool
 }
 
 // ResourceChanges contains the aggregate resource changes by operation type.
@@ -196,6 +208,9 @@ func (acts *updateActions) OnResourceStepPre(step deploy.Step) (interface{}, err
 	acts.Seen[step.URN()] = step
 
 	acts.Opts.Events.resourcePreEvent(step, false /*planning*/, acts.Opts.Debug)
+	if !acts.Opts.Previewed {
+		acts.Opts.Events.resourceDriftEvent(step, false /*planning*/, acts.Opts.Debug)
+	}
 
 	// Inform the snapshot service that we are about to perform a step.
 	return acts.Context.SnapshotManager.BeginMutation(step)
@@ -95,7 +95,7 @@ func (p *Plan) Source() Source                         { return p.source }
 func (p *Plan) IsRefresh() bool                        { return p.source.IsRefresh() }
 
 func (p *Plan) SignalCancellation() error {
-	return p.ctx.Host.SignalCancellation()
+	return p.ctx.SignalCancellation()
 }
 
 // Provider fetches the provider for a given resource type, possibly lazily allocating the plugins for it.  If a
@@ -28,8 +28,12 @@ import (
 
 // Options controls the planning and deployment process.
 type Options struct {
-	Events   Events // an optional events callback interface.
-	Parallel int    // the degree of parallelism for resource operations (<=1 for serial).
+	Events   Events     // an optional events callback interface.
+	Parallel int        // the degree of parallelism for resource operations (<=1 for serial).
+	Plan     *SavedPlan // an optional saved plan that the generated steps must conform to.
+	SavePlan *SavedPlan // an optional saved plan into which the generated steps are recorded.
+
+	ContinueOnError bool // true to keep applying independent steps after a step fails.
 }
 
 // Events is an interface that can be used to hook interesting engine/planning events.
@@ -83,6 +87,9 @@ type PlanIterator struct {
 	resources []*resource.State        // the resulting ordered resource states.
 	dones     map[*resource.State]bool // true for each old state we're done with.
 
+	pendingReg *trackedRegisterEvent // the registration whose steps are being drained (only with ContinueOnError).
+	failures   []StepFailure         // the steps that failed (only with ContinueOnError).
+
 	srcdone bool // true if the source interpreter has been run to completion.
 	done    bool // true if the planning and associated iteration has finished.
 }
@@ -100,6 +107,20 @@ func (iter *PlanIterator) Resources() []*resource.State    { return iter.resourc
 func (iter *PlanIterator) Dones() map[*resource.State]bool { return iter.dones }
 func (iter *PlanIterator) Done() bool                      { return iter.done }
 
+// StepFailure records a step that failed while applying a plan with ContinueOnError.
+type StepFailure struct {
+	Step   Step            // the step that failed.
+	Status resource.Status // the status of the resource after the failure.
+	Err    error           // the error the step failed with.
+}
+
+// Failures returns the steps that have failed so far.  This is only populated when applying a plan with
+// ContinueOnError; otherwise, the first failure ends the iteration.
+func (iter *PlanIterator) Failures() []StepFailure { return iter.failures }
+
+// Skipped returns the set of URNs whose steps were skipped because a resource they depend on failed.
+func (iter *PlanIterator) Skipped() map[resource.URN]bool { return iter.stepGen.skipped }
+
 // Apply performs a plan's step and records its result in the iterator's state.
 func (iter *PlanIterator) Apply(step Step, preview bool) (resource.Status, error) {
 	urn := step.URN()
@@ -138,6 +159,13 @@ func (iter *PlanIterator) Apply(step Step, preview bool) (resource.Status, error
 		}
 	}
 
+	// If we're continuing past errors, remember the failure so that dependents of this resource are skipped, and
+	// keep going.  The error message has already been issued through our diag subsystem.
+	if err != nil && iter.opts.ContinueOnError {
+		iter.failStep(step, status, err)
+		return resource.StatusOK, nil
+	}
+
 	// At this point, if err is not nil, we've already issued an error message through our
 	// diag subsystem and we need to bail.
 	//
@@ -155,6 +183,24 @@ func (iter *PlanIterator) Apply(step Step, preview bool) (resource.Status, error
 	return status, nil
 }
 
+// failStep records the failure of a step when continuing past errors.  Any steps remaining from the same registration
+// are abandoned, and if the program is still awaiting the result of that registration, it is sent the resource's
+// goal state so that it can proceed to register resources that don't depend on this one.
+func (iter *PlanIterator) failStep(step Step, status resource.Status, err error) {
+	logging.V(7).Infof("Step %v on %v failed; continuing with independent steps: %v", step.Op(), step.URN(), err)
+	iter.failures = append(iter.failures, StepFailure{Step: step, Status: status, Err: err})
+	iter.stepGen.failed[step.URN()] = true
+
+	if reg := iter.pendingReg; reg != nil {
+		iter.stepGen.failed[reg.urn] = true
+		iter.stepqueue = nil
+		iter.pendingReg = nil
+		if !reg.done {
+			reg.Done(&RegisterResult{State: reg.fallback})
+		}
+	}
+}
+
 // Close terminates the iteration of this plan.
 func (iter *PlanIterator) Close() error {
 	return iter.src.Close()
@@ -171,6 +217,9 @@ outer:
 			iter.stepqueue = iter.stepqueue[1:]
 			return step, nil
 		} else if !iter.srcdone {
+			// Any prior registration's steps have all been applied.
+			iter.pendingReg = nil
+
 			event, err := iter.src.Next()
 			if err != nil {
 				return nil, err
@@ -178,12 +227,30 @@ outer:
 				// If we have an event, drive the behavior based on which kind it is.
 				switch e := event.(type) {
 				case RegisterResourceEvent:
+					// If we're continuing past errors, track whether the program has been told of the outcome of this
+					// registration, so that we can unblock it should one of the resulting steps fail.
+					var tracked *trackedRegisterEvent
+					if iter.opts.ContinueOnError {
+						tracked = &trackedRegisterEvent{RegisterResourceEvent: e}
+						e = tracked
+					}
+
 					// If the intent is to register a resource, compute the plan steps necessary to do so.
 					steps, steperr := iter.stepGen.GenerateSteps(e)
 					if steperr != nil {
 						return nil, steperr
 					}
-					contract.Assert(len(steps) > 0)
+
+					// A resource that depends on a failed resource may be skipped without producing any steps.
+					if len(steps) == 0 {
+						contract.Assert(iter.opts.ContinueOnError)
+						continue outer
+					}
+					if tracked != nil {
+						last := steps[len(steps)-1]
+						tracked.urn, tracked.fallback = last.URN(), last.New()
+						iter.pendingReg = tracked
+					}
 					if len(steps) > 1 {
 						iter.stepqueue = steps[1:]
 					}
@@ -203,7 +270,11 @@ outer:
 			// If all returns are nil, the source is done, note it, and don't go back for more.  Add any deletions to be
 			// performed, and then keep going 'round the next iteration of the loop so we can wrap up the planning.
 			iter.srcdone = true
-			iter.delqueue = iter.stepGen.GenerateDeletes()
+			dels, delerr := iter.stepGen.GenerateDeletes()
+			if delerr != nil {
+				return nil, delerr
+			}
+			iter.delqueue = dels
 		} else {
 			// The interpreter has finished, so we need to now drain any deletions that piled up.
 			if step := iter.nextDeleteStep(); step != nil {
@@ -221,6 +292,15 @@ outer:
 func (iter *PlanIterator) registerResourceOutputs(e RegisterResourceOutputsEvent) error {
 	// Look up the final state in the pending registration list.
 	urn := e.URN()
+
+	// If this resource failed, or was skipped, there are no outputs to record.
+	if iter.stepGen.failed[urn] || iter.stepGen.skipped[urn] {
+		if _, has := iter.pendingNews[urn]; !has {
+			e.Done()
+			return nil
+		}
+	}
+
 	reg, has := iter.pendingNews[urn]
 	contract.Assertf(has, "cannot complete a resource '%v' whose registration isn't pending", urn)
 	contract.Assertf(reg != nil, "expected a non-nil resource step ('%v')", urn)
@@ -246,14 +326,51 @@ func (iter *PlanIterator) registerResourceOutputs(e RegisterResourceOutputsEvent
 
 // nextDeleteStep produces a new step that deletes a resource if necessary.
 func (iter *PlanIterator) nextDeleteStep() Step {
-	if len(iter.delqueue) > 0 {
+	for len(iter.delqueue) > 0 {
 		del := iter.delqueue[0]
 		iter.delqueue = iter.delqueue[1:]
+
+		// If we're continuing past errors, don't delete a resource that is still depended upon by a resource whose
+		// step failed or was skipped.
+		if iter.opts.ContinueOnError {
+			if dependent, has := iter.failedDependent(del.Old()); has {
+				iter.stepGen.skipResource(del.URN(), dependent)
+				continue
+			}
+		}
 		return del
 	}
 	return nil
 }
 
+// failedDependent returns the URN of a resource that depends on the given old resource and whose step either failed
+// or was skipped, if there is one.
+func (iter *PlanIterator) failedDependent(old *resource.State) (resource.URN, bool) {
+	if iter.p.depGraph == nil {
+		return "", false
+	}
+	for _, dependent := range iter.p.depGraph.DependingOn(old) {
+		if iter.stepGen.failed[dependent.URN] || iter.stepGen.skipped[dependent.URN] {
+			return dependent.URN, true
+		}
+	}
+	return "", false
+}
+
+// trackedRegisterEvent wraps a registration to track whether its result has been sent to the program.
+type trackedRegisterEvent struct {
+	RegisterResourceEvent
+
+	urn      resource.URN    // the URN of the registered resource.
+	fallback *resource.State // the state to send to the program if the registration fails.
+	done     bool            // true if the result has been sent.
+}
+
+func (e *trackedRegisterEvent) Done(result *RegisterResult) {
+	e.done = true
+	e.RegisterResourceEvent.Done(result)
+}
+
 // Provider fetches the provider for a given resource type, possibly lazily allocating the plugins for it.  If a
 // provider could not be found, or an error occurred while creating it, a non-nil error is returned.
 func (iter *PlanIterator) Provider(t tokens.Type) (plugin.Provider, error) {
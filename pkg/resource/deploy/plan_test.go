@@ -174,6 +174,7 @@ func TestBasicCRUDPlan(t *testing.T) {
 		nil,
 		"",
 		false,
+		false, 0,
 		nil,
 		[]string{},
 	)
@@ -188,6 +189,7 @@ func TestBasicCRUDPlan(t *testing.T) {
 		},
 		"",
 		false,
+		false, 0,
 		nil,
 		[]string{},
 	)
@@ -199,6 +201,7 @@ func TestBasicCRUDPlan(t *testing.T) {
 		nil,
 		"",
 		false,
+		false, 0,
 		nil,
 		[]string{},
 	)
@@ -209,20 +212,20 @@ func TestBasicCRUDPlan(t *testing.T) {
 	newResA := resource.NewGoal(typA, namA, true, resource.PropertyMap{
 		"af1": resource.NewStringProperty("a-value"),
 		"af2": resource.NewNumberProperty(42),
-	}, "", false, nil)
+	}, "", false, nil, 0, false, false, nil)
 	newStateA := &testRegEvent{goal: newResA}
 	//     - B is updated:
 	newResB := resource.NewGoal(typB, namB, true, resource.PropertyMap{
 		"bf1": resource.NewStringProperty("b-value"),
 		// delete the bf2 field, and add bf3.
 		"bf3": resource.NewBoolProperty(true),
-	}, "", false, nil)
+	}, "", false, nil, 0, false, false, nil)
 	newStateB := &testRegEvent{goal: newResB}
 	//     - C has no changes:
 	newResC := resource.NewGoal(typC, namC, true, resource.PropertyMap{
 		"cf1": resource.NewStringProperty("c-value"),
 		"cf2": resource.NewNumberProperty(83),
-	}, "", false, nil)
+	}, "", false, nil, 0, false, false, nil)
 	newStateC := &testRegEvent{goal: newResC}
 	//     - No D; it is deleted.
 
@@ -336,6 +339,316 @@ func TestBasicCRUDPlan(t *testing.T) {
 	assert.True(t, iter.Deletes()[urnD])
 }
 
+// TestContinueOnError ensures that a plan applied with ContinueOnError applies the steps of resources that don't
+// depend on a failed resource, and skips the rest.
+func TestContinueOnError(t *testing.T) {
+	t.Parallel()
+
+	typ := tokens.Type("test:index:Thing")
+	ctx, err := plugin.NewContext(cmdutil.Diag(), &testProviderHost{
+		provider: func(pkg tokens.Package, version *semver.Version) (plugin.Provider, error) {
+			return &testProvider{
+				check: func(urn resource.URN,
+					olds, news resource.PropertyMap) (resource.PropertyMap, []plugin.CheckFailure, error) {
+					return news, nil, nil
+				},
+				create: func(urn resource.URN,
+					props resource.PropertyMap) (resource.ID, resource.PropertyMap, resource.Status, error) {
+					if urn.Name() == "a" {
+						return "", nil, resource.StatusUnknown, errors.New("create failed")
+					}
+					return resource.ID(urn.Name()), resource.PropertyMap{}, resource.StatusOK, nil
+				},
+				delete: func(urn resource.URN, id resource.ID, props resource.PropertyMap) (resource.Status, error) {
+					return resource.StatusOK, nil
+				},
+			}, nil
+		},
+	}, nil, nil, "", nil)
+	assert.NoError(t, err)
+	defer func() { assert.NoError(t, ctx.Close()) }()
+
+	urn := func(name tokens.QName) resource.URN { return resource.NewURN("test", "proj", "", typ, name) }
+	old := func(name tokens.QName, deps ...resource.URN) *resource.State {
+		return resource.NewState(typ, urn(name), true, false, resource.ID(name), resource.PropertyMap{},
+			resource.PropertyMap{}, "", false, false, 0, deps, nil)
+	}
+	goal := func(name tokens.QName, deps ...resource.URN) *resource.Goal {
+		return resource.NewGoal(typ, name, true, resource.PropertyMap{}, "", false, deps, 0, false, false, nil)
+	}
+
+	// a fails to be created, so b and d, which depend on it, are skipped.  e isn't deleted, since d still depends on
+	// it, but c is created and f is deleted as they are independent of a.
+	prev := []*resource.State{old("e"), old("d", urn("e")), old("f")}
+	regA, regB := &testRegEvent{goal: goal("a")}, &testRegEvent{goal: goal("b", urn("a"))}
+	source := NewFixedSource("proj", []SourceEvent{
+		regA, regB, &testRegEvent{goal: goal("d", urn("a"), urn("e"))}, &testRegEvent{goal: goal("c")},
+	})
+	plan := NewPlan(ctx, &Target{Name: "test"}, NewSnapshot(Manifest{}, prev), source, nil, false)
+	iter, err := plan.Start(Options{ContinueOnError: true})
+	assert.NoError(t, err)
+	defer func() { assert.NoError(t, iter.Close()) }()
+
+	applied := make(map[resource.URN]StepOp)
+	for {
+		step, err := iter.Next()
+		assert.NoError(t, err)
+		if step == nil {
+			break
+		}
+		_, err = iter.Apply(step, false)
+		assert.NoError(t, err)
+		applied[step.URN()] = step.Op()
+	}
+
+	assert.Equal(t, map[resource.URN]StepOp{
+		urn("a"): OpCreate, urn("d"): OpSame, urn("c"): OpCreate, urn("f"): OpDelete,
+	}, applied)
+	if assert.Len(t, iter.Failures(), 1) {
+		assert.Equal(t, urn("a"), iter.Failures()[0].Step.URN())
+		assert.Equal(t, resource.StatusUnknown, iter.Failures()[0].Status)
+	}
+	assert.Equal(t, map[resource.URN]bool{urn("b"): true, urn("d"): true, urn("e"): true}, iter.Skipped())
+
+	// The program is told about the failed and skipped resources so that it can carry on.
+	if assert.NotNil(t, regA.result) {
+		assert.Equal(t, resource.ID(""), regA.result.State.ID)
+	}
+	assert.NotNil(t, regB.result)
+}
+
+// TestDeleteBeforeReplaceOption ensures that a program can request that a resource be deleted before it is replaced,
+// even if its provider doesn't.
+func TestDeleteBeforeReplaceOption(t *testing.T) {
+	t.Parallel()
+
+	typ := tokens.Type("test:index:Thing")
+	ctx, err := plugin.NewContext(cmdutil.Diag(), &testProviderHost{
+		provider: func(pkg tokens.Package, version *semver.Version) (plugin.Provider, error) {
+			return &testProvider{
+				check: func(urn resource.URN,
+					olds, news resource.PropertyMap) (resource.PropertyMap, []plugin.CheckFailure, error) {
+					return news, nil, nil
+				},
+				diff: func(urn resource.URN, id resource.ID, olds resource.PropertyMap,
+					news resource.PropertyMap) (plugin.DiffResult, error) {
+					return plugin.DiffResult{Changes: plugin.DiffSome, ReplaceKeys: []resource.PropertyKey{"name"}}, nil
+				},
+			}, nil
+		},
+	}, nil, nil, "", nil)
+	assert.NoError(t, err)
+	defer func() { assert.NoError(t, ctx.Close()) }()
+
+	urnA := resource.NewURN("test", "proj", "", typ, "a")
+	urnB := resource.NewURN("test", "proj", "", typ, "b")
+	prev := []*resource.State{
+		resource.NewState(typ, urnA, true, false, "a", resource.PropertyMap{"name": resource.NewStringProperty("x")},
+			resource.PropertyMap{}, "", false, false, 0, nil, nil),
+		resource.NewState(typ, urnB, true, false, "b", resource.PropertyMap{}, resource.PropertyMap{}, "", false, false, 0,
+			[]resource.URN{urnA}, nil),
+	}
+	source := NewFixedSource("proj", []SourceEvent{
+		&testRegEvent{goal: resource.NewGoal(typ, "a", true,
+			resource.PropertyMap{"name": resource.NewStringProperty("y")}, "", false, nil, 0, true, false, nil)},
+	})
+	plan := NewPlan(ctx, &Target{Name: "test"}, NewSnapshot(Manifest{}, prev), source, nil, true)
+	iter, err := plan.Start(Options{})
+	assert.NoError(t, err)
+	defer func() { assert.NoError(t, iter.Close()) }()
+
+	// a's dependent, b, must be deleted first, and then a itself, before a's replacement is created.
+	type op struct {
+		op  StepOp
+		urn resource.URN
+	}
+	var ops []op
+	for i := 0; i < 4; i++ {
+		step, err := iter.Next()
+		if !assert.NoError(t, err) || !assert.NotNil(t, step) {
+			return
+		}
+		ops = append(ops, op{step.Op(), step.URN()})
+	}
+	assert.Equal(t, []op{
+		{OpDeleteReplaced, urnB}, {OpDeleteReplaced, urnA}, {OpReplace, urnA}, {OpCreateReplacement, urnA},
+	}, ops)
+}
+
+func TestReplaceOnChanges(t *testing.T) {
+	t.Parallel()
+
+	typ := tokens.Type("test:index:Thing")
+	ctx, err := plugin.NewContext(cmdutil.Diag(), &testProviderHost{
+		provider: func(pkg tokens.Package, version *semver.Version) (plugin.Provider, error) {
+			return &testProvider{
+				check: func(urn resource.URN,
+					olds, news resource.PropertyMap) (resource.PropertyMap, []plugin.CheckFailure, error) {
+					return news, nil, nil
+				},
+				diff: func(urn resource.URN, id resource.ID, olds resource.PropertyMap,
+					news resource.PropertyMap) (plugin.DiffResult, error) {
+					return plugin.DiffResult{Changes: plugin.DiffSome}, nil
+				},
+			}, nil
+		},
+	}, nil, nil, "", nil)
+	assert.NoError(t, err)
+	defer func() { assert.NoError(t, ctx.Close()) }()
+
+	props := func(userData, name string) resource.PropertyMap {
+		return resource.PropertyMap{
+			"settings": resource.NewObjectProperty(resource.PropertyMap{
+				"userData": resource.NewStringProperty(userData),
+			}),
+			"name": resource.NewStringProperty(name),
+		}
+	}
+	replaceOnChanges := []string{"settings.userData"}
+
+	// a's user data changes, so it must be replaced; only b's name changes, so it can be updated in place.
+	urnA := resource.NewURN("test", "proj", "", typ, "a")
+	urnB := resource.NewURN("test", "proj", "", typ, "b")
+	prev := []*resource.State{
+		resource.NewState(typ, urnA, true, false, "a", props("x", "a"), resource.PropertyMap{}, "", false, false, 0,
+			nil, nil),
+		resource.NewState(typ, urnB, true, false, "b", props("x", "b"), resource.PropertyMap{}, "", false, false, 0,
+			nil, nil),
+	}
+	source := NewFixedSource("proj", []SourceEvent{
+		&testRegEvent{goal: resource.NewGoal(typ, "a", true, props("y", "a"), "", false, nil, 0, false, false,
+			replaceOnChanges)},
+		&testRegEvent{goal: resource.NewGoal(typ, "b", true, props("x", "c"), "", false, nil, 0, false, false,
+			replaceOnChanges)},
+	})
+	plan := NewPlan(ctx, &Target{Name: "test"}, NewSnapshot(Manifest{}, prev), source, nil, true)
+	iter, err := plan.Start(Options{})
+	assert.NoError(t, err)
+	defer func() { assert.NoError(t, iter.Close()) }()
+
+	var steps []Step
+	for i := 0; i < 3; i++ {
+		step, err := iter.Next()
+		if !assert.NoError(t, err) || !assert.NotNil(t, step) {
+			return
+		}
+		steps = append(steps, step)
+	}
+	assert.Equal(t, OpCreateReplacement, steps[0].Op())
+	assert.Equal(t, []resource.PropertyKey{"settings"}, steps[0].(*CreateStep).Keys())
+	assert.Equal(t, OpReplace, steps[1].Op())
+	assert.Equal(t, []resource.PropertyKey{"settings"}, steps[1].(*ReplaceStep).Keys())
+	assert.Equal(t, OpUpdate, steps[2].Op())
+	assert.Equal(t, urnB, steps[2].URN())
+}
+
+func TestRetainOnDelete(t *testing.T) {
+	t.Parallel()
+
+	typ := tokens.Type("test:index:Thing")
+	var deleted []resource.URN
+	ctx, err := plugin.NewContext(cmdutil.Diag(), &testProviderHost{
+		provider: func(pkg tokens.Package, version *semver.Version) (plugin.Provider, error) {
+			return &testProvider{
+				delete: func(urn resource.URN, id resource.ID, props resource.PropertyMap) (resource.Status, error) {
+					deleted = append(deleted, urn)
+					return resource.StatusOK, nil
+				},
+			}, nil
+		},
+	}, nil, nil, "", nil)
+	assert.NoError(t, err)
+	defer func() { assert.NoError(t, ctx.Close()) }()
+
+	// Neither resource is registered by the program, so both are deleted; only b actually reaches the provider.
+	urnA := resource.NewURN("test", "proj", "", typ, "a")
+	urnB := resource.NewURN("test", "proj", "", typ, "b")
+	prev := []*resource.State{
+		resource.NewState(typ, urnA, true, false, "a", resource.PropertyMap{}, resource.PropertyMap{}, "", false, true, 0,
+			nil, nil),
+		resource.NewState(typ, urnB, true, false, "b", resource.PropertyMap{}, resource.PropertyMap{}, "", false, false, 0,
+			nil, nil),
+	}
+	plan := NewPlan(ctx, &Target{Name: "test"}, NewSnapshot(Manifest{}, prev), NewFixedSource("proj", nil), nil, true)
+	iter, err := plan.Start(Options{})
+	assert.NoError(t, err)
+	defer func() { assert.NoError(t, iter.Close()) }()
+
+	var ops []StepOp
+	for {
+		step, err := iter.Next()
+		if !assert.NoError(t, err) {
+			return
+		}
+		if step == nil {
+			break
+		}
+		_, err = iter.Apply(step, false)
+		assert.NoError(t, err)
+		ops = append(ops, step.Op())
+	}
+	assert.Equal(t, []StepOp{OpDelete, OpDelete}, ops)
+	assert.Equal(t, []resource.URN{urnB}, deleted)
+}
+
+func TestRefreshDrift(t *testing.T) {
+	t.Parallel()
+
+	// The provider reports that a is unchanged, b's size has changed, and c no longer exists.
+	typ := tokens.Type("test:index:Thing")
+	live := map[resource.ID]resource.PropertyMap{
+		"a": {"size": resource.NewNumberProperty(1)},
+		"b": {"size": resource.NewNumberProperty(3)},
+	}
+	ctx, err := plugin.NewContext(cmdutil.Diag(), &testProviderHost{
+		provider: func(pkg tokens.Package, version *semver.Version) (plugin.Provider, error) {
+			return &testProvider{
+				read: func(urn resource.URN, id resource.ID, props resource.PropertyMap) (resource.PropertyMap, error) {
+					return live[id], nil
+				},
+			}, nil
+		},
+	}, nil, nil, "", nil)
+	assert.NoError(t, err)
+	defer func() { assert.NoError(t, ctx.Close()) }()
+
+	var prev []*resource.State
+	for _, name := range []string{"a", "b", "c"} {
+		props := resource.PropertyMap{"size": resource.NewNumberProperty(1)}
+		prev = append(prev, resource.NewState(typ, resource.NewURN("test", "proj", "", typ, tokens.QName(name)),
+			true, false, resource.ID(name), props, props, "", false, false, 0, nil, nil))
+	}
+	target := &Target{Name: "test", Snapshot: NewSnapshot(Manifest{}, prev)}
+	source := NewRefreshSource(ctx, &workspace.Project{Name: "proj"}, target, true)
+	plan := NewPlan(ctx, target, target.Snapshot, source, nil, true)
+	assert.True(t, plan.IsRefresh())
+	iter, err := plan.Start(Options{})
+	assert.NoError(t, err)
+	defer func() { assert.NoError(t, iter.Close()) }()
+
+	// Drift shows up as an update of b, which never requires replacement, and a delete of c.
+	var steps []Step
+	for {
+		step, err := iter.Next()
+		if !assert.NoError(t, err) {
+			return
+		}
+		if step == nil {
+			break
+		}
+		steps = append(steps, step)
+	}
+	if assert.Len(t, steps, 3) {
+		assert.Equal(t, OpSame, steps[0].Op())
+		assert.Equal(t, prev[0].URN, steps[0].URN())
+		assert.Equal(t, OpUpdate, steps[1].Op())
+		assert.Equal(t, prev[1].URN, steps[1].URN())
+		assert.Equal(t, live["b"], steps[1].New().Outputs)
+		assert.Equal(t, OpDelete, steps[2].Op())
+		assert.Equal(t, prev[2].URN, steps[2].URN())
+	}
+}
+
 type testRegEvent struct {
 	goal   *resource.Goal
 	result *RegisterResult
@@ -385,7 +698,8 @@ func (host *testProviderHost) Analyzer(nm tokens.QName) (plugin.Analyzer, error)
 func (host *testProviderHost) Provider(pkg tokens.Package, version *semver.Version) (plugin.Provider, error) {
 	return host.provider(pkg, version)
 }
-func (host *testProviderHost) LanguageRuntime(runtime string) (plugin.LanguageRuntime, error) {
+func (host *testProviderHost) LanguageRuntime(runtime string,
+	options map[string]interface{}) (plugin.LanguageRuntime, error) {
 	return host.langhost(runtime)
 }
 func (host *testProviderHost) ListPlugins() []workspace.PluginInfo {
@@ -0,0 +1,106 @@
+// Copyright 2016-2018, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package deploy
+
+import (
+	"context"
+	"time"
+
+	"github.com/pulumi/pulumi/pkg/diag"
+	"github.com/pulumi/pulumi/pkg/resource"
+	"github.com/pulumi/pulumi/pkg/resource/plugin"
+	"github.com/pulumi/pulumi/pkg/util/contract"
+	"github.com/pulumi/pulumi/pkg/util/logging"
+	"github.com/pulumi/pulumi/pkg/util/retry"
+	"github.com/pulumi/pulumi/pkg/util/rpcutil/rpcerror"
+)
+
+// DefaultRetries is the number of times a resource operation that a provider reports as having failed transiently is
+// retried, for resources that don't specify their own limit.
+const DefaultRetries = 3
+
+var (
+	retryDelay    = time.Second      // the base delay between retries, which is backed off before each one.
+	retryBackoff  = 2.0              // the backoff multiplier applied to the delay before each retry.
+	retryMaxDelay = 30 * time.Second // the maximum delay between retries.
+)
+
+// retryOperation performs a provider operation on a resource, retrying it with backoff for as long as it fails with
+// errors that the provider marks as retryable, up to the given number of retries (0 for the default, <0 for none).
+// Each retry is reported as a warning.  Retrying stops early if the plugin context is canceled.  The status and error
+// of the final attempt are returned.
+func retryOperation(ctx *plugin.Context, urn resource.URN, name string, retries int,
+	op func() (resource.Status, error)) (resource.Status, error) {
+
+	if retries == 0 {
+		retries = DefaultRetries
+	} else if retries < 0 {
+		retries = 0
+	}
+
+	// Stop waiting to retry as soon as the operations using the plugin context are asked to cancel.
+	cancelContext, cancel := context.WithCancel(ctx.Request())
+	defer cancel()
+	go func() {
+		select {
+		case <-ctx.Canceled():
+			logging.V(7).Infof("%s of %s canceled; no further retries will be made", name, urn)
+			cancel()
+		case <-cancelContext.Done():
+		}
+	}()
+
+	var status resource.Status
+	var err error
+	var requested time.Duration
+	_, _, untilErr := retry.Until(cancelContext, retry.Acceptor{
+		Accept: func(try int, nextRetryTime time.Duration) (bool, interface{}, error) {
+			status, err = op()
+			if err == nil || try >= retries {
+				return true, nil, nil
+			}
+			var retryable bool
+			retryable, requested = isRetryable(err)
+			return !retryable, nil, nil
+		},
+		Delay:    &retryDelay,
+		Backoff:  &retryBackoff,
+		MaxDelay: &retryMaxDelay,
+		Wait: func(try int, delay time.Duration) time.Duration {
+			// If the provider asked for a longer delay than the backoff calls for, honor it, within reason.
+			wait := delay
+			if requested > wait {
+				wait = requested
+			}
+			if wait > retryMaxDelay {
+				wait = retryMaxDelay
+			}
+			logging.V(7).Infof("%s of %s failed transiently (attempt %d); retrying: %v", name, urn, try+1, err)
+			ctx.Diag.Warningf(diag.GetResourceOperationRetryWarning(urn), name, try+1, retries+1, wait, err)
+			return wait
+		},
+	})
+	contract.AssertNoError(untilErr)
+	return status, err
+}
+
+// isRetryable returns true if the given error returned by a provider is marked as transient, along with the delay
+// the provider requested before retrying.
+func isRetryable(err error) (bool, time.Duration) {
+	if rpcErr, ok := rpcerror.FromError(err); ok && rpcErr != nil {
+		return rpcErr.Retryable()
+	}
+	return false, 0
+}
@@ -0,0 +1,158 @@
+// Copyright 2016-2018, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package deploy
+
+import (
+	"testing"
+	"time"
+
+	"github.com/blang/semver"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+
+	"github.com/pulumi/pulumi/pkg/resource"
+	"github.com/pulumi/pulumi/pkg/resource/plugin"
+	"github.com/pulumi/pulumi/pkg/tokens"
+	"github.com/pulumi/pulumi/pkg/util/cmdutil"
+	"github.com/pulumi/pulumi/pkg/util/rpcutil/rpcerror"
+)
+
+func TestRetryOperation(t *testing.T) {
+	defer func(delay, maxDelay time.Duration) { retryDelay, retryMaxDelay = delay, maxDelay }(retryDelay, retryMaxDelay)
+	retryDelay, retryMaxDelay = time.Millisecond, time.Millisecond
+
+	ctx, err := plugin.NewContext(cmdutil.Diag(), nil, nil, nil, "", nil)
+	assert.NoError(t, err)
+	defer func() { assert.NoError(t, ctx.Close()) }()
+
+	urn := resource.NewURN("test", "proj", "", "test:index:Thing", "a")
+	transient, ok := rpcerror.FromError(rpcerror.WithRetry(rpcerror.New(codes.Unavailable, "throttled"), 0))
+	assert.True(t, ok)
+	permanent, _ := rpcerror.FromError(rpcerror.New(codes.InvalidArgument, "bad input"))
+
+	// failing returns an operation that fails with the given errors before succeeding, counting its attempts.
+	failing := func(attempts *int, errs ...error) func() (resource.Status, error) {
+		return func() (resource.Status, error) {
+			*attempts++
+			if *attempts <= len(errs) {
+				return resource.StatusOK, errs[*attempts-1]
+			}
+			return resource.StatusOK, nil
+		}
+	}
+
+	// Transient failures are retried until the operation succeeds.
+	var attempts int
+	_, opErr := retryOperation(ctx, urn, "create", 0, failing(&attempts, transient, transient))
+	assert.NoError(t, opErr)
+	assert.Equal(t, 3, attempts)
+
+	// ... but only as many times as allowed.
+	attempts = 0
+	_, opErr = retryOperation(ctx, urn, "create", 1, failing(&attempts, transient, transient))
+	assert.Equal(t, transient, opErr)
+	assert.Equal(t, 2, attempts)
+
+	// Negative retries disable retrying.
+	attempts = 0
+	_, opErr = retryOperation(ctx, urn, "create", -1, failing(&attempts, transient))
+	assert.Equal(t, transient, opErr)
+	assert.Equal(t, 1, attempts)
+
+	// Other failures aren't retried at all.
+	attempts = 0
+	_, opErr = retryOperation(ctx, urn, "create", 0, failing(&attempts, permanent))
+	assert.Equal(t, permanent, opErr)
+	assert.Equal(t, 1, attempts)
+}
+
+func TestRetryOperationDelay(t *testing.T) {
+	defer func(delay, maxDelay time.Duration) { retryDelay, retryMaxDelay = delay, maxDelay }(retryDelay, retryMaxDelay)
+	retryDelay, retryMaxDelay = time.Millisecond, 10*time.Millisecond
+
+	ctx, err := plugin.NewContext(cmdutil.Diag(), nil, nil, nil, "", nil)
+	assert.NoError(t, err)
+	defer func() { assert.NoError(t, ctx.Close()) }()
+
+	urn := resource.NewURN("test", "proj", "", "test:index:Thing", "a")
+	throttled, ok := rpcerror.FromError(rpcerror.WithRetry(rpcerror.New(codes.Unavailable, "throttled"), time.Hour))
+	assert.True(t, ok)
+
+	// A delay requested by the provider is capped at the maximum delay.
+	attempts := 0
+	start := time.Now()
+	_, opErr := retryOperation(ctx, urn, "create", 1, func() (resource.Status, error) {
+		attempts++
+		return resource.StatusOK, throttled
+	})
+	assert.Equal(t, throttled, opErr)
+	assert.Equal(t, 2, attempts)
+	assert.True(t, time.Since(start) < time.Minute)
+
+	// Once the context is canceled, no further retries are made.
+	retryDelay, retryMaxDelay = time.Hour, time.Hour
+	signaled := make(chan struct{})
+	go func() {
+		defer close(signaled)
+		time.Sleep(10 * time.Millisecond)
+		assert.NoError(t, ctx.SignalCancellation())
+	}()
+	defer func() { <-signaled }()
+	attempts = 0
+	_, opErr = retryOperation(ctx, urn, "create", 0, func() (resource.Status, error) {
+		attempts++
+		return resource.StatusOK, throttled
+	})
+	assert.Equal(t, throttled, opErr)
+	assert.Equal(t, 1, attempts)
+}
+
+func TestDeleteRetries(t *testing.T) {
+	defer func(delay, maxDelay time.Duration) { retryDelay, retryMaxDelay = delay, maxDelay }(retryDelay, retryMaxDelay)
+	retryDelay, retryMaxDelay = time.Millisecond, time.Millisecond
+
+	transient, ok := rpcerror.FromError(rpcerror.WithRetry(rpcerror.New(codes.Unavailable, "throttled"), 0))
+	assert.True(t, ok)
+	attempts := make(map[resource.URN]int)
+	ctx, err := plugin.NewContext(cmdutil.Diag(), &testProviderHost{
+		provider: func(pkg tokens.Package, version *semver.Version) (plugin.Provider, error) {
+			return &testProvider{
+				delete: func(urn resource.URN, id resource.ID, props resource.PropertyMap) (resource.Status, error) {
+					attempts[urn]++
+					return resource.StatusOK, transient
+				},
+			}, nil
+		},
+	}, nil, nil, "", nil)
+	assert.NoError(t, err)
+	defer func() { assert.NoError(t, ctx.Close()) }()
+
+	// Deletes honor the number of retries recorded in each resource's state.
+	typ := tokens.Type("test:index:Thing")
+	urnA := resource.NewURN("test", "proj", "", typ, "a")
+	urnB := resource.NewURN("test", "proj", "", typ, "b")
+	prev := []*resource.State{
+		resource.NewState(typ, urnA, true, false, "a", resource.PropertyMap{}, resource.PropertyMap{}, "", false, false,
+			-1, nil, nil),
+		resource.NewState(typ, urnB, true, false, "b", resource.PropertyMap{}, resource.PropertyMap{}, "", false, false,
+			2, nil, nil),
+	}
+	plan := NewPlan(ctx, &Target{Name: "test"}, NewSnapshot(Manifest{}, prev), NewFixedSource("proj", nil), nil, false)
+	for _, old := range prev {
+		_, err = NewDeleteStep(plan, old).Apply(false)
+		assert.Equal(t, transient, err)
+	}
+	assert.Equal(t, map[resource.URN]int{urnA: 1, urnB: 3}, attempts)
+}
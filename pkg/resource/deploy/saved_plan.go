@@ -0,0 +1,191 @@
+// Copyright 2016-2018, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package deploy
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/pulumi/pulumi/pkg/resource"
+)
+
+// SavedPlan records the steps that a preview computed for each resource.  An update that is constrained to a saved
+// plan refuses to perform any step, or make any change to a resource's properties, that the plan does not contain.
+type SavedPlan struct {
+	Resources map[resource.URN]*ResourcePlan // the steps planned for each resource, keyed by URN.
+}
+
+// ResourcePlan records the steps planned for a single resource.
+type ResourcePlan struct {
+	Ops         []StepOp               // the operations planned for the resource, in order.
+	OldInputs   resource.PropertyMap   // the resource's inputs before the update, if it already existed.
+	NewInputs   resource.PropertyMap   // the resource's inputs after the update; unknown values match anything.
+	ReplaceKeys []resource.PropertyKey // the properties whose changes cause the resource to be replaced.
+}
+
+// NewSavedPlan creates a new, empty saved plan.
+func NewSavedPlan() *SavedPlan {
+	return &SavedPlan{Resources: make(map[resource.URN]*ResourcePlan)}
+}
+
+// recordStep adds the given step to the plan.
+func (p *SavedPlan) recordStep(step Step) {
+	urn := step.URN()
+	rp, has := p.Resources[urn]
+	if !has {
+		rp = &ResourcePlan{}
+		p.Resources[urn] = rp
+	}
+
+	rp.Ops = append(rp.Ops, step.Op())
+	if old := step.Old(); old != nil && !old.Delete && rp.OldInputs == nil {
+		rp.OldInputs = old.Inputs
+	}
+	if new := step.New(); new != nil {
+		rp.NewInputs = new.Inputs
+	}
+	if keys := stepReplaceKeys(step); len(keys) > 0 {
+		rp.ReplaceKeys = keys
+	}
+}
+
+// checkStep returns an error if the given step deviates from the plan.
+func (p *SavedPlan) checkStep(step Step) error {
+	urn, op := step.URN(), step.Op()
+	rp, has := p.Resources[urn]
+	if !has {
+		return planDeviation(step, "the resource is not in the plan")
+	}
+
+	// A resource may turn out to be unchanged if the preview could not know some of its inputs; this is harmless.
+	if !rp.hasOp(op) && !(op == OpSame && rp.hasOp(OpUpdate)) {
+		return planDeviation(step, "the plan expected %s", rp.describeOps())
+	}
+
+	if old := step.Old(); old != nil && !old.Delete && rp.OldInputs != nil {
+		if diffs := plannedPropertyDiffs(rp.OldInputs, old.Inputs); len(diffs) > 0 {
+			return planDeviation(step, "its state has changed since the plan was made (properties %v differ)", diffs)
+		}
+	}
+	if new := step.New(); new != nil && op != OpSame {
+		if diffs := plannedPropertyDiffs(rp.NewInputs, new.Inputs); len(diffs) > 0 {
+			return planDeviation(step, "properties %v differ from the plan", diffs)
+		}
+	}
+	if keys := stepReplaceKeys(step); (op == OpReplace || op == OpCreateReplacement) &&
+		!samePropertyKeys(keys, rp.ReplaceKeys) {
+		return planDeviation(step, "it would be replaced due to changes to %v, but the plan expected %v",
+			keys, rp.ReplaceKeys)
+	}
+	return nil
+}
+
+// planDeviation returns an error describing how the given step deviates from the plan.
+func planDeviation(step Step, format string, args ...interface{}) error {
+	return errors.Errorf("refusing to perform step '%s' for resource '%v', which deviates from the plan: %s",
+		step.Op(), step.URN(), fmt.Sprintf(format, args...))
+}
+
+func (rp *ResourcePlan) hasOp(op StepOp) bool {
+	for _, o := range rp.Ops {
+		if o == op {
+			return true
+		}
+	}
+	return false
+}
+
+func (rp *ResourcePlan) describeOps() string {
+	ops := make([]string, len(rp.Ops))
+	for i, op := range rp.Ops {
+		ops[i] = string(op)
+	}
+	return strings.Join(ops, ", ")
+}
+
+// stepReplaceKeys returns the keys that cause the given step's resource to be replaced, if any.
+func stepReplaceKeys(step Step) []resource.PropertyKey {
+	switch s := step.(type) {
+	case *CreateStep:
+		return s.Keys()
+	case *ReplaceStep:
+		return s.Keys()
+	default:
+		return nil
+	}
+}
+
+func samePropertyKeys(a, b []resource.PropertyKey) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	set := make(map[resource.PropertyKey]bool)
+	for _, k := range a {
+		set[k] = true
+	}
+	for _, k := range b {
+		if !set[k] {
+			return false
+		}
+	}
+	return true
+}
+
+// plannedPropertyDiffs returns the sorted keys of the actual properties whose values differ from the planned ones.
+func plannedPropertyDiffs(planned, actual resource.PropertyMap) []resource.PropertyKey {
+	keys := make(map[resource.PropertyKey]bool)
+	for k := range planned {
+		keys[k] = true
+	}
+	for k := range actual {
+		keys[k] = true
+	}
+
+	var diffs []resource.PropertyKey
+	for k := range keys {
+		if !plannedValueMatches(planned[k], actual[k]) {
+			diffs = append(diffs, k)
+		}
+	}
+	sort.Slice(diffs, func(i, j int) bool { return diffs[i] < diffs[j] })
+	return diffs
+}
+
+// plannedValueMatches returns true if the actual value matches the planned one.  Values that are unknown on either
+// side match anything, since they are only known once the resources they depend upon have been updated.
+func plannedValueMatches(planned, actual resource.PropertyValue) bool {
+	switch {
+	case planned.IsComputed() || planned.IsOutput() || actual.IsComputed() || actual.IsOutput():
+		return true
+	case planned.IsArray() && actual.IsArray():
+		plannedArr, actualArr := planned.ArrayValue(), actual.ArrayValue()
+		if len(plannedArr) != len(actualArr) {
+			return false
+		}
+		for i := range plannedArr {
+			if !plannedValueMatches(plannedArr[i], actualArr[i]) {
+				return false
+			}
+		}
+		return true
+	case planned.IsObject() && actual.IsObject():
+		return len(plannedPropertyDiffs(planned.ObjectValue(), actual.ObjectValue())) == 0
+	default:
+		return planned.DeepEquals(actual)
+	}
+}
@@ -0,0 +1,126 @@
+// Copyright 2016-2018, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package deploy
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/pulumi/pulumi/pkg/resource"
+	"github.com/pulumi/pulumi/pkg/resource/plugin"
+	"github.com/pulumi/pulumi/pkg/tokens"
+	"github.com/pulumi/pulumi/pkg/util/cmdutil"
+)
+
+// walkTestPlan plans the registration of the given goals against the previous resources, and returns the operations
+// performed or the first error encountered.
+func walkTestPlan(t *testing.T, prev []*resource.State, goals []*resource.Goal, opts Options,
+	preview bool) ([]StepOp, error) {
+
+	ctx, err := plugin.NewContext(cmdutil.Diag(), nil, nil, nil, "", nil)
+	assert.NoError(t, err)
+	defer func() { assert.NoError(t, ctx.Close()) }()
+
+	var events []SourceEvent
+	for _, goal := range goals {
+		events = append(events, &testRegEvent{goal: goal})
+	}
+	source := NewFixedSource("proj", events)
+	plan := NewPlan(ctx, &Target{Name: "test"}, NewSnapshot(Manifest{}, prev), source, nil, preview)
+
+	iter, err := plan.Start(opts)
+	assert.NoError(t, err)
+	defer func() { assert.NoError(t, iter.Close()) }()
+
+	var ops []StepOp
+	for {
+		step, err := iter.Next()
+		if err != nil || step == nil {
+			return ops, err
+		}
+		if _, err = iter.Apply(step, preview); err != nil {
+			return ops, err
+		}
+		ops = append(ops, step.Op())
+	}
+}
+
+func TestSavedPlan(t *testing.T) {
+	t.Parallel()
+
+	typ := tokens.Type("test:index:Thing")
+	urnA := resource.NewURN("test", "proj", "", typ, "a")
+	urnB := resource.NewURN("test", "proj", "", typ, "b")
+	prev := func() []*resource.State {
+		return []*resource.State{
+			resource.NewState(typ, urnA, false, false, "", resource.PropertyMap{"x": resource.NewNumberProperty(1)},
+				resource.PropertyMap{}, "", false, false, 0, nil, nil),
+			resource.NewState(typ, urnB, false, false, "", resource.PropertyMap{}, resource.PropertyMap{}, "", false, false, 0,
+				nil, nil),
+		}
+	}
+	goalA := func(x, y resource.PropertyValue) *resource.Goal {
+		return resource.NewGoal(typ, "a", false, resource.PropertyMap{"x": x, "y": y}, "", false, nil, 0, false, false,
+			nil)
+	}
+
+	// Preview an update to a whose y property is unknown, and a delete of b.
+	saved := NewSavedPlan()
+	unknown := resource.MakeComputed(resource.NewStringProperty(""))
+	ops, err := walkTestPlan(t, prev(), []*resource.Goal{goalA(resource.NewNumberProperty(2), unknown)},
+		Options{SavePlan: saved}, true)
+	assert.NoError(t, err)
+	assert.Equal(t, []StepOp{OpUpdate, OpDelete}, ops)
+	assert.Equal(t, []StepOp{OpUpdate}, saved.Resources[urnA].Ops)
+	assert.Equal(t, resource.NewNumberProperty(1), saved.Resources[urnA].OldInputs["x"])
+	assert.Equal(t, []StepOp{OpDelete}, saved.Resources[urnB].Ops)
+
+	// An update that conforms to the plan succeeds, whatever the value of the unknown property turns out to be.
+	ops, err = walkTestPlan(t, prev(), []*resource.Goal{
+		goalA(resource.NewNumberProperty(2), resource.NewStringProperty("known")),
+	}, Options{Plan: saved}, false)
+	assert.NoError(t, err)
+	assert.Equal(t, []StepOp{OpUpdate, OpDelete}, ops)
+
+	// An update that changes a property differently than planned fails before performing the step.
+	ops, err = walkTestPlan(t, prev(), []*resource.Goal{
+		goalA(resource.NewNumberProperty(3), resource.NewStringProperty("known")),
+	}, Options{Plan: saved}, false)
+	assert.Len(t, ops, 0)
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), "properties [x] differ from the plan")
+	}
+
+	// An update whose starting state differs from the plan's fails too.
+	changed := prev()
+	changed[0].Inputs["x"] = resource.NewNumberProperty(5)
+	_, err = walkTestPlan(t, changed, []*resource.Goal{
+		goalA(resource.NewNumberProperty(2), resource.NewStringProperty("known")),
+	}, Options{Plan: saved}, false)
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), "its state has changed since the plan was made")
+	}
+
+	// An update that performs an operation the plan does not contain fails as well.
+	ops, err = walkTestPlan(t, prev(), []*resource.Goal{
+		goalA(resource.NewNumberProperty(2), resource.NewStringProperty("known")),
+		resource.NewGoal(typ, "b", false, resource.PropertyMap{}, "", false, nil, 0, false, false, nil),
+	}, Options{Plan: saved}, false)
+	assert.Equal(t, []StepOp{OpUpdate}, ops)
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), "step 'same' for resource")
+	}
+}
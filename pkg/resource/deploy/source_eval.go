@@ -16,7 +16,9 @@ package deploy
 
 import (
 	"fmt"
+	"sync"
 
+	"github.com/blang/semver"
 	pbempty "github.com/golang/protobuf/ptypes/empty"
 	"github.com/pkg/errors"
 	"golang.org/x/net/context"
@@ -155,8 +157,8 @@ func (iter *evalSourceIterator) forkRun(opts Options) {
 	go func() {
 		// Next, launch the language plugin.
 		run := func() error {
-			rt := iter.src.runinfo.Proj.Runtime
-			langhost, err := iter.src.plugctx.Host.LanguageRuntime(rt)
+			rt := iter.src.runinfo.Proj.Runtime.Name
+			langhost, err := iter.src.plugctx.Host.LanguageRuntime(rt, iter.src.runinfo.Proj.Runtime.Options)
 			if err != nil {
 				return errors.Wrapf(err, "failed to launch language host %s", rt)
 			}
@@ -205,6 +207,8 @@ type resmon struct {
 	addr       string                             // the address the host is listening on.
 	cancel     chan bool                          // a channel that can cancel the server.
 	done       chan error                         // a channel that resolves when the server completes.
+	urns       map[resource.URN]bool              // the URNs of resources this program has registered or read.
+	urnsLock   sync.Mutex                         // a lock protecting the URNs map.
 }
 
 // newResourceMonitor creates a new resource monitor RPC server.
@@ -216,6 +220,7 @@ func newResourceMonitor(src *evalSource, regChan chan *registerResourceEvent,
 		regChan:    regChan,
 		regOutChan: regOutChan,
 		cancel:     make(chan bool),
+		urns:       make(map[resource.URN]bool),
 	}
 
 	// Fire up a gRPC server and start listening for incomings.
@@ -246,16 +251,55 @@ func (rm *resmon) Cancel() error {
 	return <-rm.done
 }
 
+// recordURN remembers that the resource with the given URN has been registered or read by this program.
+func (rm *resmon) recordURN(urn resource.URN) {
+	rm.urnsLock.Lock()
+	defer rm.urnsLock.Unlock()
+	rm.urns[urn] = true
+}
+
+// checkURNs ensures that each of the given URNs belongs to a resource this program has already registered or read.
+// A language host only learns a resource's URN once that operation has finished, so anything else is out of order.
+func (rm *resmon) checkURNs(what string, urns []string) error {
+	rm.urnsLock.Lock()
+	defer rm.urnsLock.Unlock()
+	for _, urn := range urns {
+		if !rm.urns[resource.URN(urn)] {
+			return errors.Errorf("%s '%s' has not been registered", what, urn)
+		}
+	}
+	return nil
+}
+
 // Invoke performs an invocation of a member located in a resource provider.
 func (rm *resmon) Invoke(ctx context.Context, req *pulumirpc.InvokeRequest) (*pulumirpc.InvokeResponse, error) {
-	// Fetch the token and load up the resource provider.
-	// TODO: we should be flowing version information about this request, but instead, we'll bind to the latest.
+	// Fetch the token and make sure that the resource the invoke is on behalf of, if any, is one we know about.
 	tok := tokens.ModuleMember(req.GetTok())
-	prov, err := rm.src.plugctx.Host.Provider(tok.Package(), nil)
+	parent := req.GetParent()
+	if parent != "" {
+		if err := rm.checkURNs("parent", []string{parent}); err != nil {
+			return nil, errors.Wrapf(err, "invalid parent for %v", tok)
+		}
+	}
+
+	// Load up the resource provider, binding to the requested package and version or else the token's latest.
+	pkg := tok.Package()
+	if p := req.GetProvider(); p != "" {
+		pkg = tokens.Package(p)
+	}
+	var version *semver.Version
+	if v := req.GetVersion(); v != "" {
+		sv, err := semver.ParseTolerant(v)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid provider version '%v' for %v", v, tok)
+		}
+		version = &sv
+	}
+	prov, err := rm.src.plugctx.Host.Provider(pkg, version)
 	if err != nil {
 		return nil, err
 	} else if prov == nil {
-		return nil, errors.Errorf("could not load resource provider for package '%v' from $PATH", tok.Package())
+		return nil, errors.Errorf("could not load resource provider for package '%v' from $PATH", pkg)
 	}
 
 	// Now unpack all of the arguments and prepare to perform the invocation.
@@ -267,7 +311,8 @@ func (rm *resmon) Invoke(ctx context.Context, req *pulumirpc.InvokeRequest) (*pu
 	}
 
 	// Do the invoke and then return the arguments.
-	logging.V(5).Infof("ResourceMonitor.Invoke received: tok=%v #args=%v", tok, len(args))
+	logging.V(5).Infof("ResourceMonitor.Invoke received: tok=%v #args=%v provider=%v version=%v parent=%v",
+		tok, len(args), pkg, req.GetVersion(), parent)
 	ret, failures, err := prov.Invoke(tok, args)
 	if err != nil {
 		return nil, errors.Wrapf(err, "invocation of %v returned an error", tok)
@@ -293,6 +338,12 @@ func (rm *resmon) ReadResource(ctx context.Context,
 	t := tokens.Type(req.GetType())
 	name := tokens.QName(req.GetName())
 	parent := resource.URN(req.GetParent())
+
+	// The read must follow the resources it depends on, so refuse any that this program hasn't finished with yet.
+	if err := rm.checkURNs("dependency", req.GetDependencies()); err != nil {
+		return nil, errors.Wrapf(err, "invalid dependencies for %v resource %v", t, name)
+	}
+
 	prov, err := rm.src.plugctx.Host.Provider(t.Package(), nil)
 	if err != nil {
 		return nil, err
@@ -322,8 +373,15 @@ func (rm *resmon) ReadResource(ctx context.Context,
 		}
 
 		// Now actually call the plugin to read the state and then return the results.
-		logging.V(5).Infof("ResourceMonitor.ReadResource received: %s #props=%d", label, len(props))
-		result, err := prov.Read(urn, id, props)
+		logging.V(5).Infof("ResourceMonitor.ReadResource received: %s #props=%d deps=%v",
+			label, len(props), req.GetDependencies())
+		var result resource.PropertyMap
+		retries := int(req.GetRetries())
+		_, err = retryOperation(rm.src.plugctx, urn, "read", retries, func() (resource.Status, error) {
+			var readErr error
+			result, readErr = prov.Read(urn, id, props)
+			return resource.StatusOK, readErr
+		})
 		if err != nil {
 			return nil, errors.Wrapf(err, "reading resource %s state", urn)
 		}
@@ -334,6 +392,7 @@ func (rm *resmon) ReadResource(ctx context.Context,
 		resp.Properties = marshaled
 	}
 
+	rm.recordURN(urn)
 	return resp, nil
 }
 
@@ -348,6 +407,10 @@ func (rm *resmon) RegisterResource(ctx context.Context,
 	custom := req.GetCustom()
 	parent := resource.URN(req.GetParent())
 	protect := req.GetProtect()
+	retries := int(req.GetRetries())
+	deleteBeforeReplace := req.GetDeleteBeforeReplace()
+	retainOnDelete := req.GetRetainOnDelete()
+	replaceOnChanges := req.GetReplaceOnChanges()
 
 	dependencies := []resource.URN{}
 	for _, dependingURN := range req.GetDependencies() {
@@ -361,12 +424,14 @@ func (rm *resmon) RegisterResource(ctx context.Context,
 	}
 
 	logging.V(5).Infof(
-		"ResourceMonitor.RegisterResource received: t=%v, name=%v, custom=%v, #props=%v, parent=%v, protect=%v, deps=%v",
-		t, name, custom, len(props), parent, protect, dependencies)
+		"ResourceMonitor.RegisterResource received: t=%v, name=%v, custom=%v, #props=%v, parent=%v, protect=%v, deps=%v, "+
+			"deleteBeforeReplace=%v, retainOnDelete=%v, replaceOnChanges=%v", t, name, custom, len(props), parent, protect,
+		dependencies, deleteBeforeReplace, retainOnDelete, replaceOnChanges)
 
 	// Send the goal state to the engine.
 	step := &registerResourceEvent{
-		goal: resource.NewGoal(t, name, custom, props, parent, protect, dependencies),
+		goal: resource.NewGoal(t, name, custom, props, parent, protect, dependencies, retries,
+			deleteBeforeReplace, retainOnDelete, replaceOnChanges),
 		done: make(chan *RegisterResult),
 	}
 
@@ -387,6 +452,7 @@ func (rm *resmon) RegisterResource(ctx context.Context,
 	}
 
 	state := result.State
+	rm.recordURN(state.URN)
 	props = state.All()
 	stable := result.Stable
 	var stables []string
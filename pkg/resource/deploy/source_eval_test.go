@@ -0,0 +1,45 @@
+// Copyright 2016-2018, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package deploy
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/net/context"
+
+	"github.com/pulumi/pulumi/pkg/resource"
+	pulumirpc "github.com/pulumi/pulumi/sdk/proto/go"
+)
+
+func TestResourceMonitorRejectsUnregisteredURNs(t *testing.T) {
+	rm := &resmon{urns: make(map[resource.URN]bool)}
+	urn := resource.NewURN("test", "proj", "", "test:index:Thing", "a")
+
+	// Reads may only depend on, and invokes may only be on behalf of, resources that have already been registered.
+	_, err := rm.ReadResource(context.Background(), &pulumirpc.ReadResourceRequest{
+		Type:         "test:index:Thing",
+		Name:         "b",
+		Id:           "some-id",
+		Dependencies: []string{string(urn)},
+	})
+	assert.Error(t, err)
+	_, err = rm.Invoke(context.Background(), &pulumirpc.InvokeRequest{Tok: "test:index:getThing", Parent: string(urn)})
+	assert.Error(t, err)
+
+	rm.recordURN(urn)
+	assert.NoError(t, rm.checkURNs("dependency", []string{string(urn)}))
+	assert.Error(t, rm.checkURNs("dependency", []string{string(urn), "urn:pulumi:test::proj::test:index:Thing::c"}))
+}
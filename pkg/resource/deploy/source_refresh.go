@@ -94,18 +94,24 @@ func (iter *refreshSourceIterator) newRefreshGoal(s *resource.State) (*resource.
 		if err != nil {
 			return nil, errors.Wrapf(err, "fetching provider to refresh %s", s.URN)
 		}
-		refreshed, err := provider.Read(s.URN, s.ID, s.Outputs)
+		var refreshed resource.PropertyMap
+		_, err = retryOperation(iter.plugctx, s.URN, "read", s.Retries, func() (resource.Status, error) {
+			var readErr error
+			refreshed, readErr = provider.Read(s.URN, s.ID, s.Outputs)
+			return resource.StatusOK, readErr
+		})
 		if err != nil {
 			return nil, errors.Wrapf(err, "refreshing %s's state", s.URN)
 		} else if refreshed == nil {
 			return nil, nil // the resource was deleted.
 		}
-		s = resource.NewState(
-			s.Type, s.URN, s.Custom, s.Delete, s.ID, s.Inputs, refreshed, s.Parent, s.Protect, s.Dependencies, s.InitErrors)
+		s = resource.NewState(s.Type, s.URN, s.Custom, s.Delete, s.ID, s.Inputs, refreshed, s.Parent, s.Protect,
+			s.RetainOnDelete, s.Retries, s.Dependencies, s.InitErrors)
 	}
 
 	// Now just return the actual state as the goal state.
-	return resource.NewGoal(s.Type, s.URN.Name(), s.Custom, s.Outputs, s.Parent, s.Protect, s.Dependencies), nil
+	return resource.NewGoal(s.Type, s.URN.Name(), s.Custom, s.Outputs, s.Parent, s.Protect, s.Dependencies,
+		s.Retries, false, s.RetainOnDelete, nil), nil
 }
 
 type refreshSourceEvent struct {
@@ -17,6 +17,7 @@ package deploy
 import (
 	"github.com/pkg/errors"
 
+	"github.com/pulumi/pulumi/pkg/diag"
 	"github.com/pulumi/pulumi/pkg/diag/colors"
 	"github.com/pulumi/pulumi/pkg/resource"
 	"github.com/pulumi/pulumi/pkg/resource/plugin"
@@ -157,13 +158,20 @@ func (s *CreateStep) Apply(preview bool) (resource.Status, error) {
 			if err != nil {
 				return resource.StatusOK, err
 			}
-			id, outs, rst, err := prov.Create(s.URN(), s.new.Inputs)
+			var id resource.ID
+			var outs resource.PropertyMap
+			rst, err := retryOperation(s.plan.ctx, s.URN(), "create", s.new.Retries,
+				func() (resource.Status, error) {
+					var createStatus resource.Status
+					var createErr error
+					id, outs, createStatus, createErr = prov.Create(s.URN(), s.new.Inputs)
+					return createStatus, createErr
+				})
 			if err != nil {
-				if rst == resource.StatusUnknown {
+				if rst != resource.StatusPartialFailure {
 					return rst, err
 				}
 
-				contract.Assert(rst == resource.StatusPartialFailure)
 				resourceError = err
 				resourceStatus = rst
 
@@ -246,13 +254,19 @@ func (s *DeleteStep) Apply(preview bool) (resource.Status, error) {
 	}
 
 	if !preview {
-		if s.old.Custom && !s.plan.IsRefresh() {
+		if s.old.Custom && s.old.RetainOnDelete && !s.plan.IsRefresh() {
+			// Resources that are retained on deletion are simply dropped from the snapshot; the provider is not told.
+			s.plan.Diag().Infof(diag.GetResourceRetainedMessage(s.URN()), s.old.ID)
+		} else if s.old.Custom && !s.plan.IsRefresh() {
 			// Invoke the Delete RPC function for this provider:
 			prov, err := getProvider(s)
 			if err != nil {
 				return resource.StatusOK, err
 			}
-			if rst, err := prov.Delete(s.URN(), s.old.ID, s.old.All()); err != nil {
+			rst, err := retryOperation(s.plan.ctx, s.URN(), "delete", s.old.Retries, func() (resource.Status, error) {
+				return prov.Delete(s.URN(), s.old.ID, s.old.All())
+			})
+			if err != nil {
 				return rst, err
 			}
 		}
@@ -317,13 +331,19 @@ func (s *UpdateStep) Apply(preview bool) (resource.Status, error) {
 			}
 
 			// Update to the combination of the old "all" state (including outputs), but overwritten with new inputs.
-			outs, rst, upderr := prov.Update(s.URN(), s.old.ID, s.old.All(), s.new.Inputs)
+			var outs resource.PropertyMap
+			rst, upderr := retryOperation(s.plan.ctx, s.URN(), "update", s.new.Retries,
+				func() (resource.Status, error) {
+					var updateStatus resource.Status
+					var updateErr error
+					outs, updateStatus, updateErr = prov.Update(s.URN(), s.old.ID, s.old.All(), s.new.Inputs)
+					return updateStatus, updateErr
+				})
 			if upderr != nil {
-				if rst == resource.StatusUnknown {
+				if rst != resource.StatusPartialFailure {
 					return rst, upderr
 				}
 
-				contract.Assert(rst == resource.StatusPartialFailure)
 				resourceError = upderr
 				resourceStatus = rst
 
@@ -15,6 +15,8 @@
 package deploy
 
 import (
+	"strings"
+
 	"github.com/pkg/errors"
 
 	"github.com/pulumi/pulumi/pkg/diag"
@@ -39,6 +41,8 @@ type stepGenerator struct {
 	updates  map[resource.URN]bool // set of URNs updated in this plan
 	creates  map[resource.URN]bool // set of URNs created in this plan
 	sames    map[resource.URN]bool // set of URNs that were not changed in this plan
+	failed   map[resource.URN]bool // set of URNs whose steps failed in this plan (only with ContinueOnError)
+	skipped  map[resource.URN]bool // set of URNs skipped because a resource they depend on failed
 }
 
 // GenerateSteps produces one or more steps required to achieve the goal state
@@ -47,7 +51,29 @@ type stepGenerator struct {
 // If the given resource is a custom resource, the step generator will invoke Diff
 // and Check on the provider associated with that resource. If those fail, an error
 // is returned.
+//
+// If the step generator is constrained to a saved plan, an error is returned if any of the steps deviate from it.
+//
+// If the step generator is continuing past errors and the resource depends on a resource whose step failed or was
+// skipped, the resource is skipped too: its old state, if any, is retained as-is, and otherwise no steps are produced.
 func (sg *stepGenerator) GenerateSteps(event RegisterResourceEvent) ([]Step, error) {
+	if sg.opts.ContinueOnError {
+		if steps, skipped := sg.generateSkippedSteps(event); skipped {
+			return steps, nil
+		}
+	}
+
+	steps, err := sg.generateSteps(event)
+	if err != nil {
+		return nil, err
+	}
+	if err = sg.checkPlannedSteps(steps); err != nil {
+		return nil, err
+	}
+	return steps, nil
+}
+
+func (sg *stepGenerator) generateSteps(event RegisterResourceEvent) ([]Step, error) {
 	var invalid bool // will be set to true if this object fails validation.
 
 	goal := event.Goal()
@@ -189,6 +215,11 @@ func (sg *stepGenerator) GenerateSteps(event RegisterResourceEvent) ([]Step, err
 				"unrecognized diff state for %s: %d", urn, diff.Changes)
 		}
 
+		// If the program asked for replacement whenever certain properties change, escalate the diff accordingly.
+		if !refresh {
+			diff = escalateReplaceOnChanges(diff, goal.ReplaceOnChanges, oldInputs, inputs)
+		}
+
 		// If there were changes, check for a replacement vs. an in-place update.
 		if diff.Changes == plugin.DiffSome {
 			if diff.Replace() {
@@ -224,9 +255,10 @@ func (sg *stepGenerator) GenerateSteps(event RegisterResourceEvent) ([]Step, err
 				//       until pulumi/pulumi#624 is resolved, we cannot safely perform this operation on resources
 				//       that have dependent resources (we try to delete the resource while they refer to it).
 				//
-				// The provider is responsible for requesting which of these two modes to use.
+				// The provider is responsible for requesting which of these two modes to use, although the program may
+				// also request delete-before-replace for a resource, e.g. because it has a fixed, unique name.
 
-				if diff.DeleteBeforeReplace {
+				if diff.DeleteBeforeReplace || goal.DeleteBeforeReplace {
 					logging.V(7).Infof("Planner decided to delete-before-replacement for resource '%v'", urn)
 					contract.Assert(sg.plan.depGraph != nil)
 
@@ -296,7 +328,51 @@ func (sg *stepGenerator) GenerateSteps(event RegisterResourceEvent) ([]Step, err
 	return []Step{NewCreateStep(sg.plan, event, new)}, nil
 }
 
-func (sg *stepGenerator) GenerateDeletes() []Step {
+// generateSkippedSteps skips the registration of a resource if its parent or any of its dependencies failed or were
+// skipped.  The returned steps retain the resource's old state; if there is none, the program is sent the resource's
+// goal state directly and no steps are returned.
+func (sg *stepGenerator) generateSkippedSteps(event RegisterResourceEvent) ([]Step, bool) {
+	goal := event.Goal()
+	var failedDep resource.URN
+	if sg.failed[goal.Parent] || sg.skipped[goal.Parent] {
+		failedDep = goal.Parent
+	}
+	for _, dep := range goal.Dependencies {
+		if failedDep == "" && (sg.failed[dep] || sg.skipped[dep]) {
+			failedDep = dep
+		}
+	}
+	if failedDep == "" {
+		return nil, false
+	}
+
+	urn := sg.generateURN(event)
+	sg.skipResource(urn, failedDep)
+	_, _, _, new := sg.getResourcePropertyStates(urn, goal)
+	old, hasOld := sg.plan.Olds()[urn]
+	if !hasOld {
+		event.Done(&RegisterResult{State: new})
+		return nil, true
+	}
+
+	sg.sames[urn] = true
+	retained := resource.NewState(old.Type, urn, old.Custom, false, "", old.Inputs, nil,
+		old.Parent, old.Protect, old.RetainOnDelete, old.Retries, old.Dependencies, old.InitErrors)
+	return []Step{NewSameStep(sg.plan, event, old, retained)}, true
+}
+
+// skipResource records that the given resource was skipped because of the failure of the given resource it is
+// related to, and warns about it.
+func (sg *stepGenerator) skipResource(urn resource.URN, failed resource.URN) {
+	logging.V(7).Infof("Planner decided to skip '%v' because '%v' failed", urn, failed)
+	sg.skipped[urn] = true
+	sg.plan.Diag().Warningf(diag.GetResourceSkippedWarning(urn), failed)
+}
+
+// GenerateDeletes produces the steps required to delete the resources that were not registered by the program, or
+// that are pending deletion.  If the step generator is constrained to a saved plan, an error is returned if any of
+// these deletes deviate from it.
+func (sg *stepGenerator) GenerateDeletes() ([]Step, error) {
 	// To compute the deletion list, we must walk the list of old resources *backwards*.  This is because the list is
 	// stored in dependency order, and earlier elements are possibly leaf nodes for later elements.  We must not delete
 	// dependencies prior to their dependent nodes.
@@ -343,8 +419,73 @@ func (sg *stepGenerator) GenerateDeletes() []Step {
 			}
 		}
 	}
-	return dels
+	if err := sg.checkPlannedSteps(dels); err != nil {
+		return nil, err
+	}
+	return dels, nil
+}
+
+// checkPlannedSteps ensures that the given steps conform to the saved plan this step generator is constrained to, if
+// any, and then records them in the plan being saved, if any.
+func (sg *stepGenerator) checkPlannedSteps(steps []Step) error {
+	for _, step := range steps {
+		if sg.opts.Plan != nil {
+			if err := sg.opts.Plan.checkStep(step); err != nil {
+				return err
+			}
+		}
+		if sg.opts.SavePlan != nil {
+			sg.opts.SavePlan.recordStep(step)
+		}
+	}
+	return nil
+}
+
+// escalateReplaceOnChanges turns the given diff into a replacement if any of the given property paths differ between
+// the old and new inputs, adding the top-level keys of those paths to the diff's replacement keys.
+func escalateReplaceOnChanges(diff plugin.DiffResult, paths []string,
+	oldInputs, newInputs resource.PropertyMap) plugin.DiffResult {
+	for _, path := range paths {
+		keys := strings.Split(path, ".")
+		oldValue, newValue := lookupPropertyPath(oldInputs, keys), lookupPropertyPath(newInputs, keys)
+		if oldValue.DeepEquals(newValue) {
+			continue
+		}
+
+		diff.Changes = plugin.DiffSome
+		key := resource.PropertyKey(keys[0])
+		if !containsPropertyKey(diff.ReplaceKeys, key) {
+			diff.ReplaceKeys = append(diff.ReplaceKeys, key)
+		}
+	}
+	return diff
+}
+
+// lookupPropertyPath returns the value found by following the given keys through nested objects, or a null value if
+// the path does not exist.
+func lookupPropertyPath(props resource.PropertyMap, keys []string) resource.PropertyValue {
+	value := resource.NewObjectProperty(props)
+	for _, key := range keys {
+		if !value.IsObject() {
+			return resource.NewNullProperty()
+		}
+		v, has := value.ObjectValue()[resource.PropertyKey(key)]
+		if !has {
+			return resource.NewNullProperty()
+		}
+		value = v
+	}
+	return value
+}
 
+// containsPropertyKey returns true if the given key is in the list of keys.
+func containsPropertyKey(keys []resource.PropertyKey, key resource.PropertyKey) bool {
+	for _, k := range keys {
+		if k == key {
+			return true
+		}
+	}
+	return false
 }
 
 // diff returns a DiffResult for the given resource.
@@ -369,6 +510,11 @@ func (sg *stepGenerator) diff(urn resource.URN, id resource.ID, oldInputs, oldOu
 		return plugin.DiffResult{Changes: plugin.DiffNone}, nil
 	}
 
+	// A refresh just records the resource's live state, so any differences are drift, which never require replacement.
+	if refresh {
+		return plugin.DiffResult{Changes: plugin.DiffSome}, nil
+	}
+
 	// If there is no provider for this resource, simply return a "diffs exist" result.
 	if prov == nil {
 		return plugin.DiffResult{Changes: plugin.DiffSome}, nil
@@ -405,7 +551,7 @@ func (sg *stepGenerator) getResourcePropertyStates(urn resource.URN, goal *resou
 	}
 	return props, inputs, outputs,
 		resource.NewState(goal.Type, urn, goal.Custom, false, "",
-			inputs, outputs, goal.Parent, goal.Protect, goal.Dependencies, []string{})
+			inputs, outputs, goal.Parent, goal.Protect, goal.RetainOnDelete, goal.Retries, goal.Dependencies, []string{})
 
 }
 
@@ -471,5 +617,7 @@ func newStepGenerator(plan *Plan, opts Options) *stepGenerator {
 		replaces: make(map[resource.URN]bool),
 		updates:  make(map[resource.URN]bool),
 		deletes:  make(map[resource.URN]bool),
+		failed:   make(map[resource.URN]bool),
+		skipped:  make(map[resource.URN]bool),
 	}
 }
@@ -0,0 +1,401 @@
+// Copyright 2016-2018, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package edit contains operations that modify a deployment snapshot directly, outside of the usual plan/apply
+// lifecycle.  These are used by commands that rename stacks or otherwise perform surgery on a stack's state.
+package edit
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/pulumi/pulumi/pkg/resource"
+	"github.com/pulumi/pulumi/pkg/resource/deploy"
+	"github.com/pulumi/pulumi/pkg/resource/graph"
+	"github.com/pulumi/pulumi/pkg/tokens"
+	"github.com/pulumi/pulumi/pkg/util/contract"
+)
+
+// RenameStack rewrites every URN in the given snapshot -- each resource's own URN, its parent, and its
+// dependencies -- so that they refer to the stack newName rather than the stack they were created in.
+func RenameStack(snap *deploy.Snapshot, newName tokens.QName) {
+	contract.Require(snap != nil, "snap")
+	contract.Require(newName != "", "newName")
+
+	rewriteURNs(snap.Resources, func(urn resource.URN) resource.URN { return renameStack(urn, newName) })
+}
+
+// ResourceNotFoundError is returned when a URN given to one of the operations in this package does not refer to any
+// resource in the snapshot.
+type ResourceNotFoundError struct {
+	URN resource.URN
+}
+
+func (e ResourceNotFoundError) Error() string {
+	return fmt.Sprintf("no resource named '%s' found", e.URN)
+}
+
+// ResourceProtectedError is returned by DeleteResource when the resource to be deleted is protected.
+type ResourceProtectedError struct {
+	Condemned *resource.State
+}
+
+func (e ResourceProtectedError) Error() string {
+	return fmt.Sprintf("resource '%s' is protected; unprotect it first", e.Condemned.URN)
+}
+
+// ResourceHasDependenciesError is returned by DeleteResource when other resources in the snapshot depend on, or are
+// children of, the resource to be deleted.
+type ResourceHasDependenciesError struct {
+	Condemned    *resource.State
+	Dependencies []*resource.State
+}
+
+func (e ResourceHasDependenciesError) Error() string {
+	var urns []string
+	for _, dep := range e.Dependencies {
+		urns = append(urns, string(dep.URN))
+	}
+	return fmt.Sprintf("resource '%s' is depended on by: %s", e.Condemned.URN, strings.Join(urns, ", "))
+}
+
+// LocateResource returns the resource in the snapshot with the given URN.  If several resources share the URN because
+// some are pending deletion, the live one is preferred.
+func LocateResource(snap *deploy.Snapshot, urn resource.URN) (*resource.State, error) {
+	var found *resource.State
+	if snap != nil {
+		for _, res := range snap.Resources {
+			if res.URN == urn && (found == nil || found.Delete) {
+				found = res
+			}
+		}
+	}
+	if found == nil {
+		return nil, ResourceNotFoundError{URN: urn}
+	}
+	return found, nil
+}
+
+// DeleteResource removes the given resource from the snapshot without touching the cloud resource it describes.
+// Protected resources are never deleted.  If other resources depend on, or are children of, the condemned resource,
+// the deletion is refused unless force is true, in which case dependents forget the dependency and children are
+// reparented to the condemned resource's own parent.
+func DeleteResource(snap *deploy.Snapshot, condemned *resource.State, force bool) error {
+	contract.Require(snap != nil, "snap")
+	contract.Require(condemned != nil, "condemned")
+
+	if condemned.Protect {
+		return ResourceProtectedError{Condemned: condemned}
+	}
+
+	// Anything that depends on the condemned resource, directly or indirectly, or that is its child, blocks deletion.
+	dependents := make(map[*resource.State]bool)
+	for _, dep := range graph.NewDependencyGraph(snap.Resources).DependingOn(condemned) {
+		dependents[dep] = true
+	}
+	var blockers, children []*resource.State
+	for _, res := range snap.Resources {
+		if res.Parent == condemned.URN {
+			children = append(children, res)
+		}
+		if dependents[res] || res.Parent == condemned.URN {
+			blockers = append(blockers, res)
+		}
+	}
+
+	if len(blockers) > 0 {
+		if !force {
+			return ResourceHasDependenciesError{Condemned: condemned, Dependencies: blockers}
+		}
+
+		for _, res := range snap.Resources {
+			res.Dependencies = removeURN(res.Dependencies, condemned.URN)
+		}
+		var parent *resource.State
+		if condemned.Parent != "" {
+			p, err := LocateResource(snap, condemned.Parent)
+			if err != nil {
+				return err
+			}
+			parent = p
+		}
+		for _, child := range children {
+			if err := ReparentResource(snap, child, parent); err != nil {
+				return err
+			}
+		}
+	}
+
+	for i, res := range snap.Resources {
+		if res == condemned {
+			snap.Resources = append(snap.Resources[:i:i], snap.Resources[i+1:]...)
+			break
+		}
+	}
+	return nil
+}
+
+// ProtectResource sets the given resource's protect bit, so that it may not be deleted.
+func ProtectResource(snap *deploy.Snapshot, res *resource.State) {
+	contract.Require(snap != nil, "snap")
+	contract.Require(res != nil, "res")
+	res.Protect = true
+}
+
+// UnprotectResource clears the given resource's protect bit, so that it may subsequently be deleted.
+func UnprotectResource(snap *deploy.Snapshot, res *resource.State) {
+	contract.Require(snap != nil, "snap")
+	contract.Require(res != nil, "res")
+	res.Protect = false
+}
+
+// ReparentResource makes newParent the parent of the given resource, or leaves it without a parent if newParent is
+// nil.  Because a resource's URN encodes its parent's type, the URNs of the resource and all of its descendants are
+// rewritten, along with every reference to them, and the snapshot is reordered so that parents precede children.
+func ReparentResource(snap *deploy.Snapshot, res *resource.State, newParent *resource.State) error {
+	contract.Require(snap != nil, "snap")
+	contract.Require(res != nil, "res")
+
+	var parentURN resource.URN
+	if newParent != nil {
+		for p := newParent; p != nil; p = findParent(snap, p) {
+			if p.URN == res.URN {
+				return errors.Errorf("cannot make '%s' a child of itself or of one of its descendants", res.URN)
+			}
+		}
+		parentURN = newParent.URN
+	}
+
+	// Compute the new URNs of the resource and its descendants, top-down, so each child sees its parent's new type.
+	renames := make(map[resource.URN]resource.URN)
+	var rename func(urn, parent resource.URN)
+	rename = func(urn, parent resource.URN) {
+		var parentType tokens.Type
+		if parent != "" && parent.Type() != resource.RootStackType {
+			parentType = parent.QualifiedType()
+		}
+		renamed := resource.NewURN(urn.Stack(), urn.Project(), parentType, urn.Type(), urn.Name())
+		renames[urn] = renamed
+		for _, other := range snap.Resources {
+			if other.Parent == urn {
+				if _, done := renames[other.URN]; !done {
+					rename(other.URN, renamed)
+				}
+			}
+		}
+	}
+	rename(res.URN, parentURN)
+
+	for old, renamed := range renames {
+		if existing, err := LocateResource(snap, renamed); old != renamed && err == nil {
+			return errors.Errorf("cannot rename '%s' to '%s'; a resource with that URN already exists", old, existing.URN)
+		}
+	}
+
+	res.Parent = parentURN
+	rewriteURNs(snap.Resources, func(urn resource.URN) resource.URN {
+		if renamed, has := renames[urn]; has {
+			return renamed
+		}
+		return urn
+	})
+	snap.Resources = sortResources(snap.Resources)
+	return nil
+}
+
+// MoveResources moves the given resources, along with all of their descendants, from the source snapshot to the
+// destination snapshot, rewriting their URNs to refer to the destination stack.  Children of the source stack's root
+// stack resource become children of the destination's root stack resource, or are left without a parent if the
+// destination has none yet.  The move is refused if the stacks belong to different projects, if resources that stay
+// behind depend on the moved ones, or if the moved resources refer to resources that the destination lacks.
+func MoveResources(source, dest *deploy.Snapshot, resources []*resource.State, destStack tokens.QName) error {
+	contract.Require(source != nil, "source")
+	contract.Require(dest != nil, "dest")
+	contract.Require(destStack != "", "destStack")
+
+	// Gather the full set of resources to move: the requested ones and all of their descendants.
+	moving := make(map[*resource.State]bool)
+	movingURNs := make(map[resource.URN]bool)
+	for _, res := range resources {
+		if res.Type == resource.RootStackType {
+			return errors.Errorf("cannot move the root stack resource '%s'", res.URN)
+		}
+		moving[res] = true
+		movingURNs[res.URN] = true
+	}
+	for _, res := range source.Resources {
+		if movingURNs[res.Parent] {
+			moving[res] = true
+			movingURNs[res.URN] = true
+		}
+	}
+
+	var moved, remaining []*resource.State
+	for _, res := range source.Resources {
+		if moving[res] {
+			moved = append(moved, res)
+		} else {
+			remaining = append(remaining, res)
+		}
+	}
+	if len(moved) == 0 {
+		return nil
+	}
+
+	// A resource's URN names its project, which the destination stack must share.
+	project := moved[0].URN.Project()
+	for _, res := range append(moved, dest.Resources...) {
+		if res.URN.Project() != project {
+			return errors.Errorf("cannot move resources of project '%s' to a stack of project '%s'",
+				project, res.URN.Project())
+		}
+	}
+
+	// Ensure nothing left behind depends on a resource that is moving away.
+	for _, res := range remaining {
+		for _, dep := range res.Dependencies {
+			if movingURNs[dep] {
+				return errors.Errorf("resource '%s' depends on '%s'; it must be moved too", res.URN, dep)
+			}
+		}
+	}
+
+	// References to the source's root stack resource become references to the destination's.
+	var sourceRoot, destRoot resource.URN
+	if root := findRootStack(source); root != nil {
+		sourceRoot = root.URN
+	}
+	if root := findRootStack(dest); root != nil {
+		destRoot = root.URN
+	}
+	rename := func(urn resource.URN) resource.URN {
+		if urn != "" && urn == sourceRoot {
+			return destRoot
+		}
+		return renameStack(urn, destStack)
+	}
+
+	// Ensure that everything the moved resources refer to will exist in the destination, and that nothing collides.
+	destURNs := make(map[resource.URN]bool)
+	for _, res := range dest.Resources {
+		destURNs[res.URN] = true
+	}
+	for _, res := range moved {
+		renamed := rename(res.URN)
+		if destURNs[renamed] {
+			return errors.Errorf("a resource named '%s' already exists in the destination stack", renamed)
+		}
+		for _, ref := range append([]resource.URN{res.Parent}, res.Dependencies...) {
+			if ref != "" && ref != sourceRoot && !movingURNs[ref] && !destURNs[rename(ref)] {
+				return errors.Errorf("resource '%s' refers to '%s', which does not exist in the destination stack",
+					res.URN, ref)
+			}
+		}
+	}
+
+	// If the destination has no root stack resource, references to the source's are dropped.
+	rewriteURNs(moved, rename)
+	for _, res := range moved {
+		res.Dependencies = removeURN(res.Dependencies, "")
+	}
+	source.Resources = remaining
+	dest.Resources = append(dest.Resources, moved...)
+	return nil
+}
+
+// renameStack returns the given URN rewritten to refer to the given stack.
+func renameStack(urn resource.URN, stack tokens.QName) resource.URN {
+	if urn == "" {
+		return urn
+	}
+	return resource.NewURN(stack, urn.Project(), "", urn.QualifiedType(), urn.Name())
+}
+
+// rewriteURNs applies the given function to every URN in the given resources: each resource's own URN, its parent,
+// and its dependencies.
+func rewriteURNs(resources []*resource.State, rewrite func(resource.URN) resource.URN) {
+	for _, res := range resources {
+		res.URN = rewrite(res.URN)
+		if res.Parent != "" {
+			res.Parent = rewrite(res.Parent)
+		}
+		for i, dep := range res.Dependencies {
+			res.Dependencies[i] = rewrite(dep)
+		}
+	}
+}
+
+// findRootStack returns the live root stack resource in the snapshot, or nil if it has none.
+func findRootStack(snap *deploy.Snapshot) *resource.State {
+	for _, res := range snap.Resources {
+		if res.Type == resource.RootStackType && !res.Delete {
+			return res
+		}
+	}
+	return nil
+}
+
+// findParent returns the given resource's parent in the snapshot, or nil if it has none.
+func findParent(snap *deploy.Snapshot, res *resource.State) *resource.State {
+	if res.Parent == "" {
+		return nil
+	}
+	parent, err := LocateResource(snap, res.Parent)
+	if err != nil {
+		return nil
+	}
+	return parent
+}
+
+// removeURN returns the given list of URNs without any occurrences of the given URN.
+func removeURN(urns []resource.URN, urn resource.URN) []resource.URN {
+	result := urns[:0:0]
+	for _, u := range urns {
+		if u != urn {
+			result = append(result, u)
+		}
+	}
+	return result
+}
+
+// sortResources returns the given resources reordered so that parents and dependencies precede the resources that
+// refer to them, otherwise keeping the existing order as much as possible.
+func sortResources(resources []*resource.State) []*resource.State {
+	byURN := make(map[resource.URN][]*resource.State)
+	for _, res := range resources {
+		byURN[res.URN] = append(byURN[res.URN], res)
+	}
+
+	sorted := make([]*resource.State, 0, len(resources))
+	visited := make(map[*resource.State]bool)
+	var visit func(res *resource.State)
+	visit = func(res *resource.State) {
+		if visited[res] {
+			return
+		}
+		visited[res] = true
+		for _, ref := range append([]resource.URN{res.Parent}, res.Dependencies...) {
+			for _, other := range byURN[ref] {
+				visit(other)
+			}
+		}
+		sorted = append(sorted, res)
+	}
+	for _, res := range resources {
+		visit(res)
+	}
+	return sorted
+}
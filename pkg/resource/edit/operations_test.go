@@ -0,0 +1,206 @@
+// Copyright 2016-2018, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package edit
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/pulumi/pulumi/pkg/resource"
+	"github.com/pulumi/pulumi/pkg/resource/deploy"
+	"github.com/pulumi/pulumi/pkg/tokens"
+)
+
+func newResource(stack tokens.QName, name string, parent *resource.State, deps ...*resource.State) *resource.State {
+	var parentType tokens.Type
+	var parentURN resource.URN
+	if parent != nil {
+		parentType = parent.URN.QualifiedType()
+		parentURN = parent.URN
+	}
+
+	var dependencies []resource.URN
+	for _, d := range deps {
+		dependencies = append(dependencies, d.URN)
+	}
+
+	t := tokens.Type("test:resource:Type")
+	return &resource.State{
+		Type:         t,
+		URN:          resource.NewURN(stack, "test", parentType, t, tokens.QName(name)),
+		Inputs:       resource.PropertyMap{},
+		Outputs:      resource.PropertyMap{},
+		Parent:       parentURN,
+		Dependencies: dependencies,
+	}
+}
+
+func TestRenameStack(t *testing.T) {
+	a := newResource("old", "a", nil)
+	b := newResource("old", "b", a)
+	c := newResource("old", "c", a, b)
+	snap := deploy.NewSnapshot(deploy.Manifest{}, []*resource.State{a, b, c})
+
+	RenameStack(snap, "new")
+	assert.NoError(t, snap.VerifyIntegrity())
+
+	for _, res := range snap.Resources {
+		assert.Equal(t, tokens.QName("new"), res.URN.Stack())
+		assert.Equal(t, tokens.PackageName("test"), res.URN.Project())
+	}
+	assert.Equal(t, resource.URN(""), a.Parent)
+	assert.Equal(t, a.URN, b.Parent)
+	assert.Equal(t, b.URN.QualifiedType(), tokens.Type("test:resource:Type$test:resource:Type"))
+	assert.Equal(t, a.URN, c.Parent)
+	assert.Equal(t, []resource.URN{b.URN}, c.Dependencies)
+}
+
+func TestDeleteResource(t *testing.T) {
+	a := newResource("stack", "a", nil)
+	b := newResource("stack", "b", a)
+	c := newResource("stack", "c", nil, a)
+	d := newResource("stack", "d", nil)
+	d.Protect = true
+	snap := deploy.NewSnapshot(deploy.Manifest{}, []*resource.State{a, b, c, d})
+
+	// a has a child and a dependent, so it can't be deleted without forcing it.
+	err := DeleteResource(snap, a, false)
+	if assert.IsType(t, ResourceHasDependenciesError{}, err) {
+		assert.Equal(t, []*resource.State{b, c}, err.(ResourceHasDependenciesError).Dependencies)
+	}
+
+	// d is protected, so it can't be deleted at all.
+	assert.IsType(t, ResourceProtectedError{}, DeleteResource(snap, d, true))
+	UnprotectResource(snap, d)
+	assert.False(t, d.Protect)
+	assert.NoError(t, DeleteResource(snap, d, false))
+	ProtectResource(snap, c)
+	assert.True(t, c.Protect)
+	UnprotectResource(snap, c)
+
+	// Forcing a's deletion reparents b and drops c's dependency.
+	assert.NoError(t, DeleteResource(snap, a, true))
+	assert.NoError(t, snap.VerifyIntegrity())
+	assert.Equal(t, []*resource.State{b, c}, snap.Resources)
+	assert.Equal(t, resource.URN(""), b.Parent)
+	assert.Equal(t, tokens.Type("test:resource:Type"), b.URN.QualifiedType())
+	assert.Empty(t, c.Dependencies)
+
+	_, err = LocateResource(snap, a.URN)
+	assert.IsType(t, ResourceNotFoundError{}, err)
+}
+
+func TestReparentResource(t *testing.T) {
+	a := newResource("stack", "a", nil)
+	b := newResource("stack", "b", nil)
+	c := newResource("stack", "c", b)
+	d := newResource("stack", "d", nil, c)
+	e := newResource("stack", "e", nil)
+	snap := deploy.NewSnapshot(deploy.Manifest{}, []*resource.State{b, c, d, a, e})
+
+	// Making b a child of a, which comes after it, rewrites b's and c's URNs and moves them after a.
+	assert.NoError(t, ReparentResource(snap, b, a))
+	assert.NoError(t, snap.VerifyIntegrity())
+	assert.Equal(t, []*resource.State{a, b, c, d, e}, snap.Resources)
+	assert.Equal(t, a.URN, b.Parent)
+	assert.Equal(t, b.URN, c.Parent)
+	assert.Equal(t, tokens.Type("test:resource:Type$test:resource:Type$test:resource:Type"), c.URN.QualifiedType())
+	assert.Equal(t, []resource.URN{c.URN}, d.Dependencies)
+
+	// A resource can't be made a child of one of its own descendants.
+	assert.Error(t, ReparentResource(snap, a, c))
+}
+
+func TestMoveResources(t *testing.T) {
+	a := newResource("src", "a", nil)
+	b := newResource("src", "b", a)
+	c := newResource("src", "c", nil, a)
+	source := deploy.NewSnapshot(deploy.Manifest{}, []*resource.State{a, b, c})
+	dest := deploy.NewSnapshot(deploy.Manifest{}, []*resource.State{newResource("dst", "x", nil)})
+
+	// c depends on a, so a can't be moved without it.
+	assert.Error(t, MoveResources(source, dest, []*resource.State{a}, "dst"))
+
+	// Moving a and c takes a's child, b, along with them.
+	assert.NoError(t, MoveResources(source, dest, []*resource.State{a, c}, "dst"))
+	assert.NoError(t, source.VerifyIntegrity())
+	assert.NoError(t, dest.VerifyIntegrity())
+	assert.Empty(t, source.Resources)
+	assert.Len(t, dest.Resources, 4)
+	for _, res := range dest.Resources {
+		assert.Equal(t, tokens.QName("dst"), res.URN.Stack())
+	}
+	assert.Equal(t, a.URN, b.Parent)
+	assert.Equal(t, []resource.URN{a.URN}, c.Dependencies)
+
+	// Moving a resource whose name is already taken in the destination fails.
+	source = deploy.NewSnapshot(deploy.Manifest{}, []*resource.State{newResource("src", "x", nil)})
+	assert.Error(t, MoveResources(source, dest, source.Resources, "dst"))
+}
+
+func newRootStack(stack tokens.QName) *resource.State {
+	return &resource.State{
+		Type:    resource.RootStackType,
+		URN:     resource.NewURN(stack, "test", "", resource.RootStackType, tokens.QName("test-"+stack)),
+		Inputs:  resource.PropertyMap{},
+		Outputs: resource.PropertyMap{},
+	}
+}
+
+func TestMoveResourcesRootStack(t *testing.T) {
+	srcRoot := newRootStack("src")
+	a := newResource("src", "a", nil)
+	a.Parent = srcRoot.URN
+	b := newResource("src", "b", a, srcRoot)
+	source := deploy.NewSnapshot(deploy.Manifest{}, []*resource.State{srcRoot, a, b})
+	dstRoot := newRootStack("dst")
+	dest := deploy.NewSnapshot(deploy.Manifest{}, []*resource.State{dstRoot})
+
+	// The root stack resource itself can't be moved.
+	assert.Error(t, MoveResources(source, dest, []*resource.State{srcRoot}, "dst"))
+
+	// Children of the source's root stack become children of the destination's.
+	assert.NoError(t, MoveResources(source, dest, []*resource.State{a}, "dst"))
+	assert.NoError(t, source.VerifyIntegrity())
+	assert.NoError(t, dest.VerifyIntegrity())
+	assert.Equal(t, []*resource.State{srcRoot}, source.Resources)
+	assert.Equal(t, []*resource.State{dstRoot, a, b}, dest.Resources)
+	assert.Equal(t, dstRoot.URN, a.Parent)
+	assert.Equal(t, []resource.URN{dstRoot.URN}, b.Dependencies)
+
+	// A destination without a root stack resource leaves them without a parent.
+	c := newResource("src", "c", nil, srcRoot)
+	c.Parent = srcRoot.URN
+	source = deploy.NewSnapshot(deploy.Manifest{}, []*resource.State{srcRoot, c})
+	dest = deploy.NewSnapshot(deploy.Manifest{}, nil)
+	assert.NoError(t, MoveResources(source, dest, []*resource.State{c}, "dst"))
+	assert.NoError(t, dest.VerifyIntegrity())
+	assert.Equal(t, resource.URN(""), c.Parent)
+	assert.Empty(t, c.Dependencies)
+}
+
+func TestMoveResourcesAcrossProjects(t *testing.T) {
+	source := deploy.NewSnapshot(deploy.Manifest{}, []*resource.State{newResource("src", "a", nil)})
+	other := &resource.State{
+		Type: "test:resource:Type",
+		URN:  resource.NewURN("dst", "other", "", "test:resource:Type", "x"),
+	}
+	dest := deploy.NewSnapshot(deploy.Manifest{}, []*resource.State{other})
+
+	assert.Error(t, MoveResources(source, dest, source.Resources, "dst"))
+	assert.Len(t, source.Resources, 1)
+	assert.Equal(t, []*resource.State{other}, dest.Resources)
+}
@@ -16,6 +16,7 @@ package plugin
 
 import (
 	"context"
+	"sync"
 
 	"github.com/opentracing/opentracing-go"
 
@@ -31,6 +32,9 @@ type Context struct {
 	Pwd  string    // the working directory to spawn all plugins in.
 
 	tracingSpan opentracing.Span // the OpenTracing span to parent requests within.
+
+	cancelLock sync.Mutex
+	canceled   chan struct{} // closed once operations using this context have been asked to cancel.
 }
 
 // NewContext allocates a new context with a given sink and host.  Note that the host is "owned" by this context from
@@ -59,6 +63,32 @@ func (ctx *Context) Request() context.Context {
 	return opentracing.ContextWithSpan(context.Background(), ctx.tracingSpan)
 }
 
+// Canceled returns a channel that is closed once the operations using this context have been asked to cancel.
+func (ctx *Context) Canceled() <-chan struct{} {
+	ctx.cancelLock.Lock()
+	defer ctx.cancelLock.Unlock()
+	if ctx.canceled == nil {
+		ctx.canceled = make(chan struct{})
+	}
+	return ctx.canceled
+}
+
+// SignalCancellation asks the operations using this context, and the plugins loaded by its host, to cancel.
+func (ctx *Context) SignalCancellation() error {
+	ctx.cancelLock.Lock()
+	if ctx.canceled == nil {
+		ctx.canceled = make(chan struct{})
+	}
+	select {
+	case <-ctx.canceled:
+	default:
+		close(ctx.canceled)
+	}
+	ctx.cancelLock.Unlock()
+
+	return ctx.Host.SignalCancellation()
+}
+
 // Close reclaims all resources associated with this context.
 func (ctx *Context) Close() error {
 	if ctx.tracingSpan != nil {
@@ -16,6 +16,7 @@ package plugin
 
 import (
 	"os"
+	"strings"
 
 	"github.com/blang/semver"
 	"github.com/hashicorp/go-multierror"
@@ -46,9 +47,10 @@ type Host interface {
 	// Provider fetches the provider for a given package, lazily allocating it if necessary.  If a provider for this
 	// package could not be found, or an error occurs while creating it, a non-nil error is returned.
 	Provider(pkg tokens.Package, version *semver.Version) (Provider, error)
-	// LanguageRuntime fetches the language runtime plugin for a given language, lazily allocating if necessary.  If
-	// an implementation of this language runtime wasn't found, on an error occurs, a non-nil error is returned.
-	LanguageRuntime(runtime string) (LanguageRuntime, error)
+	// LanguageRuntime fetches the language runtime plugin for a given language, lazily allocating if necessary.  Any
+	// options are passed to the plugin as command line flags.  If an implementation of this language runtime wasn't
+	// found, on an error occurs, a non-nil error is returned.
+	LanguageRuntime(runtime string, options map[string]interface{}) (LanguageRuntime, error)
 
 	// ListPlugins lists all plugins that have been loaded, with version information.
 	ListPlugins() []workspace.PluginInfo
@@ -275,16 +277,23 @@ func (host *defaultHost) Provider(pkg tokens.Package, version *semver.Version) (
 	return plugin.(Provider), nil
 }
 
-func (host *defaultHost) LanguageRuntime(runtime string) (LanguageRuntime, error) {
+func (host *defaultHost) LanguageRuntime(runtime string, options map[string]interface{}) (LanguageRuntime, error) {
+	// Plugins launched with different options are distinct, so they are memoized separately.
+	args, err := languageRuntimeArgs(options)
+	if err != nil {
+		return nil, errors.Wrapf(err, "invalid options for the %s runtime", runtime)
+	}
+	key := strings.Join(append([]string{runtime}, args...), " ")
+
 	plugin, err := host.loadPlugin(func() (interface{}, error) {
 		// First see if we already loaded this plugin.
-		if plug, has := host.languagePlugins[runtime]; has {
+		if plug, has := host.languagePlugins[key]; has {
 			contract.Assert(plug != nil)
 			return plug.Plugin, nil
 		}
 
 		// If not, allocate a new one.
-		plug, err := NewLanguageRuntime(host, host.ctx, runtime)
+		plug, err := NewLanguageRuntime(host, host.ctx, runtime, options)
 		if err == nil && plug != nil {
 			info, infoerr := plug.GetPluginInfo()
 			if infoerr != nil {
@@ -293,7 +302,7 @@ func (host *defaultHost) LanguageRuntime(runtime string) (LanguageRuntime, error
 
 			// Memoize the result.
 			host.plugins = append(host.plugins, info)
-			host.languagePlugins[runtime] = &languagePlugin{Plugin: plug, Info: info}
+			host.languagePlugins[key] = &languagePlugin{Plugin: plug, Info: info}
 			if host.events != nil {
 				if eventerr := host.events.OnPluginLoad(info); eventerr != nil {
 					return nil, errors.Wrapf(eventerr, "failed to perform plugin load callback")
@@ -329,7 +338,7 @@ func (host *defaultHost) EnsurePlugins(plugins []workspace.PluginInfo, kinds Fla
 			}
 		case workspace.LanguagePlugin:
 			if kinds&LanguagePlugins != 0 {
-				if _, err := host.LanguageRuntime(plugin.Name); err != nil {
+				if _, err := host.LanguageRuntime(plugin.Name, nil); err != nil {
 					result = multierror.Append(result,
 						errors.Wrapf(err, "failed to load language plugin %s", plugin.Name))
 				}
@@ -356,12 +365,12 @@ func (host *defaultHost) GetRequiredPlugins(info ProgInfo, kinds Flags) ([]works
 	if kinds&LanguagePlugins != 0 {
 		// First make sure the language plugin is present.  We need this to load the required resource plugins.
 		// TODO: we need to think about how best to version this.  For now, it always picks the latest.
-		lang, err := host.LanguageRuntime(info.Proj.Runtime)
+		lang, err := host.LanguageRuntime(info.Proj.Runtime.Name, info.Proj.Runtime.Options)
 		if err != nil {
-			return nil, errors.Wrapf(err, "failed to load language plugin %s", info.Proj.Runtime)
+			return nil, errors.Wrapf(err, "failed to load language plugin %s", info.Proj.Runtime.Name)
 		}
 		plugins = append(plugins, workspace.PluginInfo{
-			Name: info.Proj.Runtime,
+			Name: info.Proj.Runtime.Name,
 			Kind: workspace.LanguagePlugin,
 		})
 
@@ -15,6 +15,9 @@
 package plugin
 
 import (
+	"fmt"
+	"sort"
+	"strconv"
 	"strings"
 
 	"github.com/blang/semver"
@@ -38,9 +41,12 @@ type langhost struct {
 	client  pulumirpc.LanguageRuntimeClient
 }
 
-// NewLanguageRuntime binds to a language's runtime plugin and then creates a gRPC connection to it.  If the
-// plugin could not be found, or an error occurs while creating the child process, an error is returned.
-func NewLanguageRuntime(host Host, ctx *Context, runtime string) (LanguageRuntime, error) {
+// NewLanguageRuntime binds to a language's runtime plugin and then creates a gRPC connection to it.  Any options are
+// passed to the plugin as command line flags, so they must have scalar values and be flags that the plugin accepts;
+// today, only the Go language host accepts any.  If the plugin could not be found, or an error occurs while creating
+// the child process, an error is returned.
+func NewLanguageRuntime(host Host, ctx *Context, runtime string,
+	options map[string]interface{}) (LanguageRuntime, error) {
 	// Load the plugin's path by using the standard workspace logic.
 	_, path, err := workspace.GetPluginPath(
 		workspace.LanguagePlugin, strings.Replace(runtime, tokens.QNameDelimiter, "_", -1), nil)
@@ -53,7 +59,11 @@ func NewLanguageRuntime(host Host, ctx *Context, runtime string) (LanguageRuntim
 		})
 	}
 
-	plug, err := newPlugin(ctx, path, runtime, []string{host.ServerAddr()})
+	args, err := languageRuntimeArgs(options)
+	if err != nil {
+		return nil, errors.Wrapf(err, "invalid options for the %s runtime", runtime)
+	}
+	plug, err := newPlugin(ctx, path, runtime, append(args, host.ServerAddr()))
 	if err != nil {
 		return nil, err
 	}
@@ -67,6 +77,30 @@ func NewLanguageRuntime(host Host, ctx *Context, runtime string) (LanguageRuntim
 	}, nil
 }
 
+// languageRuntimeArgs turns a language runtime's options into command line flags, sorted by name so that they are
+// deterministic.  Only strings, numbers, and booleans can be passed as flags.
+func languageRuntimeArgs(options map[string]interface{}) ([]string, error) {
+	var args []string
+	for k, v := range options {
+		var value string
+		switch v := v.(type) {
+		case string:
+			value = v
+		case bool:
+			value = strconv.FormatBool(v)
+		case int:
+			value = strconv.Itoa(v)
+		case float64:
+			value = strconv.FormatFloat(v, 'f', -1, 64)
+		default:
+			return nil, errors.Errorf("option '%s' must be a string, number, or boolean", k)
+		}
+		args = append(args, fmt.Sprintf("-%s=%s", k, value))
+	}
+	sort.Strings(args)
+	return args, nil
+}
+
 func (h *langhost) Runtime() string { return h.runtime }
 
 // GetRequiredPlugins computes the complete set of anticipated plugins required by a program.
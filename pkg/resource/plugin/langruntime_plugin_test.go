@@ -0,0 +1,42 @@
+// Copyright 2016-2018, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package plugin
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLanguageRuntimeArgs(t *testing.T) {
+	args, err := languageRuntimeArgs(nil)
+	assert.NoError(t, err)
+	assert.Empty(t, args)
+
+	args, err = languageRuntimeArgs(map[string]interface{}{
+		"binary":  "bin/app",
+		"verbose": true,
+		"jobs":    4,
+		"ratio":   1000000.5,
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"-binary=bin/app", "-jobs=4", "-ratio=1000000.5", "-verbose=true"}, args)
+
+	// Nested values can't be passed as flags.
+	_, err = languageRuntimeArgs(map[string]interface{}{"nested": map[string]interface{}{"a": "b"}})
+	assert.Error(t, err)
+	_, err = languageRuntimeArgs(map[string]interface{}{"list": []interface{}{"a"}})
+	assert.Error(t, err)
+}
@@ -273,7 +273,7 @@ func (p *provider) Create(urn resource.URN, props resource.PropertyMap) (resourc
 		resourceStatus, id, liveObject, resourceError = parseError(err)
 		logging.V(7).Infof("%s failed: %v", label, resourceError)
 
-		if resourceStatus == resource.StatusUnknown {
+		if resourceStatus != resource.StatusPartialFailure {
 			return "", nil, resourceStatus, resourceError
 		}
 		// Else it's a `StatusPartialFailure`.
@@ -392,7 +392,7 @@ func (p *provider) Update(urn resource.URN, id resource.ID,
 		resourceStatus, _, liveObject, resourceError = parseError(err)
 		logging.V(7).Infof("%s failed: %v", label, resourceError)
 
-		if resourceStatus == resource.StatusUnknown {
+		if resourceStatus != resource.StatusPartialFailure {
 			return nil, resourceStatus, resourceError
 		}
 		// Else it's a `StatusPartialFailure`.
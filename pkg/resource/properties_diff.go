@@ -82,38 +82,27 @@ type ValueDiff struct {
 	Object *ObjectDiff   // the object's detailed diffs (only for objects).
 }
 
-// ArrayDiff holds the results of diffing two arrays of property values.
+// ArrayDiff holds the results of diffing two arrays of property values.  The arrays are aligned so that elements that
+// are inserted, removed or moved do not cause the elements around them to be reported as changed.
 type ArrayDiff struct {
-	Adds    map[int]PropertyValue // elements added in the new.
-	Deletes map[int]PropertyValue // elements deleted in the new.
-	Sames   map[int]PropertyValue // elements the same in both.
-	Updates map[int]ValueDiff     // elements that have changed in the new.
+	Adds    map[int]PropertyValue // elements added in the new, by their index in the new array.
+	Deletes map[int]PropertyValue // elements deleted in the new, by their index in the old array.
+	Sames   map[int]PropertyValue // elements the same in both, by their index in the new array.
+	Updates map[int]ValueDiff     // elements that have changed in the new, by their index in the new array.
+	Moves   map[int]int           // elements moved to a new position, from their new index to their old index.
+	Edits   []ArrayEdit           // the elements of both arrays, aligned, in order.
 }
 
-// Len computes the length of this array, taking into account adds, deletes, sames, and updates.
+// ArrayEdit aligns an element of the old array with an element of the new array.
+type ArrayEdit struct {
+	Old int // the element's index in the old array, or -1 if it was added.
+	New int // the element's index in the new array, or -1 if it was deleted.
+}
+
+// Len computes the length of this array, taking into account adds, deletes, sames, and updates; that is, the number of
+// aligned elements.
 func (diff *ArrayDiff) Len() int {
-	len := 0
-	for i := range diff.Adds {
-		if i+1 > len {
-			len = i + 1
-		}
-	}
-	for i := range diff.Deletes {
-		if i+1 > len {
-			len = i + 1
-		}
-	}
-	for i := range diff.Sames {
-		if i+1 > len {
-			len = i + 1
-		}
-	}
-	for i := range diff.Updates {
-		if i+1 > len {
-			len = i + 1
-		}
-	}
-	return len
+	return len(diff.Edits)
 }
 
 // Diff returns a diffset by comparing the property map to another; it returns nil if there are no diffs.
@@ -168,42 +157,14 @@ func (props PropertyMap) Diff(other PropertyMap) *ObjectDiff {
 // Diff returns a diff by comparing a single property value to another; it returns nil if there are no diffs.
 func (v PropertyValue) Diff(other PropertyValue) *ValueDiff {
 	if v.IsArray() && other.IsArray() {
-		old := v.ArrayValue()
-		new := other.ArrayValue()
-		// If any elements exist in the new array but not the old, track them as adds.
-		adds := make(map[int]PropertyValue)
-		for i := len(old); i < len(new); i++ {
-			adds[i] = new[i]
-		}
-		// If any elements exist in the old array but not the new, track them as adds.
-		deletes := make(map[int]PropertyValue)
-		for i := len(new); i < len(old); i++ {
-			deletes[i] = old[i]
-		}
-		// Now if elements exist in both, track them as sames or updates.
-		sames := make(map[int]PropertyValue)
-		updates := make(map[int]ValueDiff)
-		for i := 0; i < len(old) && i < len(new); i++ {
-			if diff := old[i].Diff(new[i]); diff != nil {
-				updates[i] = *diff
-			} else {
-				sames[i] = old[i]
+		if diff := diffArrays(v.ArrayValue(), other.ArrayValue()); diff != nil {
+			return &ValueDiff{
+				Old:   v,
+				New:   other,
+				Array: diff,
 			}
 		}
-
-		if len(adds) == 0 && len(deletes) == 0 && len(updates) == 0 {
-			return nil
-		}
-		return &ValueDiff{
-			Old: v,
-			New: other,
-			Array: &ArrayDiff{
-				Adds:    adds,
-				Deletes: deletes,
-				Sames:   sames,
-				Updates: updates,
-			},
-		}
+		return nil
 	}
 	if v.IsObject() && other.IsObject() {
 		old := v.ObjectValue()
@@ -295,3 +256,137 @@ func (v PropertyValue) DeepEquals(other PropertyValue) bool {
 	// For all other cases, primitives are equal if their values are equal.
 	return v.V == other.V
 }
+
+// maxArrayDiffCells is the largest number of element comparisons diffArrays will make to align two arrays or to find
+// the elements that moved between them; larger arrays are compared element by element, since the cost of both grows
+// with the product of their lengths.
+const maxArrayDiffCells = 1 << 20
+
+// diffArrays diffs two arrays, returning nil if they are the same.  The longest common subsequence of the arrays is
+// kept in place; elements outside of it that appear in both arrays are reported as moves, and the remaining elements
+// between two elements of the subsequence are paired up as updates, with any left over reported as adds or deletes.
+func diffArrays(old []PropertyValue, new []PropertyValue) *ArrayDiff {
+	same := func(i, j int) bool { return old[i].DeepEquals(new[j]) }
+
+	// Elements common to the start and end of both arrays don't need to be aligned.
+	prefix := 0
+	for prefix < len(old) && prefix < len(new) && same(prefix, prefix) {
+		prefix++
+	}
+	suffix := 0
+	for suffix < len(old)-prefix && suffix < len(new)-prefix && same(len(old)-1-suffix, len(new)-1-suffix) {
+		suffix++
+	}
+
+	// Find the longest common subsequence of what remains, as a list of aligned elements.
+	var anchors []ArrayEdit
+	for i := 0; i < prefix; i++ {
+		anchors = append(anchors, ArrayEdit{Old: i, New: i})
+	}
+	oldEnd, newEnd := len(old)-suffix, len(new)-suffix
+	if n, m := oldEnd-prefix, newEnd-prefix; n > 0 && m > 0 && n*m <= maxArrayDiffCells {
+		// lengths[i][j] is the length of the common subsequence of old[prefix+i:oldEnd] and new[prefix+j:newEnd].
+		lengths := make([][]int, n+1)
+		for i := range lengths {
+			lengths[i] = make([]int, m+1)
+		}
+		for i := n - 1; i >= 0; i-- {
+			for j := m - 1; j >= 0; j-- {
+				if same(prefix+i, prefix+j) {
+					lengths[i][j] = lengths[i+1][j+1] + 1
+				} else if lengths[i+1][j] >= lengths[i][j+1] {
+					lengths[i][j] = lengths[i+1][j]
+				} else {
+					lengths[i][j] = lengths[i][j+1]
+				}
+			}
+		}
+		for i, j := 0, 0; i < n && j < m; {
+			switch {
+			case same(prefix+i, prefix+j):
+				anchors = append(anchors, ArrayEdit{Old: prefix + i, New: prefix + j})
+				i, j = i+1, j+1
+			case lengths[i+1][j] >= lengths[i][j+1]:
+				i++
+			default:
+				j++
+			}
+		}
+	}
+	for i := 0; i < suffix; i++ {
+		anchors = append(anchors, ArrayEdit{Old: oldEnd + i, New: newEnd + i})
+	}
+
+	// Elements outside of the subsequence that appear in both arrays have moved.
+	matchedOld := make(map[int]bool)
+	matchedNew := make(map[int]bool)
+	for _, anchor := range anchors {
+		matchedOld[anchor.Old], matchedNew[anchor.New] = true, true
+	}
+	moves := make(map[int]int)
+	if n, m := len(old)-len(anchors), len(new)-len(anchors); n*m <= maxArrayDiffCells {
+		for j := range new {
+			if matchedNew[j] {
+				continue
+			}
+			for i := range old {
+				if !matchedOld[i] && same(i, j) {
+					moves[j] = i
+					matchedOld[i], matchedNew[j] = true, true
+					break
+				}
+			}
+		}
+	}
+
+	diff := &ArrayDiff{
+		Adds:    make(map[int]PropertyValue),
+		Deletes: make(map[int]PropertyValue),
+		Sames:   make(map[int]PropertyValue),
+		Updates: make(map[int]ValueDiff),
+		Moves:   moves,
+	}
+
+	// Walk the gaps between the elements of the subsequence, pairing up the elements in each.
+	oldStart, newStart := 0, 0
+	for _, anchor := range append(anchors, ArrayEdit{Old: len(old), New: len(new)}) {
+		var olds []int
+		for i := oldStart; i < anchor.Old; i++ {
+			if !matchedOld[i] {
+				olds = append(olds, i)
+			}
+		}
+		for j := newStart; j < anchor.New; j++ {
+			if i, moved := moves[j]; moved {
+				diff.Edits = append(diff.Edits, ArrayEdit{Old: i, New: j})
+			} else if len(olds) > 0 {
+				i := olds[0]
+				olds = olds[1:]
+				if d := old[i].Diff(new[j]); d != nil {
+					diff.Updates[j] = *d
+				} else {
+					diff.Sames[j] = new[j]
+				}
+				diff.Edits = append(diff.Edits, ArrayEdit{Old: i, New: j})
+			} else {
+				diff.Adds[j] = new[j]
+				diff.Edits = append(diff.Edits, ArrayEdit{Old: -1, New: j})
+			}
+		}
+		for _, i := range olds {
+			diff.Deletes[i] = old[i]
+			diff.Edits = append(diff.Edits, ArrayEdit{Old: i, New: -1})
+		}
+
+		if anchor.New < len(new) {
+			diff.Sames[anchor.New] = new[anchor.New]
+			diff.Edits = append(diff.Edits, anchor)
+		}
+		oldStart, newStart = anchor.Old+1, anchor.New+1
+	}
+
+	if len(diff.Adds) == 0 && len(diff.Deletes) == 0 && len(diff.Updates) == 0 && len(diff.Moves) == 0 {
+		return nil
+	}
+	return diff
+}
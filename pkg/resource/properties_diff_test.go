@@ -112,7 +112,7 @@ func TestArrayPropertyValueDiffs(t *testing.T) {
 	assert.Nil(t, d2)
 	// all updates:
 	d3a1 := NewArrayProperty([]PropertyValue{
-		NewStringProperty("element one"), NewNumberProperty(2), NewNullProperty()})
+		NewStringProperty("element one"), NewNumberProperty(2), NewBoolProperty(true)})
 	d3a2 := NewArrayProperty([]PropertyValue{
 		NewNumberProperty(1), NewNullProperty(), NewStringProperty("element three")})
 	d3 := d3a1.Diff(d3a2)
@@ -127,6 +127,18 @@ func TestArrayPropertyValueDiffs(t *testing.T) {
 		assert.Equal(t, d3a1.ArrayValue()[i], update.Old)
 		assert.Equal(t, d3a2.ArrayValue()[i], update.New)
 	}
+	// keep the common elements in place, even when they move to different indices:
+	d3b := d3a1.Diff(NewArrayProperty([]PropertyValue{
+		NewNumberProperty(1), NewNullProperty(), NewStringProperty("element one"), NewBoolProperty(true)}))
+	assert.NotNil(t, d3b)
+	assert.NotNil(t, d3b.Array)
+	assert.Equal(t, map[int]PropertyValue{0: NewNumberProperty(1), 1: NewNullProperty()}, d3b.Array.Adds)
+	assert.Equal(t, map[int]PropertyValue{1: NewNumberProperty(2)}, d3b.Array.Deletes)
+	assert.Equal(t, map[int]PropertyValue{2: NewStringProperty("element one"), 3: NewBoolProperty(true)},
+		d3b.Array.Sames)
+	assert.Equal(t, 0, len(d3b.Array.Updates))
+	assert.Equal(t, []ArrayEdit{{Old: -1, New: 0}, {Old: -1, New: 1}, {Old: 0, New: 2}, {Old: 1, New: -1},
+		{Old: 2, New: 3}}, d3b.Array.Edits)
 	// update one, keep one, delete one:
 	d4a1 := NewArrayProperty([]PropertyValue{
 		NewStringProperty("element one"), NewNumberProperty(2), NewBoolProperty(true)})
@@ -186,6 +198,65 @@ func TestArrayPropertyValueDiffs(t *testing.T) {
 	assert.NotNil(t, d6)
 }
 
+func TestArrayPropertyValueDiffAlignment(t *testing.T) {
+	t.Parallel()
+	var olds []PropertyValue
+	for i := 0; i < 50; i++ {
+		olds = append(olds, NewNumberProperty(float64(i)))
+	}
+
+	// inserting an element at the front is a single add:
+	news := append([]PropertyValue{NewStringProperty("first")}, olds...)
+	d1 := NewArrayProperty(olds).Diff(NewArrayProperty(news))
+	assert.NotNil(t, d1)
+	assert.NotNil(t, d1.Array)
+	assert.Equal(t, map[int]PropertyValue{0: NewStringProperty("first")}, d1.Array.Adds)
+	assert.Equal(t, 0, len(d1.Array.Deletes))
+	assert.Equal(t, 0, len(d1.Array.Updates))
+	assert.Equal(t, 0, len(d1.Array.Moves))
+	assert.Equal(t, 50, len(d1.Array.Sames))
+	assert.Equal(t, 51, d1.Array.Len())
+
+	// removing it again is a single delete:
+	d2 := NewArrayProperty(news).Diff(NewArrayProperty(olds))
+	assert.NotNil(t, d2)
+	assert.Equal(t, map[int]PropertyValue{0: NewStringProperty("first")}, d2.Array.Deletes)
+	assert.Equal(t, 0, len(d2.Array.Adds))
+	assert.Equal(t, 0, len(d2.Array.Updates))
+	assert.Equal(t, 50, len(d2.Array.Sames))
+
+	// moving the last element to the front is a single move:
+	moved := append([]PropertyValue{olds[49]}, olds[:49]...)
+	d3 := NewArrayProperty(olds).Diff(NewArrayProperty(moved))
+	assert.NotNil(t, d3)
+	assert.Equal(t, map[int]int{0: 49}, d3.Array.Moves)
+	assert.Equal(t, 0, len(d3.Array.Adds))
+	assert.Equal(t, 0, len(d3.Array.Deletes))
+	assert.Equal(t, 0, len(d3.Array.Updates))
+	assert.Equal(t, 49, len(d3.Array.Sames))
+	assert.Equal(t, ArrayEdit{Old: 49, New: 0}, d3.Array.Edits[0])
+}
+
+func TestArrayPropertyValueDiffBudget(t *testing.T) {
+	t.Parallel()
+
+	// Reversing an array too large to align within the budget neither aligns nor searches for moved elements; the
+	// elements are simply compared pairwise.
+	n := 1100
+	contract.Assert(n*n > maxArrayDiffCells)
+	var olds, news []PropertyValue
+	for i := 0; i < n; i++ {
+		olds = append(olds, NewNumberProperty(float64(i)))
+		news = append(news, NewNumberProperty(float64(n-1-i)))
+	}
+	d := NewArrayProperty(olds).Diff(NewArrayProperty(news))
+	if assert.NotNil(t, d) && assert.NotNil(t, d.Array) {
+		assert.Equal(t, 0, len(d.Array.Moves))
+		assert.Equal(t, n, len(d.Array.Updates))
+		assert.Equal(t, n, d.Array.Len())
+	}
+}
+
 func TestObjectPropertyValueDiffs(t *testing.T) {
 	t.Parallel()
 	// no diffs:
@@ -71,8 +71,10 @@ func UnmarshalVersionedCheckpointToLatestCheckpoint(bytes []byte) (*apitype.Chec
 	}
 }
 
-// SerializeCheckpoint turns a snapshot into a data structure suitable for serialization.
-func SerializeCheckpoint(stack tokens.QName, config config.Map, snap *deploy.Snapshot) *apitype.VersionedCheckpoint {
+// SerializeCheckpoint turns a snapshot, plus the stack's config and tags, into a data structure suitable for
+// serialization.
+func SerializeCheckpoint(stack tokens.QName, config config.Map, tags map[apitype.StackTagName]string,
+	snap *deploy.Snapshot) *apitype.VersionedCheckpoint {
 	// If snap is nil, that's okay, we will just create an empty deployment; otherwise, serialize the whole snapshot.
 	var latest *apitype.DeploymentV2
 	if snap != nil {
@@ -83,6 +85,7 @@ func SerializeCheckpoint(stack tokens.QName, config config.Map, snap *deploy.Sna
 		Stack:  stack,
 		Config: config,
 		Latest: latest,
+		Tags:   tags,
 	})
 	contract.AssertNoError(err)
 
@@ -130,17 +130,19 @@ func SerializeResource(res *resource.State) apitype.ResourceV2 {
 	}
 
 	return apitype.ResourceV2{
-		URN:          res.URN,
-		Custom:       res.Custom,
-		Delete:       res.Delete,
-		ID:           res.ID,
-		Type:         res.Type,
-		Parent:       res.Parent,
-		Inputs:       inputs,
-		Outputs:      outputs,
-		Protect:      res.Protect,
-		Dependencies: res.Dependencies,
-		InitErrors:   res.InitErrors,
+		URN:            res.URN,
+		Custom:         res.Custom,
+		Delete:         res.Delete,
+		ID:             res.ID,
+		Type:           res.Type,
+		Parent:         res.Parent,
+		Inputs:         inputs,
+		Outputs:        outputs,
+		Protect:        res.Protect,
+		RetainOnDelete: res.RetainOnDelete,
+		Retries:        res.Retries,
+		Dependencies:   res.Dependencies,
+		InitErrors:     res.InitErrors,
 	}
 }
 
@@ -203,7 +205,7 @@ func DeserializeResource(res apitype.ResourceV2) (*resource.State, error) {
 
 	return resource.NewState(
 		res.Type, res.URN, res.Custom, res.Delete, res.ID, inputs, outputs, res.Parent, res.Protect,
-		res.Dependencies, res.InitErrors), nil
+		res.RetainOnDelete, res.Retries, res.Dependencies, res.InitErrors), nil
 }
 
 // DeserializeProperties deserializes an entire map of deploy properties into a resource property map.
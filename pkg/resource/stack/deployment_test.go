@@ -21,6 +21,7 @@ import (
 
 	"github.com/pulumi/pulumi/pkg/apitype"
 	"github.com/pulumi/pulumi/pkg/resource"
+	"github.com/pulumi/pulumi/pkg/resource/deploy"
 	"github.com/pulumi/pulumi/pkg/tokens"
 )
 
@@ -69,6 +70,8 @@ func TestDeploymentSerialization(t *testing.T) {
 		}),
 		"",
 		false,
+		true,
+		5,
 		[]resource.URN{
 			resource.URN("foo:bar:baz"),
 			resource.URN("foo:bar:boo"),
@@ -83,6 +86,8 @@ func TestDeploymentSerialization(t *testing.T) {
 	assert.NotNil(t, dep.ID)
 	assert.Equal(t, resource.ID("test-resource-x"), dep.ID)
 	assert.Equal(t, tokens.Type("Test"), dep.Type)
+	assert.True(t, dep.RetainOnDelete)
+	assert.Equal(t, 5, dep.Retries)
 	assert.Equal(t, 2, len(dep.Dependencies))
 	assert.Equal(t, resource.URN("foo:bar:baz"), dep.Dependencies[0])
 	assert.Equal(t, resource.URN("foo:bar:boo"), dep.Dependencies[1])
@@ -166,3 +171,31 @@ func TestLoadTooOldDeployment(t *testing.T) {
 	assert.Error(t, err)
 	assert.Equal(t, ErrDeploymentSchemaVersionTooOld, err)
 }
+
+// TestPlanSerialization ensures that a saved plan, including its unknown values, survives a round trip.
+func TestPlanSerialization(t *testing.T) {
+	urn := resource.NewURN("test", "resource/test", "", "Test", "resource-x")
+	plan := deploy.NewSavedPlan()
+	plan.Resources[urn] = &deploy.ResourcePlan{
+		Ops:       []deploy.StepOp{deploy.OpCreateReplacement, deploy.OpReplace},
+		OldInputs: resource.PropertyMap{"a": resource.NewStringProperty("old")},
+		NewInputs: resource.PropertyMap{
+			"a": resource.NewStringProperty("new"),
+			"b": resource.NewArrayProperty([]resource.PropertyValue{
+				resource.MakeComputed(resource.NewStringProperty("")),
+			}),
+		},
+		ReplaceKeys: []resource.PropertyKey{"a"},
+	}
+
+	ser := SerializePlan(plan)
+	assert.Equal(t, apitype.PlanSchemaVersionCurrent, ser.Version)
+	assert.Equal(t, []apitype.OpType{apitype.OpCreateReplacement, apitype.OpReplace}, ser.Resources[urn].Ops)
+
+	des, err := DeserializePlan(ser)
+	assert.NoError(t, err)
+	assert.Equal(t, plan, des)
+
+	_, err = DeserializePlan(apitype.PlanV1{Version: apitype.PlanSchemaVersionCurrent + 1})
+	assert.Error(t, err)
+}
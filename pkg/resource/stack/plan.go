@@ -0,0 +1,156 @@
+// Copyright 2016-2018, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package stack
+
+import (
+	"github.com/pkg/errors"
+
+	"github.com/pulumi/pulumi/pkg/apitype"
+	"github.com/pulumi/pulumi/pkg/resource"
+	"github.com/pulumi/pulumi/pkg/resource/deploy"
+	"github.com/pulumi/pulumi/pkg/resource/plugin"
+	"github.com/pulumi/pulumi/pkg/util/contract"
+)
+
+// SerializePlan serializes a saved plan so that it can be written to a plan file.
+func SerializePlan(plan *deploy.SavedPlan) apitype.PlanV1 {
+	contract.Require(plan != nil, "plan")
+
+	resources := make(map[resource.URN]apitype.ResourcePlanV1)
+	for urn, rp := range plan.Resources {
+		ops := make([]apitype.OpType, len(rp.Ops))
+		for i, op := range rp.Ops {
+			ops[i] = apitype.OpType(op)
+		}
+		var replaceKeys []string
+		for _, k := range rp.ReplaceKeys {
+			replaceKeys = append(replaceKeys, string(k))
+		}
+		resources[urn] = apitype.ResourcePlanV1{
+			Ops:         ops,
+			OldInputs:   serializePlanProperties(rp.OldInputs),
+			NewInputs:   serializePlanProperties(rp.NewInputs),
+			ReplaceKeys: replaceKeys,
+		}
+	}
+
+	return apitype.PlanV1{
+		Version:   apitype.PlanSchemaVersionCurrent,
+		Resources: resources,
+	}
+}
+
+// DeserializePlan turns a serialized plan back into its usual form.
+func DeserializePlan(plan apitype.PlanV1) (*deploy.SavedPlan, error) {
+	if plan.Version > apitype.PlanSchemaVersionCurrent {
+		return nil, errors.Errorf("this plan's version (%d) is too new", plan.Version)
+	}
+
+	result := deploy.NewSavedPlan()
+	for urn, rp := range plan.Resources {
+		ops := make([]deploy.StepOp, len(rp.Ops))
+		for i, op := range rp.Ops {
+			ops[i] = deploy.StepOp(op)
+		}
+		var replaceKeys []resource.PropertyKey
+		for _, k := range rp.ReplaceKeys {
+			replaceKeys = append(replaceKeys, resource.PropertyKey(k))
+		}
+		oldInputs, err := deserializePlanProperties(rp.OldInputs)
+		if err != nil {
+			return nil, err
+		}
+		newInputs, err := deserializePlanProperties(rp.NewInputs)
+		if err != nil {
+			return nil, err
+		}
+		result.Resources[urn] = &deploy.ResourcePlan{
+			Ops:         ops,
+			OldInputs:   oldInputs,
+			NewInputs:   newInputs,
+			ReplaceKeys: replaceKeys,
+		}
+	}
+	return result, nil
+}
+
+// serializePlanProperties serializes a property map for a plan.  Unlike SerializeProperties, unknown values are
+// recorded as the unknown string sentinel, so that the plan can tell them apart from missing values.
+func serializePlanProperties(props resource.PropertyMap) map[string]interface{} {
+	if props == nil {
+		return nil
+	}
+	return SerializeProperties(markUnknowns(props))
+}
+
+func markUnknowns(props resource.PropertyMap) resource.PropertyMap {
+	result := make(resource.PropertyMap)
+	for k, v := range props {
+		result[k] = markUnknownValue(v)
+	}
+	return result
+}
+
+func markUnknownValue(v resource.PropertyValue) resource.PropertyValue {
+	switch {
+	case v.IsComputed() || v.IsOutput():
+		return resource.NewStringProperty(plugin.UnknownStringValue)
+	case v.IsArray():
+		arr := make([]resource.PropertyValue, len(v.ArrayValue()))
+		for i, elem := range v.ArrayValue() {
+			arr[i] = markUnknownValue(elem)
+		}
+		return resource.NewArrayProperty(arr)
+	case v.IsObject():
+		return resource.NewObjectProperty(markUnknowns(v.ObjectValue()))
+	default:
+		return v
+	}
+}
+
+// deserializePlanProperties deserializes a property map from a plan, restoring any unknown values.
+func deserializePlanProperties(props map[string]interface{}) (resource.PropertyMap, error) {
+	if props == nil {
+		return nil, nil
+	}
+	result, err := DeserializeProperties(props)
+	if err != nil {
+		return nil, err
+	}
+	return restoreUnknowns(result), nil
+}
+
+func restoreUnknowns(props resource.PropertyMap) resource.PropertyMap {
+	for k, v := range props {
+		props[k] = restoreUnknownValue(v)
+	}
+	return props
+}
+
+func restoreUnknownValue(v resource.PropertyValue) resource.PropertyValue {
+	switch {
+	case v.IsString() && v.StringValue() == plugin.UnknownStringValue:
+		return resource.MakeComputed(resource.NewStringProperty(""))
+	case v.IsArray():
+		for i, elem := range v.ArrayValue() {
+			v.ArrayValue()[i] = restoreUnknownValue(elem)
+		}
+		return v
+	case v.IsObject():
+		return resource.NewObjectProperty(restoreUnknowns(v.ObjectValue()))
+	default:
+		return v
+	}
+}
@@ -16,6 +16,8 @@ package cancel
 
 import (
 	"context"
+	"sync"
+	"time"
 
 	"github.com/pulumi/pulumi/pkg/util/contract"
 )
@@ -23,8 +25,12 @@ import (
 // Context provides the ability to observe cancellation and termination requests from a Source. A termination request
 // automatically triggers a corresponding cancellation request. This can be used to implement cancellation with two
 // priority levels.
+//
+// A cancellation may also be graceful, in which case operations that are already in flight are given some time to
+// complete before they are interrupted.  A non-graceful cancellation interrupts them immediately.
 type Context struct {
 	terminate context.Context
+	interrupt context.Context
 	cancel    context.Context
 }
 
@@ -35,7 +41,12 @@ type Source struct {
 	context *Context
 
 	terminate context.CancelFunc
+	interrupt context.CancelFunc
 	cancel    context.CancelFunc
+
+	lock   sync.Mutex
+	grace  *time.Timer // the timer that interrupts a graceful cancellation, if any.
+	closed bool
 }
 
 // NewContext creates a new cancellation context and source parented to the given context. The returned cancellation
@@ -43,19 +54,22 @@ type Source struct {
 func NewContext(ctx context.Context) (*Context, *Source) {
 	contract.Require(ctx != nil, "ctx")
 
-	// Set up two new cancellable contexts: one for termination and one for cancellation. The cancellation context is a
-	// child context of the termination context and will therefore be automatically cancelled when termination is
-	// requested. Both are children of the supplied context--cancelling the supplied context will cause termination.
+	// Set up three new cancellable contexts: one for termination, one for interruption, and one for cancellation. Each
+	// is a child context of the one before it and will therefore be automatically cancelled when its parent is. All
+	// are children of the supplied context--cancelling the supplied context will cause termination.
 	terminationContext, terminate := context.WithCancel(ctx)
-	cancellationContext, cancel := context.WithCancel(terminationContext)
+	interruptionContext, interrupt := context.WithCancel(terminationContext)
+	cancellationContext, cancel := context.WithCancel(interruptionContext)
 
 	c := &Context{
 		terminate: terminationContext,
+		interrupt: interruptionContext,
 		cancel:    cancellationContext,
 	}
 	s := &Source{
 		context:   c,
 		terminate: terminate,
+		interrupt: interrupt,
 		cancel:    cancel,
 	}
 	return c, s
@@ -71,6 +85,17 @@ func (c *Context) CancelErr() error {
 	return c.cancel.Err()
 }
 
+// Interrupted returns a channel that will be closed when operations that are in flight should be interrupted: right
+// away for a non-graceful cancellation, once the grace period has elapsed for a graceful one, or upon termination.
+func (c *Context) Interrupted() <-chan struct{} {
+	return c.interrupt.Done()
+}
+
+// InterruptErr returns a non-nil error iff operations that are in flight should be interrupted.
+func (c *Context) InterruptErr() error {
+	return c.interrupt.Err()
+}
+
 // Terminated returns a channel that will be closed when the context is terminated.
 func (c *Context) Terminated() <-chan struct{} {
 	return c.terminate.Done()
@@ -86,12 +111,35 @@ func (s *Source) Context() *Context {
 	return s.context
 }
 
-// Cancel cancels this source's context.
+// Cancel cancels this source's context, interrupting any operations that are in flight.
 func (s *Source) Cancel() {
+	s.interrupt()
+}
+
+// CancelGracefully cancels this source's context, but gives any operations that are in flight the given amount of time
+// to complete before they are interrupted.  A timeout of zero waits for them indefinitely.
+func (s *Source) CancelGracefully(timeout time.Duration) {
 	s.cancel()
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	if timeout > 0 && s.grace == nil && !s.closed {
+		s.grace = time.AfterFunc(timeout, s.interrupt)
+	}
 }
 
 // Terminate terminates this source's context (which also cancels this context).
 func (s *Source) Terminate() {
 	s.terminate()
 }
+
+// Close releases the source once the operations it governs have finished, stopping the grace period of a graceful
+// cancellation so that it doesn't interrupt anything afterwards.  The context itself is left as it is.
+func (s *Source) Close() {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	if s.grace != nil {
+		s.grace.Stop()
+	}
+	s.closed = true
+}
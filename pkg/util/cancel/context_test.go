@@ -0,0 +1,80 @@
+// Copyright 2016-2018, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cancel
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCancel(t *testing.T) {
+	c, s := NewContext(context.Background())
+	assert.NoError(t, c.CancelErr())
+	assert.NoError(t, c.InterruptErr())
+
+	// A non-graceful cancellation interrupts operations in flight right away.
+	s.Cancel()
+	assert.Error(t, c.CancelErr())
+	assert.Error(t, c.InterruptErr())
+	assert.NoError(t, c.TerminateErr())
+
+	s.Terminate()
+	assert.Error(t, c.TerminateErr())
+}
+
+func TestCancelGracefully(t *testing.T) {
+	c, s := NewContext(context.Background())
+
+	// A graceful cancellation only interrupts operations in flight once the grace period has elapsed.
+	s.CancelGracefully(10 * time.Millisecond)
+	assert.Error(t, c.CancelErr())
+	assert.NoError(t, c.InterruptErr())
+	select {
+	case <-c.Interrupted():
+	case <-time.After(10 * time.Second):
+		assert.Fail(t, "expected the context to be interrupted")
+	}
+	assert.NoError(t, c.TerminateErr())
+
+	// Without a grace period, only termination interrupts them.
+	c, s = NewContext(context.Background())
+	s.CancelGracefully(0)
+	assert.Error(t, c.CancelErr())
+	assert.NoError(t, c.InterruptErr())
+	s.Terminate()
+	assert.Error(t, c.InterruptErr())
+}
+
+func TestCloseStopsGracePeriod(t *testing.T) {
+	c, s := NewContext(context.Background())
+
+	// Once the source is closed, a pending grace period no longer interrupts the context.
+	s.CancelGracefully(10 * time.Millisecond)
+	s.Close()
+	time.Sleep(50 * time.Millisecond)
+	assert.Error(t, c.CancelErr())
+	assert.NoError(t, c.InterruptErr())
+
+	// Nor does a grace period that begins after the source is closed.
+	c, s = NewContext(context.Background())
+	s.Close()
+	s.CancelGracefully(10 * time.Millisecond)
+	time.Sleep(50 * time.Millisecond)
+	assert.Error(t, c.CancelErr())
+	assert.NoError(t, c.InterruptErr())
+}
@@ -123,6 +123,7 @@ func ExitError(msg string, args ...interface{}) {
 // exitErrorCode issues an error and exists with the given error exit code.
 func exitErrorCode(code int, msg string, args ...interface{}) {
 	Diag().Errorf(diag.Message("", msg), args...)
+	RestoreTerminals()
 	os.Exit(code)
 }
 
@@ -0,0 +1,41 @@
+// Copyright 2016-2018, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+// +build !darwin,!dragonfly,!freebsd,!linux,!netbsd,!openbsd
+
+package cmdutil
+
+import (
+	"github.com/pkg/errors"
+)
+
+// RestoreTerminals does nothing, since no KeyReader changes a terminal's mode on this platform.
+func RestoreTerminals() {}
+
+// KeyReader reads individual key presses from a terminal.  It is not supported on this platform.
+type KeyReader struct{}
+
+// NewKeyReader returns an error, since reading individual key presses is not supported on this platform.
+func NewKeyReader(fd int) (*KeyReader, error) {
+	return nil, errors.New("reading key presses is not supported on this platform")
+}
+
+// Keys returns a channel on which no key presses are ever delivered.
+func (r *KeyReader) Keys() <-chan byte {
+	return nil
+}
+
+// Close does nothing.
+func (r *KeyReader) Close() error {
+	return nil
+}
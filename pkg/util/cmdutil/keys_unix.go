@@ -0,0 +1,151 @@
+// Copyright 2016-2018, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+// +build darwin dragonfly freebsd linux netbsd openbsd
+
+package cmdutil
+
+import (
+	"os"
+	"os/signal"
+	"sync"
+
+	"golang.org/x/sys/unix"
+
+	"github.com/pulumi/pulumi/pkg/util/contract"
+)
+
+var (
+	activeReadersLock sync.Mutex
+	activeReaders     = make(map[*KeyReader]bool)
+)
+
+// RestoreTerminals restores the previous mode of every terminal whose mode was changed by a KeyReader that has not yet
+// been closed.  It is meant for paths that end the process without closing readers, such as fatal errors and panics.
+func RestoreTerminals() {
+	activeReadersLock.Lock()
+	defer activeReadersLock.Unlock()
+	for r := range activeReaders {
+		contract.IgnoreError(unix.IoctlSetTermios(r.fd, ioctlWriteTermios, &r.old))
+	}
+}
+
+// KeyReader reads individual key presses from a terminal as they are typed, without waiting for a newline and
+// without echoing them.  Signals such as ^C continue to be delivered as usual.
+type KeyReader struct {
+	fd      int
+	old     unix.Termios
+	keys    chan byte
+	stop    chan struct{}
+	stopped chan struct{}
+	signals chan os.Signal
+}
+
+// NewKeyReader puts the terminal with the given file descriptor into a mode in which key presses can be read one at a
+// time, and starts reading them.  The reader must be closed to restore the terminal's previous mode.
+func NewKeyReader(fd int) (*KeyReader, error) {
+	old, err := unix.IoctlGetTermios(fd, ioctlReadTermios)
+	if err != nil {
+		return nil, err
+	}
+
+	// Turn off line buffering and echoing.  Reads time out after a tenth of a second, so that we can notice when the
+	// reader is closed rather than blocking indefinitely (and stealing input meant for whatever reads it next).
+	termios := *old
+	termios.Lflag &^= unix.ICANON | unix.ECHO
+	termios.Cc[unix.VMIN] = 0
+	termios.Cc[unix.VTIME] = 1
+	if err = unix.IoctlSetTermios(fd, ioctlWriteTermios, &termios); err != nil {
+		return nil, err
+	}
+
+	r := &KeyReader{
+		fd:      fd,
+		old:     *old,
+		keys:    make(chan byte),
+		stop:    make(chan struct{}),
+		stopped: make(chan struct{}),
+		signals: make(chan os.Signal, 1),
+	}
+
+	activeReadersLock.Lock()
+	activeReaders[r] = true
+	activeReadersLock.Unlock()
+
+	// Signals that terminate the process would leave the terminal in this mode, so catch them, restore the terminal,
+	// and then deliver them again.  Interrupts are left to the program, which closes the reader as it shuts down.
+	signal.Notify(r.signals, unix.SIGTERM, unix.SIGHUP, unix.SIGQUIT)
+	go r.handleSignals()
+
+	go r.read()
+	return r, nil
+}
+
+func (r *KeyReader) handleSignals() {
+	sig, ok := <-r.signals
+	if !ok {
+		return
+	}
+
+	RestoreTerminals()
+	signal.Reset(sig)
+	if s, isUnix := sig.(unix.Signal); isUnix {
+		contract.IgnoreError(unix.Kill(os.Getpid(), s))
+	}
+}
+
+func (r *KeyReader) read() {
+	defer close(r.stopped)
+
+	buf := make([]byte, 1)
+	for {
+		select {
+		case <-r.stop:
+			return
+		default:
+		}
+
+		n, err := unix.Read(r.fd, buf)
+		if err == unix.EINTR {
+			continue
+		} else if err != nil {
+			return
+		}
+		if n == 1 {
+			select {
+			case r.keys <- buf[0]:
+			case <-r.stop:
+				return
+			}
+		}
+	}
+}
+
+// Keys returns the channel on which key presses are delivered.
+func (r *KeyReader) Keys() <-chan byte {
+	return r.keys
+}
+
+// Close stops reading key presses and restores the terminal's previous mode.
+func (r *KeyReader) Close() error {
+	signal.Stop(r.signals)
+	close(r.signals)
+
+	close(r.stop)
+	<-r.stopped
+
+	activeReadersLock.Lock()
+	defer activeReadersLock.Unlock()
+	delete(activeReaders, r)
+	return unix.IoctlSetTermios(r.fd, ioctlWriteTermios, &r.old)
+}
@@ -4,6 +4,8 @@ package gitutil
 
 import (
 	"fmt"
+	"net/url"
+	"os"
 	"path"
 	"path/filepath"
 	"strings"
@@ -11,6 +13,8 @@ import (
 	"github.com/pkg/errors"
 	"github.com/pulumi/pulumi/pkg/util/fsutil"
 	git "gopkg.in/src-d/go-git.v4"
+	"gopkg.in/src-d/go-git.v4/plumbing"
+	"gopkg.in/src-d/go-git.v4/plumbing/object"
 )
 
 // GetGitRepository returns the git repository by walking up from the provided directory.
@@ -81,6 +85,139 @@ func GetGitHubProjectForOriginByRepo(repo *git.Repository) (string, string, erro
 	return split[0], split[1], nil
 }
 
+// GetGitRemoteURLForOrigin returns the URL of the "origin" remote for the git repository containing dir. Any
+// credentials embedded in an HTTP(S) remote URL are removed, so that the result is safe to display and store.
+func GetGitRemoteURLForOrigin(dir string) (string, error) {
+	repo, err := GetGitRepository(dir)
+	if repo == nil {
+		return "", fmt.Errorf("no git repository found from %v", dir)
+	}
+	if err != nil {
+		return "", err
+	}
+
+	remote, err := repo.Remote("origin")
+	if err != nil {
+		return "", errors.Wrap(err, "could not read origin information")
+	}
+	if len(remote.Config().URLs) == 0 {
+		return "", nil
+	}
+
+	return stripGitRemoteCredentials(remote.Config().URLs[0]), nil
+}
+
+// stripGitRemoteCredentials removes any user information from an HTTP(S) remote URL.  Other forms of remote URLs,
+// like SCP-style SSH remotes (git@github.com:owner/repo.git), are returned unchanged.
+func stripGitRemoteCredentials(remoteURL string) string {
+	u, err := url.Parse(remoteURL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.User == nil {
+		return remoteURL
+	}
+	u.User = nil
+	return u.String()
+}
+
+// GitCloneAndCheckout clones the git repository at url into the (empty or nonexistent) directory path, and checks
+// out the given ref, which may be a branch name, a tag name, or a full or abbreviated (at least four characters)
+// commit hash.  If ref is empty, the repository's default branch is checked out.
+func GitCloneAndCheckout(url string, ref string, path string) error {
+	if ref == "" {
+		_, err := git.PlainClone(path, false, &git.CloneOptions{URL: url, SingleBranch: true, Depth: 1})
+		return err
+	}
+
+	// Branches and tags can be fetched with a cheap, shallow clone; try those first.
+	for _, refName := range []plumbing.ReferenceName{
+		plumbing.ReferenceName("refs/heads/" + ref),
+		plumbing.ReferenceName("refs/tags/" + ref),
+	} {
+		_, err := git.PlainClone(path, false, &git.CloneOptions{
+			URL:           url,
+			ReferenceName: refName,
+			SingleBranch:  true,
+			Depth:         1,
+		})
+		if err == nil {
+			return nil
+		} else if err != plumbing.ErrReferenceNotFound {
+			return err
+		}
+
+		// A failed clone leaves a partially initialized repository behind, which must be removed before retrying.
+		if err = os.RemoveAll(path); err != nil {
+			return err
+		}
+	}
+
+	// Otherwise, the ref must be a commit hash, which requires the full history.
+	if !isCommitHashPrefix(ref) {
+		return errors.Errorf("no branch, tag, or commit named '%s' found in %s", ref, url)
+	}
+	repo, err := git.PlainClone(path, false, &git.CloneOptions{URL: url})
+	if err != nil {
+		return err
+	}
+	hash, err := resolveCommitHash(repo, ref)
+	if err != nil {
+		return errors.Wrapf(err, "resolving '%s' in %s", ref, url)
+	}
+	w, err := repo.Worktree()
+	if err != nil {
+		return err
+	}
+	return w.Checkout(&git.CheckoutOptions{Hash: hash, Force: true})
+}
+
+// isCommitHashPrefix returns true if ref could be a full or abbreviated commit hash.
+func isCommitHashPrefix(ref string) bool {
+	if len(ref) < 4 || len(ref) > 40 {
+		return false
+	}
+	for _, c := range strings.ToLower(ref) {
+		if !strings.ContainsRune("0123456789abcdef", c) {
+			return false
+		}
+	}
+	return true
+}
+
+// resolveCommitHash finds the single commit in repo whose hash starts with the given full or abbreviated hash.
+func resolveCommitHash(repo *git.Repository, ref string) (plumbing.Hash, error) {
+	ref = strings.ToLower(ref)
+	if len(ref) == 40 {
+		hash := plumbing.NewHash(ref)
+		if _, err := repo.CommitObject(hash); err != nil {
+			return plumbing.ZeroHash, errors.Errorf("no commit named '%s' found", ref)
+		}
+		return hash, nil
+	}
+
+	commits, err := repo.CommitObjects()
+	if err != nil {
+		return plumbing.ZeroHash, err
+	}
+	var matches []plumbing.Hash
+	err = commits.ForEach(func(c *object.Commit) error {
+		if strings.HasPrefix(c.Hash.String(), ref) {
+			matches = append(matches, c.Hash)
+		}
+		return nil
+	})
+	if err != nil {
+		return plumbing.ZeroHash, err
+	}
+
+	switch len(matches) {
+	case 0:
+		return plumbing.ZeroHash, errors.Errorf("no commit named '%s' found", ref)
+	case 1:
+		return matches[0], nil
+	default:
+		return plumbing.ZeroHash, errors.Errorf("commit hash '%s' is ambiguous; use a longer or full hash", ref)
+	}
+}
+
 func trimGitRemoteURL(url string, prefix string, suffix string) string {
 	return strings.TrimSuffix(strings.TrimPrefix(url, prefix), suffix)
 }
@@ -0,0 +1,106 @@
+// Copyright 2016-2018, Pulumi Corporation.  All rights reserved.
+
+package gitutil
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	git "gopkg.in/src-d/go-git.v4"
+	"gopkg.in/src-d/go-git.v4/plumbing"
+	"gopkg.in/src-d/go-git.v4/plumbing/object"
+)
+
+// newTestRepo creates a repository in a temporary directory with one commit per file content, returning the
+// directory and the commit hashes in order.
+func newTestRepo(t *testing.T, contents ...string) (string, []plumbing.Hash) {
+	dir, err := ioutil.TempDir("", "gitutil-test")
+	assert.NoError(t, err)
+	repo, err := git.PlainInit(dir, false)
+	assert.NoError(t, err)
+	w, err := repo.Worktree()
+	assert.NoError(t, err)
+
+	var hashes []plumbing.Hash
+	for _, content := range contents {
+		assert.NoError(t, ioutil.WriteFile(filepath.Join(dir, "file.txt"), []byte(content), 0600))
+		_, err = w.Add("file.txt")
+		assert.NoError(t, err)
+		hash, err := w.Commit(content, &git.CommitOptions{
+			Author: &object.Signature{Name: "test", Email: "test@example.com", When: time.Now()},
+		})
+		assert.NoError(t, err)
+		hashes = append(hashes, hash)
+	}
+	return dir, hashes
+}
+
+func TestIsCommitHashPrefix(t *testing.T) {
+	assert.True(t, isCommitHashPrefix("abcd"))
+	assert.True(t, isCommitHashPrefix("ABCDEF0123"))
+	assert.True(t, isCommitHashPrefix("0123456789abcdef0123456789abcdef01234567"))
+	assert.False(t, isCommitHashPrefix("abc"))
+	assert.False(t, isCommitHashPrefix("master"))
+	assert.False(t, isCommitHashPrefix("0123456789abcdef0123456789abcdef012345678"))
+}
+
+func TestResolveCommitHash(t *testing.T) {
+	dir, hashes := newTestRepo(t, "one", "two")
+	defer os.RemoveAll(dir)
+	repo, err := git.PlainOpen(dir)
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	// Both full and abbreviated hashes resolve.
+	for _, hash := range hashes {
+		resolved, err := resolveCommitHash(repo, hash.String())
+		assert.NoError(t, err)
+		assert.Equal(t, hash, resolved)
+		resolved, err = resolveCommitHash(repo, hash.String()[:7])
+		assert.NoError(t, err)
+		assert.Equal(t, hash, resolved)
+	}
+
+	// Unknown hashes don't.
+	missing := "0000000000000000000000000000000000000000"
+	if hashes[0].String()[:4] != missing[:4] && hashes[1].String()[:4] != missing[:4] {
+		_, err = resolveCommitHash(repo, missing[:4])
+		assert.Error(t, err)
+	}
+	_, err = resolveCommitHash(repo, missing)
+	assert.Error(t, err)
+}
+
+func TestGitCloneAndCheckout(t *testing.T) {
+	source, hashes := newTestRepo(t, "one", "two")
+	defer os.RemoveAll(source)
+
+	checkout := func(ref string) (string, error) {
+		dir, err := ioutil.TempDir("", "gitutil-test")
+		assert.NoError(t, err)
+		defer os.RemoveAll(dir)
+		if err = GitCloneAndCheckout(source, ref, filepath.Join(dir, "clone")); err != nil {
+			return "", err
+		}
+		b, err := ioutil.ReadFile(filepath.Join(dir, "clone", "file.txt"))
+		return string(b), err
+	}
+
+	contents, err := checkout("")
+	assert.NoError(t, err)
+	assert.Equal(t, "two", contents)
+	contents, err = checkout(hashes[0].String())
+	assert.NoError(t, err)
+	assert.Equal(t, "one", contents)
+	contents, err = checkout(hashes[0].String()[:8])
+	assert.NoError(t, err)
+	assert.Equal(t, "one", contents)
+
+	_, err = checkout("no-such-branch")
+	assert.Error(t, err)
+}
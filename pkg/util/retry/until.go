@@ -24,6 +24,10 @@ type Acceptor struct {
 	Delay    *time.Duration // an optional delay duration.
 	Backoff  *float64       // an optional backoff multiplier.
 	MaxDelay *time.Duration // an optional maximum delay duration.
+
+	// Wait is an optional function that, before each retry, is given the delay that the backoff calls for and returns
+	// the delay to wait instead; it may be used, e.g., to honor a delay requested by a server.
+	Wait func(try int, delay time.Duration) time.Duration
 }
 
 // Acceptance is meant to accept a condition.  It returns true when this condition has succeeded, and false otherwise
@@ -77,10 +81,16 @@ func Until(ctx context.Context, acceptor Acceptor) (bool, interface{}, error) {
 		}
 
 		// Wait for delay or timeout.
+		wait := delay
+		if acceptor.Wait != nil {
+			wait = acceptor.Wait(try, delay)
+		}
+		timer := time.NewTimer(wait)
 		select {
-		case <-time.After(delay):
+		case <-timer.C:
 			// Continue on.
 		case <-ctx.Done():
+			timer.Stop()
 			return false, nil, nil
 		}
 
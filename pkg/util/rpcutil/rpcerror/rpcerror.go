@@ -30,9 +30,12 @@ package rpcerror
 
 import (
 	"fmt"
+	"time"
 
 	"github.com/golang/protobuf/proto"
+	"github.com/golang/protobuf/ptypes"
 	"github.com/pkg/errors"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 
@@ -83,6 +86,24 @@ func (r *Error) Details() []interface{} {
 	return r.details
 }
 
+// Retryable returns true if the server marked this error as transient, meaning that the operation that failed may
+// succeed if it is retried, along with the delay the server requested before doing so (zero if it didn't request one).
+func (r *Error) Retryable() (bool, time.Duration) {
+	for _, detail := range r.details {
+		if retryInfo, ok := detail.(*errdetails.RetryInfo); ok {
+			var delay time.Duration
+			if retryInfo.RetryDelay != nil {
+				d, err := ptypes.Duration(retryInfo.RetryDelay)
+				if err == nil {
+					delay = d
+				}
+			}
+			return true, delay
+		}
+	}
+	return false, 0
+}
+
 // ErrorCause represents a root cause of an error that ultimately caused
 // an RPC endpoint to issue an error. ErrorCauses are optionally attached
 // to Errors.
@@ -157,6 +178,16 @@ func WithDetails(err error, details ...proto.Message) error {
 	return status.Err()
 }
 
+// WithRetry marks an error created by this package as transient, telling clients that the operation that failed may
+// succeed if it is retried after the given delay.  A zero delay leaves it to the client to choose one.
+func WithRetry(err error, delay time.Duration) error {
+	retryInfo := &errdetails.RetryInfo{}
+	if delay > 0 {
+		retryInfo.RetryDelay = ptypes.DurationProto(delay)
+	}
+	return WithDetails(err, retryInfo)
+}
+
 // FromError "unwraps" an error created by functions in the `rpcerror` package and produces
 // an `Error` structure from them.
 //
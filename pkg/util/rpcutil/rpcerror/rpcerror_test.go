@@ -16,6 +16,7 @@ package rpcerror
 
 import (
 	"testing"
+	"time"
 
 	"github.com/pkg/errors"
 	"github.com/stretchr/testify/assert"
@@ -137,3 +138,21 @@ func TestErrorString(t *testing.T) {
 
 	assert.Equal(t, "thing failed 2", unwrapped.Error())
 }
+
+func TestRetryable(t *testing.T) {
+	rpcErr, ok := FromError(New(codes.Unavailable, "try again"))
+	if !assert.True(t, ok) {
+		t.FailNow()
+	}
+	retryable, _ := rpcErr.Retryable()
+	assert.False(t, retryable)
+
+	rpcErr, ok = FromError(WithRetry(New(codes.Unavailable, "throttled"), 2*time.Second))
+	if !assert.True(t, ok) {
+		t.FailNow()
+	}
+	retryable, delay := rpcErr.Retryable()
+	assert.True(t, retryable)
+	assert.Equal(t, 2*time.Second, delay)
+	assert.Equal(t, "throttled", rpcErr.Error())
+}
@@ -15,6 +15,7 @@
 package workspace
 
 import (
+	"encoding/json"
 	"io/ioutil"
 	"os"
 	"path/filepath"
@@ -41,7 +42,7 @@ type Analyzers []tokens.QName
 // nolint: lll
 type Project struct {
 	Name    tokens.PackageName `json:"name" yaml:"name"`                     // a required fully qualified name.
-	Runtime string             `json:"runtime" yaml:"runtime"`               // a required runtime that executes code.
+	Runtime ProjectRuntimeInfo `json:"runtime" yaml:"runtime"`               // a required runtime that executes code.
 	Main    string             `json:"main,omitempty" yaml:"main,omitempty"` // an optional override for the main program location.
 
 	Description *string `json:"description,omitempty" yaml:"description,omitempty"` // an optional informational description.
@@ -57,11 +58,56 @@ type Project struct {
 	Config string `json:"config,omitempty" yaml:"config,omitempty"` // where to store Pulumi.<stack-name>.yaml files, this is combined with the folder Pulumi.yaml is in.
 }
 
+// ProjectRuntimeInfo is the runtime that executes a project's program.  In a manifest, it may be written either as a
+// plain string naming the runtime, or as an object that also supplies options for the runtime's language host.
+type ProjectRuntimeInfo struct {
+	// The name of the runtime, such as `nodejs` or `go`.
+	Name string `json:"name" yaml:"name"`
+	// Optional runtime-specific options, which are passed to the language host as command line flags, and so must be
+	// strings, numbers, or booleans.  Only the Go language host accepts options (`binary`); the others reject them.
+	Options map[string]interface{} `json:"options,omitempty" yaml:"options,omitempty"`
+}
+
+// plainProjectRuntimeInfo has the same fields as ProjectRuntimeInfo, but none of its marshaling methods.
+type plainProjectRuntimeInfo ProjectRuntimeInfo
+
+func (info ProjectRuntimeInfo) MarshalJSON() ([]byte, error) {
+	if len(info.Options) == 0 {
+		return json.Marshal(info.Name)
+	}
+	return json.Marshal(plainProjectRuntimeInfo(info))
+}
+
+func (info *ProjectRuntimeInfo) UnmarshalJSON(b []byte) error {
+	var s string
+	if err := json.Unmarshal(b, &s); err == nil {
+		*info = ProjectRuntimeInfo{Name: s}
+		return nil
+	}
+	return json.Unmarshal(b, (*plainProjectRuntimeInfo)(info))
+}
+
+func (info ProjectRuntimeInfo) MarshalYAML() (interface{}, error) {
+	if len(info.Options) == 0 {
+		return info.Name, nil
+	}
+	return plainProjectRuntimeInfo(info), nil
+}
+
+func (info *ProjectRuntimeInfo) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	var s string
+	if err := unmarshal(&s); err == nil {
+		*info = ProjectRuntimeInfo{Name: s}
+		return nil
+	}
+	return unmarshal((*plainProjectRuntimeInfo)(info))
+}
+
 func (proj *Project) Validate() error {
 	if proj.Name == "" {
 		return errors.New("project is missing a 'name' attribute")
 	}
-	if proj.Runtime == "" {
+	if proj.Runtime.Name == "" {
 		return errors.New("project is missing a 'runtime' attribute")
 	}
 	return nil
@@ -0,0 +1,53 @@
+// Copyright 2016-2018, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package workspace
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/yaml.v2"
+)
+
+func TestProjectRuntimeInfo(t *testing.T) {
+	// The runtime may be written as a plain string.
+	var proj Project
+	assert.NoError(t, yaml.Unmarshal([]byte("name: test\nruntime: go\n"), &proj))
+	assert.Equal(t, ProjectRuntimeInfo{Name: "go"}, proj.Runtime)
+	b, err := yaml.Marshal(&proj)
+	assert.NoError(t, err)
+	assert.Equal(t, "name: test\nruntime: go\n", string(b))
+
+	// Or as an object with options.
+	proj = Project{}
+	withOptions := "name: test\nruntime:\n  name: go\n  options:\n    binary: bin/test\n"
+	assert.NoError(t, yaml.Unmarshal([]byte(withOptions), &proj))
+	assert.Equal(t, ProjectRuntimeInfo{Name: "go", Options: map[string]interface{}{"binary": "bin/test"}}, proj.Runtime)
+	b, err = yaml.Marshal(&proj)
+	assert.NoError(t, err)
+	assert.Equal(t, withOptions, string(b))
+
+	// The same holds for JSON.
+	proj = Project{}
+	assert.NoError(t, json.Unmarshal([]byte(`{"name":"test","runtime":"go"}`), &proj))
+	assert.Equal(t, ProjectRuntimeInfo{Name: "go"}, proj.Runtime)
+	proj = Project{}
+	assert.NoError(t, json.Unmarshal([]byte(`{"name":"test","runtime":{"name":"go","options":{"binary":"x"}}}`), &proj))
+	assert.Equal(t, ProjectRuntimeInfo{Name: "go", Options: map[string]interface{}{"binary": "x"}}, proj.Runtime)
+	b, err = json.Marshal(proj.Runtime)
+	assert.NoError(t, err)
+	assert.Equal(t, `{"name":"go","options":{"binary":"x"}}`, string(b))
+}
@@ -16,15 +16,22 @@ package workspace
 
 import (
 	"archive/tar"
+	"bytes"
 	"compress/gzip"
+	"encoding/json"
 	"fmt"
 	"io"
 	"io/ioutil"
+	"net/http"
 	"os"
 	"os/user"
+	"path"
 	"path/filepath"
+	"regexp"
 	"runtime"
+	"strconv"
 	"strings"
+	gotemplate "text/template"
 
 	"gopkg.in/yaml.v2"
 
@@ -32,6 +39,8 @@ import (
 	"github.com/pulumi/pulumi/pkg/resource/config"
 	"github.com/pulumi/pulumi/pkg/tokens"
 	"github.com/pulumi/pulumi/pkg/util/contract"
+	"github.com/pulumi/pulumi/pkg/util/gitutil"
+	"github.com/pulumi/pulumi/pkg/util/httputil"
 )
 
 const (
@@ -50,8 +59,159 @@ type Template struct {
 	Description string `json:"description" yaml:"description"`
 	// Optional bool which determines whether dependencies should be installed after project creation.
 	InstallDependencies bool `json:"installdependencies" yaml:"installdependencies"`
-	// Optional default config values.
-	Config map[config.Key]string `json:"config" yaml:"config"`
+	// Optional config values, which are prompted for and saved to the new stack.
+	Config map[config.Key]TemplateConfigValue `json:"config" yaml:"config"`
+	// Optional variables, which are prompted for and made available to Go templates.
+	Variables []TemplateVariable `json:"variables,omitempty" yaml:"variables,omitempty"`
+	// Optional bool which determines whether file contents and names are expanded as Go templates.
+	GoTemplates bool `json:"gotemplates,omitempty" yaml:"gotemplates,omitempty"`
+	// Optional conditions under which files are included in the new project.
+	Files []TemplateFile `json:"files,omitempty" yaml:"files,omitempty"`
+
+	// The directory containing the template's files.  This is not part of the manifest.
+	Dir string `json:"-" yaml:"-"`
+}
+
+// TemplateConfigValue is a config value declared by a template.  In a manifest, it may be written either as a plain
+// string, which is used as the default value, or as an object.
+type TemplateConfigValue struct {
+	// Optional description of the config value, shown when prompting for it.
+	Description string `json:"description,omitempty" yaml:"description,omitempty"`
+	// Optional default value.
+	Default string `json:"default,omitempty" yaml:"default,omitempty"`
+	// Optional bool which determines whether the value is encrypted when saved.
+	Secret bool `json:"secret,omitempty" yaml:"secret,omitempty"`
+}
+
+// plainTemplateConfigValue has the same fields as TemplateConfigValue, but none of its marshaling methods.
+type plainTemplateConfigValue TemplateConfigValue
+
+func (v TemplateConfigValue) isDefaultOnly() bool {
+	return v.Description == "" && !v.Secret
+}
+
+func (v TemplateConfigValue) MarshalJSON() ([]byte, error) {
+	if v.isDefaultOnly() {
+		return json.Marshal(v.Default)
+	}
+	return json.Marshal(plainTemplateConfigValue(v))
+}
+
+func (v *TemplateConfigValue) UnmarshalJSON(b []byte) error {
+	var s string
+	if err := json.Unmarshal(b, &s); err == nil {
+		*v = TemplateConfigValue{Default: s}
+		return nil
+	}
+	return json.Unmarshal(b, (*plainTemplateConfigValue)(v))
+}
+
+func (v TemplateConfigValue) MarshalYAML() (interface{}, error) {
+	if v.isDefaultOnly() {
+		return v.Default, nil
+	}
+	return plainTemplateConfigValue(v), nil
+}
+
+func (v *TemplateConfigValue) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	var s string
+	if err := unmarshal(&s); err == nil {
+		*v = TemplateConfigValue{Default: s}
+		return nil
+	}
+	return unmarshal((*plainTemplateConfigValue)(v))
+}
+
+// TemplateVariable is a variable declared by a template.  Its value is available to Go templates as
+// `{{ .Variables.<name> }}`.
+type TemplateVariable struct {
+	// The name of the variable.
+	Name string `json:"name" yaml:"name"`
+	// Optional description of the variable, shown when prompting for it.
+	Description string `json:"description,omitempty" yaml:"description,omitempty"`
+	// Optional default value.
+	Default string `json:"default,omitempty" yaml:"default,omitempty"`
+	// Optional regular expression that values must match.
+	Pattern string `json:"pattern,omitempty" yaml:"pattern,omitempty"`
+	// Optional bool which determines whether the value is read without echoing it.
+	Secret bool `json:"secret,omitempty" yaml:"secret,omitempty"`
+}
+
+// Validate returns an error if value is not a valid value for the variable.
+func (v TemplateVariable) Validate(value string) error {
+	if v.Pattern == "" {
+		return nil
+	}
+	re, err := regexp.Compile(v.Pattern)
+	if err != nil {
+		return errors.Wrapf(err, "invalid pattern for variable '%s'", v.Name)
+	}
+	if !re.MatchString(value) {
+		return errors.Errorf("value for variable '%s' must match the pattern %s", v.Name, v.Pattern)
+	}
+	return nil
+}
+
+// TemplateFile conditionally includes the template files matching a path pattern.
+type TemplateFile struct {
+	// A slash-separated pattern, in the syntax of path.Match, matched against paths relative to the template root.
+	// If a directory matches, the condition applies to its contents as well.
+	Path string `json:"path" yaml:"path"`
+	// A Go template which is expanded with the template's values; matching files are included only if it expands to
+	// `true`.
+	If string `json:"if" yaml:"if"`
+}
+
+// TemplateValues are the values available to a template's Go templates.
+type TemplateValues struct {
+	// The name of the new project.
+	Project string
+	// The description of the new project.
+	Description string
+	// The values of the template's variables, by name.
+	Variables map[string]string
+}
+
+// validTemplateVariableName matches variable names that can be referenced from Go templates as `.Variables.<name>`.
+var validTemplateVariableName = regexp.MustCompile("^[a-zA-Z_][a-zA-Z0-9_]*$")
+
+// validate returns an error if the template's manifest is malformed.
+func (template Template) validate() error {
+	seen := make(map[string]bool)
+	for _, v := range template.Variables {
+		if !validTemplateVariableName.MatchString(v.Name) {
+			return errors.Errorf("'%s' is not a valid variable name", v.Name)
+		}
+		if seen[v.Name] {
+			return errors.Errorf("variable '%s' is declared more than once", v.Name)
+		}
+		seen[v.Name] = true
+
+		if v.Pattern != "" {
+			if _, err := regexp.Compile(v.Pattern); err != nil {
+				return errors.Wrapf(err, "invalid pattern for variable '%s'", v.Name)
+			}
+			if v.Default != "" {
+				if err := v.Validate(v.Default); err != nil {
+					return errors.Wrap(err, "invalid default")
+				}
+			}
+		}
+	}
+
+	for _, f := range template.Files {
+		if f.Path == "" {
+			return errors.New("file conditions must specify a path")
+		}
+		if _, err := path.Match(f.Path, ""); err != nil {
+			return errors.Wrapf(err, "invalid file path pattern '%s'", f.Path)
+		}
+		if _, err := newGoTemplate(f.Path, f.If); err != nil {
+			return errors.Wrapf(err, "invalid condition for files matching '%s'", f.Path)
+		}
+	}
+
+	return nil
 }
 
 // LoadLocalTemplate returns a local template.
@@ -76,6 +236,7 @@ func LoadLocalTemplate(name string) (Template, error) {
 	}
 
 	template.Name = name
+	template.Dir = templateDir
 	return template, nil
 }
 
@@ -142,17 +303,249 @@ func InstallTemplate(name string, tarball io.ReadCloser) error {
 	return nil
 }
 
-// CopyTemplateFilesDryRun does a dry run of copying a template to a destination directory,
-// to ensure it won't overwrite any files.
-func (template Template) CopyTemplateFilesDryRun(destDir string) error {
-	var err error
-	var sourceDir string
-	if sourceDir, err = GetTemplateDir(template.Name); err != nil {
-		return err
+// RemoteTemplate is a template that has been retrieved from a git repository or tarball into a temporary
+// directory.  Callers must call Delete once they are done with it to clean up the temporary directory.
+type RemoteTemplate struct {
+	// The retrieved template.  Its Dir refers to a location within Root.
+	Template Template
+	// The temporary directory the template was retrieved into.
+	Root string
+}
+
+// Delete removes the temporary directory the template was retrieved into.
+func (t RemoteTemplate) Delete() error {
+	return os.RemoveAll(t.Root)
+}
+
+// templateSourceKind is the kind of location a remote template is retrieved from.
+type templateSourceKind int
+
+const (
+	gitTemplateSource templateSourceKind = iota
+	tarballTemplateSource
+)
+
+// templateSource describes a remote template location, as parsed by parseTemplateSource.
+type templateSource struct {
+	Kind     templateSourceKind
+	Location string // the git repository URL, or the tarball URL or path.
+	Subdir   string // an optional subdirectory within the repository or tarball containing the template.
+	Ref      string // an optional git branch, tag, or commit to check out.
+}
+
+// IsTemplateURL returns true if the given template argument refers to a git repository or tarball rather than
+// the name of a template in the local template cache.
+func IsTemplateURL(s string) bool {
+	_, ok := parseTemplateSource(s)
+	return ok
+}
+
+// parseTemplateSource parses a template argument of the form `[git::]<location>[//<subdir>][#<ref>]`.  Locations
+// ending in `.tar.gz` or `.tgz` are treated as tarball URLs or paths; `http(s)://`, `ssh://`, `git://` and `git@`
+// locations, and any location with the `git::` prefix, are treated as git repositories.
+func parseTemplateSource(s string) (templateSource, bool) {
+	forceGit := strings.HasPrefix(s, "git::")
+	s = strings.TrimPrefix(s, "git::")
+
+	var source templateSource
+	if idx := strings.LastIndex(s, "#"); idx != -1 {
+		s, source.Ref = s[:idx], s[idx+1:]
+	}
+
+	// Split off the subdirectory, being careful to skip over the `//` that follows a URL scheme.
+	start := 0
+	if idx := strings.Index(s, "://"); idx != -1 {
+		start = idx + len("://")
+	}
+	if idx := strings.Index(s[start:], "//"); idx != -1 {
+		s, source.Subdir = s[:start+idx], strings.Trim(s[start+idx+len("//"):], "/")
+	}
+	source.Location = s
+
+	isURL := false
+	for _, prefix := range []string{"https://", "http://", "ssh://", "git://", "git@"} {
+		if strings.HasPrefix(s, prefix) {
+			isURL = true
+			break
+		}
+	}
+
+	switch {
+	case !forceGit && (strings.HasSuffix(s, ".tar.gz") || strings.HasSuffix(s, ".tgz")):
+		if source.Ref != "" {
+			return templateSource{}, false
+		}
+		source.Kind = tarballTemplateSource
+		return source, true
+	case forceGit || isURL:
+		source.Kind = gitTemplateSource
+		return source, true
+	default:
+		return templateSource{}, false
+	}
+}
+
+// defaultName returns the name to use for a remote template whose manifest doesn't specify one.
+func (source templateSource) defaultName() string {
+	name := source.Subdir
+	if name == "" {
+		name = strings.TrimRight(source.Location, "/")
+		if idx := strings.LastIndexAny(name, "/:"); idx != -1 {
+			name = name[idx+1:]
+		}
+		for _, suffix := range []string{".git", ".tar.gz", ".tgz"} {
+			name = strings.TrimSuffix(name, suffix)
+		}
+	}
+	return filepath.Base(name)
+}
+
+// RetrieveTemplate clones the git repository or extracts the tarball referred to by the given template URL into
+// a temporary directory, and loads the template it contains.  The template must have a manifest.
+func RetrieveTemplate(templateURL string) (RemoteTemplate, error) {
+	source, ok := parseTemplateSource(templateURL)
+	if !ok {
+		return RemoteTemplate{}, errors.Errorf("'%s' is not a template URL", templateURL)
+	}
+
+	root, err := ioutil.TempDir("", "pulumi-template-")
+	if err != nil {
+		return RemoteTemplate{}, errors.Wrap(err, "creating temporary directory")
+	}
+	result := RemoteTemplate{Root: root}
+
+	template, err := retrieveTemplate(source, root)
+	if err != nil {
+		contract.IgnoreError(result.Delete())
+		return RemoteTemplate{}, err
+	}
+	result.Template = template
+	return result, nil
+}
+
+// retrieveTemplate retrieves the template referred to by source into the directory root.
+func retrieveTemplate(source templateSource, root string) (Template, error) {
+	switch source.Kind {
+	case gitTemplateSource:
+		if err := gitutil.GitCloneAndCheckout(source.Location, source.Ref, root); err != nil {
+			return Template{}, errors.Wrapf(err, "cloning %s", source.Location)
+		}
+		if err := os.RemoveAll(filepath.Join(root, ".git")); err != nil {
+			return Template{}, err
+		}
+	case tarballTemplateSource:
+		tarball, err := openTemplateTarball(source.Location)
+		if err != nil {
+			return Template{}, err
+		}
+		if err = extractTarball(tarball, root); err != nil {
+			return Template{}, errors.Wrapf(err, "extracting %s", source.Location)
+		}
+	default:
+		contract.Failf("unexpected template source kind %v", source.Kind)
+	}
+
+	templateDir, err := findTemplateDir(source, root)
+	if err != nil {
+		return Template{}, err
+	}
+
+	// Remote templates must have a valid manifest.
+	template, err := readTemplateManifest(filepath.Join(templateDir, pulumiTemplateManifestFile))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return Template{}, errors.Errorf("%s does not contain a %s file", source.Location, pulumiTemplateManifestFile)
+		}
+		return Template{}, errors.Wrapf(err, "reading %s from %s", pulumiTemplateManifestFile, source.Location)
+	}
+	if template.Name == "" {
+		template.Name = source.defaultName()
+	}
+	template.Dir = templateDir
+
+	// On Windows, we need to replace \n with \r\n. We'll just do this as a separate step.
+	if runtime.GOOS == "windows" {
+		if err = fixWindowsLineEndings(templateDir); err != nil {
+			return Template{}, errors.Wrapf(err, "fixing line endings in %s", templateDir)
+		}
+	}
+
+	return template, nil
+}
+
+// openTemplateTarball opens the tarball at the given http(s) URL or local path.
+func openTemplateTarball(location string) (io.ReadCloser, error) {
+	if strings.HasPrefix(location, "https://") || strings.HasPrefix(location, "http://") {
+		resp, err := httputil.GetWithRetry(location, http.DefaultClient)
+		if err != nil {
+			return nil, errors.Wrapf(err, "downloading %s", location)
+		}
+		if resp.StatusCode != http.StatusOK {
+			contract.IgnoreClose(resp.Body)
+			return nil, errors.Errorf("downloading %s: %s", location, resp.Status)
+		}
+		return resp.Body, nil
+	}
+
+	f, err := os.Open(location)
+	if err != nil {
+		return nil, errors.Wrapf(err, "opening %s", location)
+	}
+	return f, nil
+}
+
+// findTemplateDir returns the directory of the template within root, into which source has been retrieved.
+func findTemplateDir(source templateSource, root string) (string, error) {
+	templateDir := root
+	if source.Subdir != "" {
+		dir, err := containedPath(root, source.Subdir)
+		if err != nil {
+			return "", err
+		}
+		templateDir = dir
+	} else if source.Kind == tarballTemplateSource {
+		// Many tarballs (e.g. source archives) wrap their contents in a single top-level directory.
+		templateDir = unwrapSingleDir(root)
+	}
+
+	info, err := os.Stat(templateDir)
+	if err != nil {
+		return "", errors.Errorf("template directory '%s' not found in %s", source.Subdir, source.Location)
+	}
+	if !info.IsDir() {
+		return "", errors.Errorf("template '%s' in %s is not a directory", source.Subdir, source.Location)
+	}
+
+	// Repositories may contain links, so the template directory must still be within root once they are resolved.
+	resolvedRoot, err := filepath.EvalSymlinks(root)
+	if err != nil {
+		return "", err
+	}
+	resolvedDir, err := filepath.EvalSymlinks(templateDir)
+	if err != nil {
+		return "", err
 	}
+	if resolvedDir != resolvedRoot && !strings.HasPrefix(resolvedDir, resolvedRoot+string(filepath.Separator)) {
+		return "", errors.Errorf("template directory '%s' in %s links to a location outside of the template",
+			source.Subdir, source.Location)
+	}
+	return templateDir, nil
+}
+
+// unwrapSingleDir returns the only entry of dir if that entry is a directory, and dir itself otherwise.
+func unwrapSingleDir(dir string) string {
+	infos, err := ioutil.ReadDir(dir)
+	if err != nil || len(infos) != 1 || !infos[0].IsDir() {
+		return dir
+	}
+	return filepath.Join(dir, infos[0].Name())
+}
 
+// CopyTemplateFilesDryRun does a dry run of copying a template to a destination directory,
+// to ensure it won't overwrite any files.
+func (template Template) CopyTemplateFilesDryRun(destDir string, values TemplateValues) error {
 	var existing []string
-	err = walkFiles(sourceDir, destDir, func(info os.FileInfo, source string, dest string) error {
+	err := template.walkTemplateFiles(destDir, values, func(info os.FileInfo, source string, dest string) error {
 		if destInfo, statErr := os.Stat(dest); statErr == nil && !destInfo.IsDir() {
 			existing = append(existing, filepath.Base(dest))
 		}
@@ -169,15 +562,8 @@ func (template Template) CopyTemplateFilesDryRun(destDir string) error {
 }
 
 // CopyTemplateFiles does the actual copy operation to a destination directory.
-func (template Template) CopyTemplateFiles(
-	destDir string, force bool, projectName string, projectDescription string) error {
-
-	sourceDir, err := GetTemplateDir(template.Name)
-	if err != nil {
-		return err
-	}
-
-	return walkFiles(sourceDir, destDir, func(info os.FileInfo, source string, dest string) error {
+func (template Template) CopyTemplateFiles(destDir string, force bool, values TemplateValues) error {
+	return template.walkTemplateFiles(destDir, values, func(info os.FileInfo, source string, dest string) error {
 		if info.IsDir() {
 			// Create the destination directory.
 			return os.Mkdir(dest, 0700)
@@ -192,7 +578,13 @@ func (template Template) CopyTemplateFiles(
 		// Transform only if it isn't a binary file.
 		result := b
 		if !isBinary(b) {
-			transformed := transform(string(b), projectName, projectDescription)
+			content := string(b)
+			if template.GoTemplates {
+				if content, err = expandGoTemplate(source, content, values); err != nil {
+					return err
+				}
+			}
+			transformed := transform(content, values.Project, values.Description)
 			result = []byte(transformed)
 		}
 
@@ -208,6 +600,99 @@ func (template Template) CopyTemplateFiles(
 	})
 }
 
+// NeedsValuesForFileNames returns true if the set or names of the files the template creates depend on the values
+// it is expanded with.
+func (template Template) NeedsValuesForFileNames() bool {
+	return template.GoTemplates || len(template.Files) > 0
+}
+
+// walkTemplateFiles walks the template's files, skipping those excluded by the template's file conditions, and
+// performs an action for each one.  If the template uses Go templates, destination names are expanded.
+func (template Template) walkTemplateFiles(destDir string, values TemplateValues,
+	actionFn func(info os.FileInfo, source string, dest string) error) error {
+
+	var walk func(sourceDir string, destDir string) error
+	walk = func(sourceDir string, destDir string) error {
+		infos, err := ioutil.ReadDir(sourceDir)
+		if err != nil {
+			return err
+		}
+		for _, info := range infos {
+			name := info.Name()
+			source := filepath.Join(sourceDir, name)
+
+			// Ignore template manifest file.
+			if !info.IsDir() && name == pulumiTemplateManifestFile && sourceDir == template.Dir {
+				continue
+			}
+
+			// Refuse links, which could otherwise copy arbitrary files from this machine into the new project.
+			if info.Mode()&os.ModeSymlink != 0 {
+				return errors.Errorf("template file %s is a link, which templates may not contain", source)
+			}
+
+			include, err := template.includes(source, values)
+			if err != nil {
+				return err
+			}
+			if !include {
+				continue
+			}
+
+			if template.GoTemplates {
+				if name, err = expandGoTemplate(source, name, values); err != nil {
+					return err
+				}
+				if name == "" || name == "." || name == ".." || strings.ContainsAny(name, `/\`) {
+					return errors.Errorf("name of %s expands to '%s', which is not a valid file name", source, name)
+				}
+			}
+			dest := filepath.Join(destDir, name)
+
+			if err := actionFn(info, source, dest); err != nil {
+				return err
+			}
+			if info.IsDir() {
+				if err := walk(source, dest); err != nil {
+					return err
+				}
+			}
+		}
+		return nil
+	}
+
+	contract.Require(template.Dir != "", "template.Dir")
+	contract.Require(destDir != "", "destDir")
+	return walk(template.Dir, destDir)
+}
+
+// includes returns true if the template file at source satisfies the conditions of all file rules matching it.
+func (template Template) includes(source string, values TemplateValues) (bool, error) {
+	rel, err := filepath.Rel(template.Dir, source)
+	if err != nil {
+		return false, err
+	}
+	rel = filepath.ToSlash(rel)
+
+	for _, f := range template.Files {
+		if matched, _ := path.Match(f.Path, rel); !matched {
+			continue
+		}
+		result, err := expandGoTemplate(pulumiTemplateManifestFile, f.If, values)
+		if err != nil {
+			return false, errors.Wrapf(err, "evaluating condition for %s", rel)
+		}
+		include, err := strconv.ParseBool(strings.TrimSpace(result))
+		if err != nil {
+			return false, errors.Errorf("condition for %s expands to '%s', which is not a bool", rel, result)
+		}
+		if !include {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
 // GetTemplateDir returns the directory in which templates on the current machine are stored.
 func GetTemplateDir(name string) (string, error) {
 	u, err := user.Current()
@@ -287,33 +772,61 @@ func extractTarball(tarball io.ReadCloser, destDir string) error {
 			return errors.Wrapf(err, "untarring")
 		}
 
-		path := filepath.Join(destDir, header.Name)
+		// Pax global headers (such as those written by `git archive`) carry metadata rather than files.
+		if header.Typeflag == tar.TypeXGlobalHeader {
+			continue
+		}
+
+		path, err := containedPath(destDir, header.Name)
+		if err != nil {
+			return errors.Wrapf(err, "untarring %s", header.Name)
+		}
 
 		switch header.Typeflag {
 		case tar.TypeDir:
 			// Create any directories as needed.
-			if _, err := os.Stat(path); err != nil {
-				if err = os.MkdirAll(path, 0700); err != nil {
-					return errors.Wrapf(err, "untarring dir %s", path)
-				}
+			if err = os.MkdirAll(path, 0700); err != nil {
+				return errors.Wrapf(err, "untarring dir %s", path)
 			}
-		case tar.TypeReg:
-			// Expand files into the target directory.
-			dst, err := os.OpenFile(path, os.O_CREATE|os.O_RDWR, os.FileMode(header.Mode))
-			if err != nil {
-				return errors.Wrapf(err, "opening file %s for untar", path)
+		case tar.TypeReg, tar.TypeRegA:
+			// Expand files into the target directory, creating their parent directories in case the tarball has no
+			// entries for them.
+			if err = os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+				return errors.Wrapf(err, "untarring dir %s", filepath.Dir(path))
 			}
-			defer contract.IgnoreClose(dst)
-			if _, err = io.Copy(dst, r); err != nil {
+			if err = extractTarballFile(r, path, os.FileMode(header.Mode)); err != nil {
 				return errors.Wrapf(err, "untarring file %s", path)
 			}
+		case tar.TypeSymlink, tar.TypeLink:
+			return errors.Errorf("template file %s is a link, which is not supported in templates", header.Name)
 		default:
-			return errors.Errorf("unexpected plugin file type %s (%v)", header.Name, header.Typeflag)
+			return errors.Errorf("unexpected template file type %s (%v)", header.Name, header.Typeflag)
 		}
 	}
 	return nil
 }
 
+// extractTarballFile writes the contents of the current tarball entry to the file at path.
+func extractTarballFile(r io.Reader, path string, mode os.FileMode) error {
+	dst, err := os.OpenFile(path, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, mode)
+	if err != nil {
+		return err
+	}
+	defer contract.IgnoreClose(dst)
+	_, err = io.Copy(dst, r)
+	return err
+}
+
+// containedPath joins the relative path rel onto dir, returning an error if the result would lie outside of dir.
+func containedPath(dir, rel string) (string, error) {
+	dir = filepath.Clean(dir)
+	path := filepath.Join(dir, filepath.FromSlash(rel))
+	if path != dir && !strings.HasPrefix(path, dir+string(filepath.Separator)) {
+		return "", errors.Errorf("'%s' refers to a location outside of the template", rel)
+	}
+	return path, nil
+}
+
 // walkFiles is a helper that walks the directories/files in a source directory
 // and performs an action for each item.
 func walkFiles(sourceDir string, destDir string,
@@ -367,6 +880,9 @@ func readTemplateManifest(filename string) (Template, error) {
 	if err != nil {
 		return Template{}, err
 	}
+	if err = manifest.validate(); err != nil {
+		return Template{}, errors.Wrapf(err, "invalid template manifest %s", filename)
+	}
 
 	return manifest, nil
 }
@@ -391,6 +907,24 @@ func transform(content string, projectName string, projectDescription string) st
 	return content
 }
 
+// newGoTemplate parses text as a Go template which fails when referencing variables that are not defined.
+func newGoTemplate(name string, text string) (*gotemplate.Template, error) {
+	return gotemplate.New(name).Option("missingkey=error").Parse(text)
+}
+
+// expandGoTemplate expands text, which was read from the file name, as a Go template with the given values.
+func expandGoTemplate(name string, text string, values TemplateValues) (string, error) {
+	t, err := newGoTemplate(name, text)
+	if err != nil {
+		return "", err
+	}
+	var buf bytes.Buffer
+	if err = t.Execute(&buf, values); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
+
 // writeAllBytes writes the bytes to the specified file, with an option to overwrite.
 func writeAllBytes(filename string, bytes []byte, overwrite bool) error {
 	flag := os.O_WRONLY | os.O_CREATE
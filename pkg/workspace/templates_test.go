@@ -15,9 +15,19 @@
 package workspace
 
 import (
+	"archive/tar"
+	"compress/gzip"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"gopkg.in/yaml.v2"
+
+	"github.com/pulumi/pulumi/pkg/resource/config"
+	"github.com/pulumi/pulumi/pkg/util/contract"
 )
 
 func TestGetValidDefaultProjectName(t *testing.T) {
@@ -76,3 +86,343 @@ func getValidProjectNamePrefixes() []string {
 	results = append(results, ".")
 	return results
 }
+
+func TestParseTemplateSource(t *testing.T) {
+	// Template names are not URLs.
+	for _, name := range []string{"typescript", "aws-javascript", "foo/bar"} {
+		_, ok := parseTemplateSource(name)
+		assert.False(t, ok, name)
+	}
+
+	// Git repositories, with optional subdirectories and refs.
+	source, ok := parseTemplateSource("https://github.com/acme/templates.git")
+	assert.True(t, ok)
+	assert.Equal(t, templateSource{Kind: gitTemplateSource, Location: "https://github.com/acme/templates.git"}, source)
+	assert.Equal(t, "templates", source.defaultName())
+
+	source, ok = parseTemplateSource("https://github.com/acme/templates.git//aws/typescript#v1.2")
+	assert.True(t, ok)
+	assert.Equal(t, templateSource{
+		Kind:     gitTemplateSource,
+		Location: "https://github.com/acme/templates.git",
+		Subdir:   "aws/typescript",
+		Ref:      "v1.2",
+	}, source)
+	assert.Equal(t, "typescript", source.defaultName())
+
+	source, ok = parseTemplateSource("git@github.com:acme/templates.git#master")
+	assert.True(t, ok)
+	assert.Equal(t, templateSource{
+		Kind:     gitTemplateSource,
+		Location: "git@github.com:acme/templates.git",
+		Ref:      "master",
+	}, source)
+	assert.Equal(t, "templates", source.defaultName())
+
+	source, ok = parseTemplateSource("git::https://example.com/templates.tar.gz")
+	assert.True(t, ok)
+	assert.Equal(t, gitTemplateSource, source.Kind)
+	assert.Equal(t, "https://example.com/templates.tar.gz", source.Location)
+
+	// Tarballs, either URLs or local paths.
+	source, ok = parseTemplateSource("https://example.com/templates.tar.gz//python")
+	assert.True(t, ok)
+	assert.Equal(t, templateSource{
+		Kind:     tarballTemplateSource,
+		Location: "https://example.com/templates.tar.gz",
+		Subdir:   "python",
+	}, source)
+
+	source, ok = parseTemplateSource("./my-template.tgz")
+	assert.True(t, ok)
+	assert.Equal(t, templateSource{Kind: tarballTemplateSource, Location: "./my-template.tgz"}, source)
+	assert.Equal(t, "my-template", source.defaultName())
+
+	// Refs are meaningless for tarballs.
+	_, ok = parseTemplateSource("https://example.com/templates.tar.gz#v1")
+	assert.False(t, ok)
+}
+
+func TestTemplateManifest(t *testing.T) {
+	var template Template
+	err := yaml.Unmarshal([]byte(`
+name: example
+config:
+  aws:region: us-west-2
+  example:token:
+    description: An API token
+    secret: true
+variables:
+  - name: bucketCount
+    default: "2"
+    pattern: "^[0-9]+$"
+gotemplates: true
+files:
+  - path: docker
+    if: "{{ eq .Variables.bucketCount \"0\" }}"
+`), &template)
+	assert.NoError(t, err)
+	assert.NoError(t, template.validate())
+
+	assert.Equal(t, TemplateConfigValue{Default: "us-west-2"}, template.Config[config.MustMakeKey("aws", "region")])
+	assert.Equal(t, TemplateConfigValue{Description: "An API token", Secret: true},
+		template.Config[config.MustMakeKey("example", "token")])
+	assert.True(t, template.GoTemplates)
+	assert.Len(t, template.Files, 1)
+
+	v := template.Variables[0]
+	assert.NoError(t, v.Validate("42"))
+	assert.Error(t, v.Validate("forty-two"))
+
+	// Plain config values round-trip as strings.
+	b, err := yaml.Marshal(template.Config)
+	assert.NoError(t, err)
+	assert.Contains(t, string(b), "aws:region: us-west-2")
+
+	// Malformed manifests are rejected.
+	assert.Error(t, Template{Variables: []TemplateVariable{{Name: "not-an-identifier"}}}.validate())
+	assert.Error(t, Template{Variables: []TemplateVariable{{Name: "a"}, {Name: "a"}}}.validate())
+	assert.Error(t, Template{Variables: []TemplateVariable{{Name: "a", Pattern: "("}}}.validate())
+	assert.Error(t, Template{Variables: []TemplateVariable{{Name: "a", Pattern: "^b$", Default: "c"}}}.validate())
+	assert.Error(t, Template{Files: []TemplateFile{{Path: "a", If: "{{ .Project "}}}.validate())
+}
+
+func TestCopyTemplateFilesGoTemplates(t *testing.T) {
+	writeFile := func(dir string, name string, content string) {
+		assert.NoError(t, os.MkdirAll(filepath.Dir(filepath.Join(dir, name)), 0700))
+		assert.NoError(t, ioutil.WriteFile(filepath.Join(dir, name), []byte(content), 0600))
+	}
+
+	templateDir, err := ioutil.TempDir("", "pulumi-template-test")
+	assert.NoError(t, err)
+	defer os.RemoveAll(templateDir)
+	writeFile(templateDir, pulumiTemplateManifestFile, "name: test")
+	writeFile(templateDir, "{{ .Project }}.txt", "{{ .Description }}: {{ .Variables.greeting }} (${PROJECT})")
+	writeFile(templateDir, "docker/Dockerfile", "FROM scratch")
+	writeFile(templateDir, "extra.txt", "extra")
+
+	template := Template{
+		Name:        "test",
+		Dir:         templateDir,
+		GoTemplates: true,
+		Files: []TemplateFile{
+			{Path: "docker", If: "{{ eq .Variables.docker \"yes\" }}"},
+			{Path: "*.txt", If: "true"},
+		},
+	}
+	values := TemplateValues{
+		Project:     "hello",
+		Description: "A greeting",
+		Variables:   map[string]string{"greeting": "hi", "docker": "no"},
+	}
+
+	destDir, err := ioutil.TempDir("", "pulumi-template-test")
+	assert.NoError(t, err)
+	defer os.RemoveAll(destDir)
+	assert.NoError(t, template.CopyTemplateFilesDryRun(destDir, values))
+	assert.NoError(t, template.CopyTemplateFiles(destDir, false, values))
+
+	b, err := ioutil.ReadFile(filepath.Join(destDir, "hello.txt"))
+	assert.NoError(t, err)
+	assert.Equal(t, "A greeting: hi (hello)", string(b))
+	_, err = os.Stat(filepath.Join(destDir, "extra.txt"))
+	assert.NoError(t, err)
+	_, err = os.Stat(filepath.Join(destDir, "docker"))
+	assert.True(t, os.IsNotExist(err))
+	_, err = os.Stat(filepath.Join(destDir, pulumiTemplateManifestFile))
+	assert.True(t, os.IsNotExist(err))
+
+	// A second copy would overwrite the expanded file names.
+	assert.Error(t, template.CopyTemplateFilesDryRun(destDir, values))
+
+	// Referencing an undeclared variable is an error.
+	values.Variables = map[string]string{"docker": "no"}
+	assert.Error(t, template.CopyTemplateFiles(destDir, true, values))
+
+	// Names that expand to a path outside of the destination directory are rejected.
+	escapeDir, err := ioutil.TempDir("", "pulumi-template-test")
+	assert.NoError(t, err)
+	defer os.RemoveAll(escapeDir)
+	writeFile(escapeDir, "{{ .Project }}/escaped.txt", "x")
+	template = Template{Name: "test", Dir: escapeDir, GoTemplates: true}
+	for _, name := range []string{"", ".", "..", "../escaped"} {
+		values.Project = name
+		if err = template.CopyTemplateFiles(destDir, true, values); assert.Error(t, err, "project name %q", name) {
+			assert.Contains(t, err.Error(), "not a valid file name")
+		}
+	}
+	_, err = os.Stat(filepath.Join(filepath.Dir(destDir), "escaped.txt"))
+	assert.True(t, os.IsNotExist(err))
+}
+
+func TestCopyTemplateFilesRejectsLinks(t *testing.T) {
+	templateDir, err := ioutil.TempDir("", "pulumi-template-test")
+	assert.NoError(t, err)
+	defer os.RemoveAll(templateDir)
+	assert.NoError(t, ioutil.WriteFile(filepath.Join(templateDir, "main.txt"), []byte("main"), 0600))
+	secret := filepath.Join(templateDir, pulumiTemplateManifestFile)
+	assert.NoError(t, ioutil.WriteFile(secret, []byte("name: test"), 0600))
+	if err = os.Symlink(secret, filepath.Join(templateDir, "secret.txt")); err != nil {
+		t.Skipf("symbolic links are not supported: %v", err)
+	}
+
+	template := Template{Name: "test", Dir: templateDir}
+	destDir, err := ioutil.TempDir("", "pulumi-template-test")
+	assert.NoError(t, err)
+	defer os.RemoveAll(destDir)
+	assert.Error(t, template.CopyTemplateFilesDryRun(destDir, TemplateValues{}))
+	assert.Error(t, template.CopyTemplateFiles(destDir, false, TemplateValues{}))
+	_, err = os.Stat(filepath.Join(destDir, "secret.txt"))
+	assert.True(t, os.IsNotExist(err))
+}
+
+// tarEntry is an entry in a test tarball.  Entries without contents and whose names end in `/` are directories.
+type tarEntry struct {
+	name     string
+	contents string
+	typeflag byte
+}
+
+// writeTestTarball writes a gzipped tarball containing the given entries to a temporary file and returns its path.
+func writeTestTarball(t *testing.T, entries ...tarEntry) string {
+	f, err := ioutil.TempFile("", "pulumi-template-test")
+	assert.NoError(t, err)
+	defer contract.IgnoreClose(f)
+
+	gzw := gzip.NewWriter(f)
+	tw := tar.NewWriter(gzw)
+	for _, entry := range entries {
+		header := &tar.Header{Name: entry.name, Mode: 0600, Size: int64(len(entry.contents)), Typeflag: entry.typeflag}
+		switch {
+		case entry.typeflag == tar.TypeXGlobalHeader:
+			header = &tar.Header{Name: entry.name, Typeflag: entry.typeflag,
+				PAXRecords: map[string]string{"comment": "0123456789abcdef"}}
+		case entry.typeflag == tar.TypeSymlink:
+			header.Size, header.Linkname = 0, entry.contents
+		case strings.HasSuffix(entry.name, "/"):
+			header.Typeflag, header.Mode = tar.TypeDir, 0700
+		case entry.typeflag == 0:
+			header.Typeflag = tar.TypeReg
+		}
+		assert.NoError(t, tw.WriteHeader(header))
+		if header.Typeflag == tar.TypeReg {
+			_, err = tw.Write([]byte(entry.contents))
+			assert.NoError(t, err)
+		}
+	}
+	assert.NoError(t, tw.Close())
+	assert.NoError(t, gzw.Close())
+	return f.Name()
+}
+
+func TestExtractTarball(t *testing.T) {
+	extract := func(entries ...tarEntry) (string, error) {
+		tarball := writeTestTarball(t, entries...)
+		defer os.Remove(tarball)
+
+		f, err := os.Open(tarball)
+		assert.NoError(t, err)
+		dir, err := ioutil.TempDir("", "pulumi-template-test")
+		assert.NoError(t, err)
+		return dir, extractTarball(f, filepath.Join(dir, "dest"))
+	}
+
+	// Files are extracted even without entries for their directories, and pax global headers are skipped.
+	dir, err := extract(
+		tarEntry{name: "pax_global_header", typeflag: tar.TypeXGlobalHeader},
+		tarEntry{name: "a/b/c.txt", contents: "c"},
+		tarEntry{name: "d/"},
+		tarEntry{name: "d/e.txt", contents: "e"})
+	if assert.NoError(t, err) {
+		b, readErr := ioutil.ReadFile(filepath.Join(dir, "dest", "a", "b", "c.txt"))
+		assert.NoError(t, readErr)
+		assert.Equal(t, "c", string(b))
+		b, readErr = ioutil.ReadFile(filepath.Join(dir, "dest", "d", "e.txt"))
+		assert.NoError(t, readErr)
+		assert.Equal(t, "e", string(b))
+	}
+	assert.NoError(t, os.RemoveAll(dir))
+
+	// Entries that would escape the destination directory are rejected.
+	dir, err = extract(tarEntry{name: "../escaped.txt", contents: "x"})
+	assert.Error(t, err)
+	_, statErr := os.Stat(filepath.Join(dir, "escaped.txt"))
+	assert.True(t, os.IsNotExist(statErr))
+	assert.NoError(t, os.RemoveAll(dir))
+
+	dir, err = extract(tarEntry{name: "a/../../../escaped.txt", contents: "x"})
+	assert.Error(t, err)
+	assert.NoError(t, os.RemoveAll(dir))
+
+	// Links are rejected.
+	dir, err = extract(tarEntry{name: "link", contents: "/etc/passwd", typeflag: tar.TypeSymlink})
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), "link")
+	}
+	assert.NoError(t, os.RemoveAll(dir))
+}
+
+func TestRetrieveTemplateTarball(t *testing.T) {
+	tarball := writeTestTarball(t,
+		tarEntry{name: "pax_global_header", typeflag: tar.TypeXGlobalHeader},
+		tarEntry{name: "repo-1.0/templates/example/" + pulumiTemplateManifestFile, contents: "description: An example\n"},
+		tarEntry{name: "repo-1.0/templates/example/index.js", contents: "// example\n"})
+	defer os.Remove(tarball)
+
+	retrieve := func(subdir string) (Template, error) {
+		root, err := ioutil.TempDir("", "pulumi-template-test")
+		assert.NoError(t, err)
+		defer os.RemoveAll(root)
+		return retrieveTemplate(templateSource{Kind: tarballTemplateSource, Location: tarball, Subdir: subdir}, root)
+	}
+
+	template, err := retrieve("repo-1.0/templates/example")
+	if assert.NoError(t, err) {
+		assert.Equal(t, "example", template.Name)
+		assert.Equal(t, "An example", template.Description)
+	}
+
+	// Subdirectories must refer to a template directory within the tarball.
+	_, err = retrieve("repo-1.0/templates/missing")
+	assert.Error(t, err)
+	_, err = retrieve("../..")
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), "outside of the template")
+	}
+}
+
+func TestFindTemplateDirRejectsLinksOutside(t *testing.T) {
+	root, err := ioutil.TempDir("", "pulumi-template-test")
+	assert.NoError(t, err)
+	defer os.RemoveAll(root)
+	outside, err := ioutil.TempDir("", "pulumi-template-test")
+	assert.NoError(t, err)
+	defer os.RemoveAll(outside)
+
+	assert.NoError(t, os.MkdirAll(filepath.Join(root, "templates", "example"), 0700))
+	if err = os.Symlink(outside, filepath.Join(root, "escape")); err != nil {
+		t.Skipf("symbolic links are not supported: %v", err)
+	}
+	assert.NoError(t, os.Symlink(filepath.Join(root, "templates"), filepath.Join(root, "inside")))
+
+	find := func(subdir string) (string, error) {
+		return findTemplateDir(templateSource{Kind: gitTemplateSource, Location: "repo", Subdir: subdir}, root)
+	}
+
+	dir, err := find("templates/example")
+	if assert.NoError(t, err) {
+		assert.Equal(t, filepath.Join(root, "templates", "example"), dir)
+	}
+
+	// Links within the repository are followed, but those that lead outside of it are refused, whether they are the
+	// template directory itself or one of its parents.
+	_, err = find("inside/example")
+	assert.NoError(t, err)
+	assert.NoError(t, os.MkdirAll(filepath.Join(outside, "nested"), 0700))
+	for _, subdir := range []string{"escape", "escape/nested"} {
+		_, err = find(subdir)
+		if assert.Error(t, err) {
+			assert.Contains(t, err.Error(), "outside of the template")
+		}
+	}
+}
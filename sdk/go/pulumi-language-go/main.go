@@ -15,22 +15,35 @@
 package main
 
 import (
+	"bytes"
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"encoding/json"
 	"flag"
 	"fmt"
+	"io"
+	"io/ioutil"
 	"os"
 	"os/exec"
+	"os/user"
+	"path/filepath"
+	"runtime"
+	"sort"
+	"strings"
 	"syscall"
+	"time"
 
 	pbempty "github.com/golang/protobuf/ptypes/empty"
 	"github.com/pkg/errors"
 	"google.golang.org/grpc"
 
 	"github.com/pulumi/pulumi/pkg/util/cmdutil"
+	"github.com/pulumi/pulumi/pkg/util/contract"
 	"github.com/pulumi/pulumi/pkg/util/logging"
 	"github.com/pulumi/pulumi/pkg/util/rpcutil"
 	"github.com/pulumi/pulumi/pkg/version"
+	"github.com/pulumi/pulumi/pkg/workspace"
 	"github.com/pulumi/pulumi/sdk/go/pulumi"
 	pulumirpc "github.com/pulumi/pulumi/sdk/proto/go"
 )
@@ -39,6 +52,8 @@ import (
 func main() {
 	var tracing string
 	flag.StringVar(&tracing, "tracing", "", "Emit tracing to a Zipkin-compatible tracing endpoint")
+	var binary string
+	flag.StringVar(&binary, "binary", "", "Run a prebuilt program binary instead of compiling the program")
 
 	flag.Parse()
 	args := flag.Args()
@@ -54,7 +69,7 @@ func main() {
 	// Fire up a gRPC server, letting the kernel choose a free port.
 	port, done, err := rpcutil.Serve(0, nil, []func(*grpc.Server) error{
 		func(srv *grpc.Server) error {
-			host := newLanguageHost(engineAddress, tracing)
+			host := newLanguageHost(engineAddress, tracing, binary)
 			pulumirpc.RegisterLanguageRuntimeServer(srv, host)
 			return nil
 		},
@@ -76,12 +91,14 @@ func main() {
 type goLanguageHost struct {
 	engineAddress string
 	tracing       string
+	binary        string
 }
 
-func newLanguageHost(engineAddress, tracing string) pulumirpc.LanguageRuntimeServer {
+func newLanguageHost(engineAddress, tracing, binary string) pulumirpc.LanguageRuntimeServer {
 	return &goLanguageHost{
 		engineAddress: engineAddress,
 		tracing:       tracing,
+		binary:        binary,
 	}
 }
 
@@ -100,14 +117,18 @@ func (host *goLanguageHost) Run(ctx context.Context, req *pulumirpc.RunRequest)
 		return nil, errors.Wrap(err, "failed to prepare environment")
 	}
 
-	// The program to execute is simply the name of the project.  This ensures good Go toolability, whereby
-	// you can simply run `go install .` to build a Pulumi program prior to running it, among other benefits.
-	program := req.GetProject()
-	logging.V(5).Infoln("language host launching process: %s", program)
+	// The program to execute is either a prebuilt binary, if the project specified one, or the result of compiling
+	// the program's sources.  A failure to compile is a user error, so it is reported just like a failed program.
+	program, err := host.findProgram(req)
+	if err != nil {
+		return &pulumirpc.RunResponse{Error: err.Error()}, nil
+	}
+	logging.V(5).Infof("language host launching process: %s", program)
 
 	// Now simply spawn a process to execute the requested program, wiring up stdout/stderr directly.
 	var errResult string
 	cmd := exec.Command(program) // nolint: gas, intentionally running dynamic program name.
+	cmd.Dir = req.GetPwd()
 	cmd.Env = env
 	cmd.Stdout = os.Stdout
 	cmd.Stderr = os.Stderr
@@ -132,6 +153,252 @@ func (host *goLanguageHost) Run(ctx context.Context, req *pulumirpc.RunRequest)
 	return &pulumirpc.RunResponse{Error: errResult}, nil
 }
 
+// findProgram returns the path of the binary to run for a RunRequest.  A prebuilt binary is resolved relative to the
+// program's working directory; otherwise, the program is compiled.
+func (host *goLanguageHost) findProgram(req *pulumirpc.RunRequest) (string, error) {
+	if host.binary != "" {
+		program := host.binary
+		if !filepath.IsAbs(program) {
+			program = filepath.Join(req.GetPwd(), program)
+		}
+		if _, err := os.Stat(program); err != nil {
+			return "", errors.Wrapf(err, "could not find prebuilt program binary")
+		}
+		return program, nil
+	}
+
+	return compileProgram(req.GetPwd(), req.GetProgram(), req.GetProject())
+}
+
+// compileProgram builds the Go program in pwd and returns the path of the resulting binary.  Binaries are cached by a
+// hash of the program's sources, so that previews and updates of an unchanged program share a single build.
+func compileProgram(pwd, program, project string) (string, error) {
+	gobin, err := exec.LookPath("go")
+	if err != nil {
+		return "", errors.Wrap(err, "locating `go` binary (it is required unless a prebuilt binary is specified)")
+	}
+
+	hash, err := hashProgram(gobin, pwd, program)
+	if err != nil {
+		return "", errors.Wrapf(err, "hashing program sources")
+	}
+	cacheDir, err := getBuildCacheDir()
+	if err != nil {
+		return "", err
+	}
+
+	binary := filepath.Join(cacheDir, hash, project)
+	if runtime.GOOS == "windows" {
+		binary += ".exe"
+	}
+	if _, err = os.Stat(binary); err == nil {
+		logging.V(5).Infof("language host reusing cached program: %s", binary)
+
+		// Mark the build as recently used, so that pruning keeps it.
+		now := time.Now()
+		contract.IgnoreError(os.Chtimes(binary, now, now))
+		return binary, nil
+	}
+
+	// Build into a temporary file and then move it into place, so that concurrent runs never see a partial binary.
+	// nolint: gas, gas prefers 0700 for a directory, but 0755 (so group and world can read it) is what we prefer
+	if err = os.MkdirAll(filepath.Dir(binary), 0755); err != nil {
+		return "", errors.Wrapf(err, "creating program build cache")
+	}
+	temp := fmt.Sprintf("%s.%d.tmp", binary, os.Getpid())
+	logging.V(5).Infof("language host compiling program %s to %s", program, binary)
+
+	cmd := exec.Command(gobin, "build", "-o", temp, program) // nolint: gas, intentionally running dynamic program.
+	cmd.Dir = pwd
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	if err = cmd.Run(); err != nil {
+		contract.IgnoreError(os.Remove(temp))
+		return "", errors.Wrapf(err, "failed to compile program")
+	}
+	if err = os.Rename(temp, binary); err != nil {
+		contract.IgnoreError(os.Remove(temp))
+
+		// On Windows, renaming onto an existing file fails.  If a concurrent run cached the same build first, use it.
+		if _, staterr := os.Stat(binary); staterr == nil {
+			return binary, nil
+		}
+		return "", errors.Wrapf(err, "caching compiled program")
+	}
+
+	pruneBuildCache(cacheDir, hash, filepath.Base(binary))
+	return binary, nil
+}
+
+// maxCachedBuilds is the number of builds of each project that are kept in the build cache.
+const maxCachedBuilds = 3
+
+// pruneBuildCache removes all but the most recently used builds of a project from the build cache, always keeping the
+// build with the given hash.  Builds are identified by the name of their binary, which is the project's name.  Errors
+// are ignored, as a build that can't be removed now (e.g. because it is running) will be removed by a later prune.
+func pruneBuildCache(cacheDir, keep, binaryName string) {
+	entries, err := ioutil.ReadDir(cacheDir)
+	if err != nil {
+		logging.V(5).Infof("language host could not read build cache: %v", err)
+		return
+	}
+
+	type build struct {
+		dir     string
+		modTime time.Time
+	}
+	var builds []build
+	for _, entry := range entries {
+		if !entry.IsDir() || entry.Name() == keep {
+			continue
+		}
+		dir := filepath.Join(cacheDir, entry.Name())
+		info, err := os.Stat(filepath.Join(dir, binaryName))
+		if err != nil {
+			continue // a build of another project, or one still in progress.
+		}
+		builds = append(builds, build{dir: dir, modTime: info.ModTime()})
+	}
+
+	// The build being kept counts towards the limit.
+	if len(builds) < maxCachedBuilds {
+		return
+	}
+	sort.Slice(builds, func(i, j int) bool {
+		return builds[i].modTime.After(builds[j].modTime)
+	})
+	for _, b := range builds[maxCachedBuilds-1:] {
+		logging.V(5).Infof("language host removing stale cached program: %s", b.dir)
+		contract.IgnoreError(os.RemoveAll(b.dir))
+	}
+}
+
+// hashProgram computes a hash of everything that goes into building the Go program in pwd: its Go sources, its module
+// and dependency manifests, the contents of any vendor directories, the sources of every other package it depends on,
+// and the Go toolchain and environment that build it.
+func hashProgram(gobin, pwd, program string) (string, error) {
+	h := sha256.New()
+	fmt.Fprintf(h, "%s\x00", program)
+
+	// The toolchain's version and environment select the compiler, the build target, and where dependencies come from.
+	// GOGCCFLAGS is left out, as it names a temporary directory that differs every time.
+	for _, args := range [][]string{{"version"}, {"env"}} {
+		out, err := runGo(gobin, pwd, args...)
+		if err != nil {
+			return "", err
+		}
+		for _, line := range strings.Split(string(out), "\n") {
+			if !strings.Contains(line, "GOGCCFLAGS=") {
+				fmt.Fprintf(h, "%s\n", line)
+			}
+		}
+	}
+
+	err := filepath.Walk(pwd, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+
+		rel, err := filepath.Rel(pwd, path)
+		if err != nil {
+			return err
+		}
+		if info.IsDir() {
+			// Skip hidden directories, such as .git, which can be large and never contain sources.
+			if rel != "." && strings.HasPrefix(info.Name(), ".") {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if !isProgramSource(rel) {
+			return nil
+		}
+
+		fmt.Fprintf(h, "%s\x00", filepath.ToSlash(rel))
+		return hashFile(h, path)
+	})
+	if err != nil {
+		return "", err
+	}
+
+	// Dependencies outside of pwd, such as those in GOPATH or the module cache, are hashed by the packages' files.
+	out, err := runGo(gobin, pwd, "list", "-deps", "-f", "{{if not .Standard}}{{.Dir}}{{end}}", program)
+	if err != nil {
+		return "", err
+	}
+	for _, dir := range strings.Split(string(out), "\n") {
+		if dir == "" {
+			continue
+		}
+		if rel, relerr := filepath.Rel(pwd, dir); relerr == nil && !strings.HasPrefix(rel, "..") {
+			continue // this package's sources were hashed above.
+		}
+		files, err := ioutil.ReadDir(dir)
+		if err != nil {
+			return "", err
+		}
+		for _, file := range files {
+			if file.Mode().IsRegular() {
+				path := filepath.Join(dir, file.Name())
+				fmt.Fprintf(h, "%s\x00", filepath.ToSlash(path))
+				if err = hashFile(h, path); err != nil {
+					return "", err
+				}
+			}
+		}
+	}
+
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// hashFile writes the contents of the file at the given path to a hash.
+func hashFile(h io.Writer, path string) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer contract.IgnoreClose(f)
+
+	_, err = io.Copy(h, f)
+	return err
+}
+
+// runGo runs the go tool with the given arguments in dir and returns its output.
+func runGo(gobin, dir string, args ...string) ([]byte, error) {
+	cmd := exec.Command(gobin, args...) // nolint: gas, intentionally running the go tool.
+	cmd.Dir = dir
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	out, err := cmd.Output()
+	if err != nil {
+		return nil, errors.Wrapf(err, "running `go %s`: %s", strings.Join(args, " "), strings.TrimSpace(stderr.String()))
+	}
+	return out, nil
+}
+
+// isProgramSource returns true if the file at the given path, relative to the program's directory, affects the build.
+func isProgramSource(rel string) bool {
+	switch filepath.Base(rel) {
+	case "go.mod", "go.sum", "Gopkg.lock":
+		return true
+	}
+	for _, dir := range strings.Split(filepath.ToSlash(filepath.Dir(rel)), "/") {
+		if dir == "vendor" {
+			return true
+		}
+	}
+	return filepath.Ext(rel) == ".go"
+}
+
+// getBuildCacheDir returns the directory in which compiled programs are cached.
+func getBuildCacheDir() (string, error) {
+	u, err := user.Current()
+	if u == nil || err != nil {
+		return "", errors.Wrapf(err, "getting user home directory")
+	}
+	return filepath.Join(u.HomeDir, workspace.BookkeepingDir, "go-build"), nil
+}
+
 // constructEnv constructs an environment for a Go progam by enumerating all of the optional and non-optional
 // arguments present in a RunRequest.
 func (host *goLanguageHost) constructEnv(req *pulumirpc.RunRequest) ([]string, error) {
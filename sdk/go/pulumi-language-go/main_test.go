@@ -0,0 +1,129 @@
+// Copyright 2016-2018, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIsProgramSource(t *testing.T) {
+	assert.True(t, isProgramSource("main.go"))
+	assert.True(t, isProgramSource(filepath.Join("pkg", "util.go")))
+	assert.True(t, isProgramSource("go.mod"))
+	assert.True(t, isProgramSource("go.sum"))
+	assert.True(t, isProgramSource("Gopkg.lock"))
+	assert.True(t, isProgramSource(filepath.Join("vendor", "github.com", "foo", "asm.s")))
+	assert.False(t, isProgramSource("Pulumi.yaml"))
+	assert.False(t, isProgramSource("README.md"))
+}
+
+func TestHashProgram(t *testing.T) {
+	gobin, err := exec.LookPath("go")
+	if err != nil {
+		t.Skip("the go tool is not available")
+	}
+	root, err := ioutil.TempDir("", "pulumi-language-go-test")
+	if !assert.NoError(t, err) {
+		return
+	}
+	defer os.RemoveAll(root)
+	dir := filepath.Join(root, "program")
+
+	write := func(name, contents string) {
+		path := filepath.Join(dir, name)
+		assert.NoError(t, os.MkdirAll(filepath.Dir(path), 0755))
+		assert.NoError(t, ioutil.WriteFile(path, []byte(contents), 0644))
+	}
+	hash := func() string {
+		h, err := hashProgram(gobin, dir, ".")
+		assert.NoError(t, err)
+		return h
+	}
+
+	write("main.go", "package main\n")
+	write("go.mod", "module test\n\ngo 1.11\n")
+	base := hash()
+	assert.Equal(t, base, hash())
+
+	// Files that don't affect the build, including those in hidden directories, don't change the hash.
+	write("Pulumi.yaml", "name: test\n")
+	write(filepath.Join(".idea", "workspace.xml"), "<project/>\n")
+	write(filepath.Join(".cache", "stale.go"), "package stale\n")
+	assert.Equal(t, base, hash())
+
+	// Sources, manifests, and vendored files do.
+	write("main.go", "package main\n\nfunc main() {}\n")
+	withSource := hash()
+	assert.NotEqual(t, base, withSource)
+	write("go.sum", "\n")
+	withSum := hash()
+	assert.NotEqual(t, withSource, withSum)
+	write(filepath.Join("vendor", "foo", "foo.go"), "package foo\n")
+	assert.NotEqual(t, withSum, hash())
+
+	// So do the sources of dependencies that live outside of the program's directory.
+	write(filepath.Join("..", "dep", "go.mod"), "module example.com/dep\n\ngo 1.11\n")
+	write(filepath.Join("..", "dep", "dep.go"), "package dep\n")
+	write("go.mod", "module test\n\ngo 1.11\n\nrequire example.com/dep v0.0.0\n\nreplace example.com/dep => ../dep\n")
+	write("main.go", "package main\n\nimport _ \"example.com/dep\"\n\nfunc main() {}\n")
+	assert.NoError(t, os.RemoveAll(filepath.Join(dir, "vendor")))
+	withDep := hash()
+	write(filepath.Join("..", "dep", "dep.go"), "package dep\n\nvar X = 1\n")
+	assert.NotEqual(t, withDep, hash())
+}
+
+func TestPruneBuildCache(t *testing.T) {
+	cacheDir, err := ioutil.TempDir("", "pulumi-language-go-test")
+	if !assert.NoError(t, err) {
+		return
+	}
+	defer os.RemoveAll(cacheDir)
+
+	// Five builds of the project, each used an hour after the last, and one of another project.
+	now := time.Now()
+	build := func(hash, binaryName string, age time.Duration) {
+		path := filepath.Join(cacheDir, hash, binaryName)
+		assert.NoError(t, os.MkdirAll(filepath.Dir(path), 0755))
+		assert.NoError(t, ioutil.WriteFile(path, nil, 0755))
+		assert.NoError(t, os.Chtimes(path, now.Add(-age), now.Add(-age)))
+	}
+	for i, hash := range []string{"a", "b", "c", "d", "e"} {
+		build(hash, "proj", time.Duration(i)*time.Hour)
+	}
+	build("other", "other-proj", 24*time.Hour)
+
+	// Pruning after building the oldest keeps it, along with the most recently used of the others.
+	pruneBuildCache(cacheDir, "e", "proj")
+	entries, err := ioutil.ReadDir(cacheDir)
+	assert.NoError(t, err)
+	var remaining []string
+	for _, entry := range entries {
+		remaining = append(remaining, entry.Name())
+	}
+	assert.Equal(t, []string{"a", "b", "e", "other"}, remaining)
+
+	// Pruning again doesn't remove anything else.
+	pruneBuildCache(cacheDir, "e", "proj")
+	entries, err = ioutil.ReadDir(cacheDir)
+	assert.NoError(t, err)
+	assert.Len(t, entries, 4)
+}
@@ -41,6 +41,10 @@ type Context struct {
 	rpcs        int         // the number of outstanding RPC requests.
 	rpcsDone    *sync.Cond  // an event signaling completion of RPCs.
 	rpcsLock    *sync.Mutex // a lock protecting the RPC count and event.
+
+	stackTransformations []ResourceTransformation // transformations applied to every resource.
+	inherited            map[URN]inheritedOpts    // the options each parent passes down to its children.
+	inheritedLock        sync.Mutex               // a lock protecting the transformations and inherited options.
 }
 
 // NewContext creates a fresh run context out of the given metadata.
@@ -80,6 +84,8 @@ func NewContext(ctx context.Context, info RunInfo) (*Context, error) {
 		rpcs:        0,
 		rpcsLock:    mutex,
 		rpcsDone:    sync.NewCond(mutex),
+
+		inherited: make(map[URN]inheritedOpts),
 	}, nil
 }
 
@@ -116,30 +122,108 @@ func (ctx *Context) GetConfig(key string) (string, bool) {
 	return v, ok
 }
 
+// RegisterStackTransformation adds a transformation that is applied to every resource registered after this call,
+// after any transformations given in the resource's own options or inherited from its parents.
+func (ctx *Context) RegisterStackTransformation(t ResourceTransformation) {
+	ctx.inheritedLock.Lock()
+	defer ctx.inheritedLock.Unlock()
+	ctx.stackTransformations = append(ctx.stackTransformations, t)
+}
+
+// InvokeOpt contains optional settings that control an invoke's behavior.
+type InvokeOpt struct {
+	// Parent is an optional resource on whose behalf the function is invoked.
+	Parent Resource
+	// Provider is an optional provider plugin package to invoke the function with; the token's package if empty.
+	Provider string
+	// Version is an optional version of the provider plugin to invoke the function with; the latest is used if empty.
+	Version string
+}
+
 // Invoke will invoke a provider's function, identified by its token tok.  This function call is synchronous.
-func (ctx *Context) Invoke(tok string, args map[string]interface{}) (map[string]interface{}, error) {
+func (ctx *Context) Invoke(tok string, args map[string]interface{},
+	opts ...InvokeOpt) (map[string]interface{}, error) {
 	if tok == "" {
 		return nil, errors.New("invoke token must not be empty")
 	}
 
+	// Note that we're about to make an outstanding RPC request, so that we can rendezvous during shutdown.
+	if err := ctx.beginRPC(); err != nil {
+		return nil, err
+	}
+	defer ctx.endRPC()
+
+	return ctx.invoke(tok, args, opts...)
+}
+
+// InvokeOutput invokes a provider's function, identified by its token tok, asynchronously.  The result resolves once
+// the arguments, which may contain outputs, are available and the function has returned, and it depends on all of the
+// resources that the arguments depend on.  During previews, if any of the arguments are unknown, the function is not
+// invoked at all and the result is unknown.
+func (ctx *Context) InvokeOutput(tok string, args map[string]interface{}, opts ...InvokeOpt) (*MapOutput, error) {
+	if tok == "" {
+		return nil, errors.New("invoke token must not be empty")
+	}
+
+	// Note that we're about to make an outstanding RPC request, so that we can rendezvous during shutdown.
+	if err := ctx.beginRPC(); err != nil {
+		return nil, err
+	}
+
+	result, resolve, reject := NewOutput(gatherDeps(args))
+	go func() {
+		defer ctx.endRPC()
+
+		// Await the arguments first, so that unknown ones can short-circuit the invoke during previews.
+		if _, known, err := awaitValue(args); err != nil {
+			reject(err)
+			return
+		} else if !known && ctx.DryRun() {
+			glog.V(9).Infof("InvokeOutput(%s, ...): skipped due to unknown arguments", tok)
+			resolve(nil, false)
+			return
+		}
+
+		outs, err := ctx.invoke(tok, args, opts...)
+		if err != nil {
+			reject(err)
+		} else {
+			resolve(outs, true)
+		}
+	}()
+	return (*MapOutput)(result), nil
+}
+
+// invoke performs the invoke RPC shared by Invoke and InvokeOutput, blocking until it has completed.
+func (ctx *Context) invoke(tok string, args map[string]interface{},
+	opts ...InvokeOpt) (map[string]interface{}, error) {
 	// Serialize arguments, first by awaiting them, and then marshaling them to the requisite gRPC values.
-	// TODO[pulumi/pulumi#1483]: feels like we should be propagating dependencies to the outputs, instead of ignoring.
 	_, rpcArgs, _, err := marshalInputs(args)
 	if err != nil {
 		return nil, errors.Wrap(err, "marshaling arguments")
 	}
 
-	// Note that we're about to make an outstanding RPC request, so that we can rendezvous during shutdown.
-	if err = ctx.beginRPC(); err != nil {
-		return nil, err
+	var parent, provider, version string
+	for _, opt := range opts {
+		if parent == "" && opt.Parent != nil {
+			parent = string(opt.Parent.URN())
+		}
+		if provider == "" {
+			provider = opt.Provider
+		}
+		if version == "" {
+			version = opt.Version
+		}
 	}
-	defer ctx.endRPC()
 
 	// Now, invoke the RPC to the provider synchronously.
 	glog.V(9).Infof("Invoke(%s, #args=%d): RPC call being made synchronously", tok, len(args))
 	resp, err := ctx.monitor.Invoke(ctx.ctx, &pulumirpc.InvokeRequest{
-		Tok:  tok,
-		Args: rpcArgs,
+		Tok:      tok,
+		Args:     rpcArgs,
+		Version:  version,
+		Parent:   parent,
+		Provider: provider,
 	})
 	if err != nil {
 		glog.V(9).Infof("Invoke(%s, ...): error: %v", tok, err)
@@ -190,10 +274,13 @@ func (ctx *Context) ReadResource(
 	go func() {
 		glog.V(9).Infof("ReadResource(%s, %s): Goroutine spawned, RPC call being made", t, name)
 		resp, err := ctx.monitor.ReadResource(ctx.ctx, &pulumirpc.ReadResourceRequest{
-			Type:       t,
-			Name:       name,
-			Parent:     op.parent,
-			Properties: op.rpcProps,
+			Id:           string(id),
+			Type:         t,
+			Name:         name,
+			Parent:       op.parent,
+			Properties:   op.rpcProps,
+			Dependencies: op.deps,
+			Retries:      int32(op.retries),
 		})
 		if err != nil {
 			glog.V(9).Infof("RegisterResource(%s, %s): error: %v", t, name, err)
@@ -236,11 +323,15 @@ func (ctx *Context) RegisterResource(
 		return nil, errors.New("resource name argument (for URN creation) cannot be empty")
 	}
 
+	// Inherit options from the resource's parent, and give any transformations a chance to rewrite them.
+	props, opt, inherited := ctx.prepareResource(t, name, custom, props, opts...)
+
 	// Prepare the inputs for an impending operation.
-	op, err := ctx.newResourceOperation(custom, props, opts...)
+	op, err := ctx.newResourceOperation(custom, props, opt)
 	if err != nil {
 		return nil, err
 	}
+	op.inherited = inherited
 
 	// Note that we're about to make an outstanding RPC request, so that we can rendezvous during shutdown.
 	if err = ctx.beginRPC(); err != nil {
@@ -252,13 +343,17 @@ func (ctx *Context) RegisterResource(
 	go func() {
 		glog.V(9).Infof("RegisterResource(%s, %s): Goroutine spawned, RPC call being made", t, name)
 		resp, err := ctx.monitor.RegisterResource(ctx.ctx, &pulumirpc.RegisterResourceRequest{
-			Type:         t,
-			Name:         name,
-			Parent:       op.parent,
-			Object:       op.rpcProps,
-			Custom:       custom,
-			Protect:      op.protect,
-			Dependencies: op.deps,
+			Type:                t,
+			Name:                name,
+			Parent:              op.parent,
+			Object:              op.rpcProps,
+			Custom:              custom,
+			Protect:             op.protect,
+			Dependencies:        op.deps,
+			Retries:             int32(op.retries),
+			DeleteBeforeReplace: op.deleteBeforeReplace,
+			RetainOnDelete:      op.retainOnDelete,
+			ReplaceOnChanges:    op.replaceOnChanges,
 		})
 		if err != nil {
 			glog.V(9).Infof("RegisterResource(%s, %s): error: %v", t, name, err)
@@ -294,17 +389,38 @@ func (ctx *Context) RegisterResource(
 	}, nil
 }
 
+// RegisterComponentResource registers a new component resource, awaiting its URN so that it may immediately be used
+// as the parent of its children.  t is the fully qualified type token and name is the "name" part to use in creating
+// a stable and globally unique URN for the component.
+func (ctx *Context) RegisterComponentResource(
+	t, name string, opts ...ResourceOpt) (*ComponentResourceState, error) {
+	res, err := ctx.RegisterResource(t, name, false, nil, opts...)
+	if err != nil {
+		return nil, err
+	}
+	urn, err := res.URN.Value()
+	if err != nil {
+		return nil, err
+	}
+	return &ComponentResourceState{ctx: ctx, urn: urn, outputs: make(map[string]interface{})}, nil
+}
+
 // resourceOperation reflects all of the inputs necessary to perform core resource RPC operations.
 type resourceOperation struct {
-	ctx      *Context
-	parent   string
-	deps     []string
-	protect  bool
-	props    map[string]interface{}
-	rpcProps *structpb.Struct
-	outURN   *resourceOutput
-	outID    *resourceOutput
-	outState map[string]*resourceOutput
+	ctx                 *Context
+	parent              string
+	deps                []string
+	protect             bool
+	retries             int
+	deleteBeforeReplace bool
+	retainOnDelete      bool
+	replaceOnChanges    []string
+	inherited           inheritedOpts
+	props               map[string]interface{}
+	rpcProps            *structpb.Struct
+	outURN              *resourceOutput
+	outID               *resourceOutput
+	outState            map[string]*resourceOutput
 }
 
 // newResourceOperation prepares the inputs for a resource operation, shared between read and register.
@@ -352,15 +468,19 @@ func (ctx *Context) newResourceOperation(custom bool, props map[string]interface
 	}
 
 	return &resourceOperation{
-		ctx:      ctx,
-		parent:   string(parent),
-		deps:     deps,
-		protect:  protect,
-		props:    props,
-		rpcProps: rpcProps,
-		outURN:   urn,
-		outID:    id,
-		outState: state,
+		ctx:                 ctx,
+		parent:              string(parent),
+		deps:                deps,
+		protect:             protect,
+		retries:             ctx.getOptsRetries(opts...),
+		deleteBeforeReplace: ctx.getOptsDeleteBeforeReplace(opts...),
+		retainOnDelete:      ctx.getOptsRetainOnDelete(opts...),
+		replaceOnChanges:    ctx.getOptsReplaceOnChanges(opts...),
+		props:               props,
+		rpcProps:            rpcProps,
+		outURN:              urn,
+		outID:               id,
+		outState:            state,
 	}, nil
 }
 
@@ -380,6 +500,13 @@ func (op *resourceOperation) complete(err error, urn string, id string, result *
 			s.reject(err)
 		}
 	} else {
+		// Record the options this resource's children will inherit before anyone can observe its URN.
+		if op.inherited.protect || len(op.inherited.transformations) > 0 {
+			op.ctx.inheritedLock.Lock()
+			op.ctx.inherited[URN(urn)] = op.inherited
+			op.ctx.inheritedLock.Unlock()
+		}
+
 		// Resolve the URN and ID.
 		op.outURN.resolve(URN(urn), true)
 		if op.outID != nil {
@@ -415,6 +542,36 @@ func (op *resourceOperation) complete(err error, urn string, id string, result *
 	}
 }
 
+// inheritedOpts are the options that a resource passes down to the resources created with it as their parent.
+type inheritedOpts struct {
+	protect         bool                     // true if children are protected.
+	transformations []ResourceTransformation // the transformations applied to children.
+}
+
+// prepareResource merges a resource's options and fills in those it inherits from its parent.  It then runs the
+// resource's own transformations, followed by those inherited from its parents and those registered for the whole
+// stack, over its properties and options.  It returns the resulting properties and options, along with the options
+// that the resource's children should inherit.
+func (ctx *Context) prepareResource(t, name string, custom bool, props map[string]interface{},
+	opts ...ResourceOpt) (map[string]interface{}, ResourceOpt, inheritedOpts) {
+	opt := mergeOpts(opts...)
+
+	ctx.inheritedLock.Lock()
+	parent := ctx.inherited[ctx.getOptsParentURN(opt)]
+	transformations := append(append([]ResourceTransformation{}, opt.Transformations...), parent.transformations...)
+	all := append(append([]ResourceTransformation{}, transformations...), ctx.stackTransformations...)
+	ctx.inheritedLock.Unlock()
+
+	opt.Protect = opt.Protect || parent.protect
+	for _, transform := range all {
+		res := transform(&ResourceTransformationArgs{Type: t, Name: name, Custom: custom, Props: props, Opts: opt})
+		if res != nil {
+			props, opt = res.Props, res.Opts
+		}
+	}
+	return props, opt, inheritedOpts{protect: opt.Protect, transformations: transformations}
+}
+
 type resourceOutput struct {
 	out     *Output
 	resolve func(interface{}, bool)
@@ -460,6 +617,47 @@ func (ctx *Context) getOptsProtect(opts ...ResourceOpt) bool {
 	return false
 }
 
+// getOptsDeleteBeforeReplace returns true if a resource's options indicate that it is to be deleted before it is
+// replaced.
+func (ctx *Context) getOptsDeleteBeforeReplace(opts ...ResourceOpt) bool {
+	for _, opt := range opts {
+		if opt.DeleteBeforeReplace {
+			return true
+		}
+	}
+	return false
+}
+
+// getOptsRetainOnDelete returns true if any of the resource's options ask that it be retained on deletion.
+func (ctx *Context) getOptsRetainOnDelete(opts ...ResourceOpt) bool {
+	for _, opt := range opts {
+		if opt.RetainOnDelete {
+			return true
+		}
+	}
+	return false
+}
+
+// getOptsReplaceOnChanges returns the property paths whose changes force replacement, gathered from all of a
+// resource's options.
+func (ctx *Context) getOptsReplaceOnChanges(opts ...ResourceOpt) []string {
+	var paths []string
+	for _, opt := range opts {
+		paths = append(paths, opt.ReplaceOnChanges...)
+	}
+	return paths
+}
+
+// getOptsRetries returns the number of times to retry transient failures given a resource's options, if any.
+func (ctx *Context) getOptsRetries(opts ...ResourceOpt) int {
+	for _, opt := range opts {
+		if opt.Retries != 0 {
+			return opt.Retries
+		}
+	}
+	return 0
+}
+
 // noMoreRPCs is a sentinel value used to stop subsequent RPCs from occurring.
 const noMoreRPCs = -1
 
@@ -515,8 +713,26 @@ type ResourceState struct {
 	State Outputs
 }
 
-// RegisterResourceOutputs completes the resource registration, attaching an optional set of computed outputs.
+// RegisterResourceOutputs completes the resource registration, attaching an optional set of computed outputs.  This
+// call is synchronous, awaiting any outputs among the values before sending them to the resource monitor.
 func (ctx *Context) RegisterResourceOutputs(urn URN, outs map[string]interface{}) error {
+	if urn == "" {
+		return errors.New("resource URN argument cannot be empty")
+	}
+
+	_, rpcOuts, _, err := marshalInputs(outs)
+	if err != nil {
+		return errors.Wrap(err, "marshaling outputs")
+	}
+
+	glog.V(9).Infof("RegisterResourceOutputs(%s, #outs=%d): RPC call being made synchronously", urn, len(outs))
+	if _, err = ctx.monitor.RegisterResourceOutputs(ctx.ctx, &pulumirpc.RegisterResourceOutputsRequest{
+		Urn:     string(urn),
+		Outputs: rpcOuts,
+	}); err != nil {
+		glog.V(9).Infof("RegisterResourceOutputs(%s, ...): error: %v", urn, err)
+		return err
+	}
 	return nil
 }
 
@@ -0,0 +1,222 @@
+// Copyright 2016-2018, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pulumi
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/golang/protobuf/ptypes/empty"
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc"
+
+	pulumirpc "github.com/pulumi/pulumi/sdk/proto/go"
+)
+
+// testMonitor is a resource monitor that records registrations and assigns each resource a URN based on its name.
+type testMonitor struct {
+	lock      sync.Mutex
+	registers map[string]*pulumirpc.RegisterResourceRequest
+	outputs   map[string]*pulumirpc.RegisterResourceOutputsRequest
+	reads     map[string]*pulumirpc.ReadResourceRequest
+	invokes   []*pulumirpc.InvokeRequest
+}
+
+func (m *testMonitor) Invoke(ctx context.Context, in *pulumirpc.InvokeRequest,
+	opts ...grpc.CallOption) (*pulumirpc.InvokeResponse, error) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	m.invokes = append(m.invokes, in)
+	return &pulumirpc.InvokeResponse{Return: in.Args}, nil
+}
+
+func (m *testMonitor) ReadResource(ctx context.Context, in *pulumirpc.ReadResourceRequest,
+	opts ...grpc.CallOption) (*pulumirpc.ReadResourceResponse, error) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	m.reads[in.Name] = in
+	return &pulumirpc.ReadResourceResponse{Urn: "urn:" + in.Name, Properties: in.Properties}, nil
+}
+
+func (m *testMonitor) RegisterResource(ctx context.Context, in *pulumirpc.RegisterResourceRequest,
+	opts ...grpc.CallOption) (*pulumirpc.RegisterResourceResponse, error) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	m.registers[in.Name] = in
+	return &pulumirpc.RegisterResourceResponse{Urn: "urn:" + in.Name, Id: in.Name, Object: in.Object}, nil
+}
+
+func (m *testMonitor) RegisterResourceOutputs(ctx context.Context, in *pulumirpc.RegisterResourceOutputsRequest,
+	opts ...grpc.CallOption) (*empty.Empty, error) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	m.outputs[in.Urn] = in
+	return &empty.Empty{}, nil
+}
+
+func newTestContext(t *testing.T) (*Context, *testMonitor) {
+	ctx, err := NewContext(context.Background(), RunInfo{Project: "proj", Stack: "stack"})
+	assert.NoError(t, err)
+	monitor := &testMonitor{
+		registers: make(map[string]*pulumirpc.RegisterResourceRequest),
+		outputs:   make(map[string]*pulumirpc.RegisterResourceOutputsRequest),
+		reads:     make(map[string]*pulumirpc.ReadResourceRequest),
+	}
+	ctx.monitor = monitor
+	return ctx, monitor
+}
+
+type testResource struct {
+	urn URN
+}
+
+func (r *testResource) URN() URN { return r.urn }
+
+func registerTestResource(t *testing.T, ctx *Context, name string, custom bool, props map[string]interface{},
+	opts ...ResourceOpt) Resource {
+	res, err := ctx.RegisterResource("test:index:Resource", name, custom, props, opts...)
+	if !assert.NoError(t, err) {
+		return nil
+	}
+	urn, err := res.URN.Value()
+	assert.NoError(t, err)
+	return &testResource{urn: urn}
+}
+
+func TestTransformations(t *testing.T) {
+	ctx, monitor := newTestContext(t)
+
+	// Every resource in the stack gets a mandatory tag.
+	ctx.RegisterStackTransformation(func(args *ResourceTransformationArgs) *ResourceTransformationResult {
+		props := map[string]interface{}{"tag": "owned"}
+		for k, v := range args.Props {
+			props[k] = v
+		}
+		return &ResourceTransformationResult{Props: props, Opts: args.Opts}
+	})
+
+	// Everything inside the component, including its grandchildren, is protected; nothing else is changed.
+	protect := func(args *ResourceTransformationArgs) *ResourceTransformationResult {
+		if !args.Custom {
+			return nil
+		}
+		args.Opts.Protect = true
+		return &ResourceTransformationResult{Props: args.Props, Opts: args.Opts}
+	}
+	comp := registerTestResource(t, ctx, "comp", false, nil,
+		ResourceOpt{Transformations: []ResourceTransformation{protect}})
+	child := registerTestResource(t, ctx, "child", true, map[string]interface{}{"x": "y"}, ResourceOpt{Parent: comp})
+	registerTestResource(t, ctx, "grandchild", true, nil, ResourceOpt{Parent: child})
+	registerTestResource(t, ctx, "other", true, nil)
+	ctx.waitForRPCs()
+
+	assert.False(t, monitor.registers["comp"].Protect)
+	assert.True(t, monitor.registers["child"].Protect)
+	assert.True(t, monitor.registers["grandchild"].Protect)
+	assert.False(t, monitor.registers["other"].Protect)
+	for _, name := range []string{"comp", "child", "grandchild", "other"} {
+		assert.Equal(t, "owned", monitor.registers[name].Object.Fields["tag"].GetStringValue(), name)
+	}
+	assert.Equal(t, "y", monitor.registers["child"].Object.Fields["x"].GetStringValue())
+}
+
+func TestComponentResource(t *testing.T) {
+	ctx, monitor := newTestContext(t)
+
+	comp, err := ctx.RegisterComponentResource("test:index:Component", "comp", ResourceOpt{Protect: true})
+	if !assert.NoError(t, err) {
+		return
+	}
+	assert.Equal(t, URN("urn:comp"), comp.URN())
+
+	// The child is parented to the component and inherits its protection.
+	child, err := ctx.RegisterResource("test:index:Resource", "child", true, map[string]interface{}{"x": "y"},
+		ResourceOpt{Parent: comp})
+	if !assert.NoError(t, err) {
+		return
+	}
+	assert.NoError(t, comp.RegisterOutputs(map[string]interface{}{"childX": child.State["x"]}))
+	ctx.waitForRPCs()
+
+	assert.Equal(t, "urn:comp", monitor.registers["child"].Parent)
+	assert.True(t, monitor.registers["child"].Protect)
+	if assert.NotNil(t, monitor.outputs["urn:comp"]) {
+		assert.Equal(t, "y", monitor.outputs["urn:comp"].Outputs.Fields["childX"].GetStringValue())
+	}
+	assert.Equal(t, child.State["x"], comp.Outputs()["childX"])
+}
+
+func TestInvokeOutput(t *testing.T) {
+	ctx, monitor := newTestContext(t)
+	dep := &testResource{urn: "urn:dep"}
+	parent := &testResource{urn: "urn:parent"}
+
+	// An invoke whose arguments are outputs waits for them, and depends on their resources.
+	arg, resolve, _ := NewOutput([]Resource{dep})
+	result, err := ctx.InvokeOutput("test:index:getThing", map[string]interface{}{"name": arg},
+		InvokeOpt{Parent: parent, Provider: "other", Version: "1.2.3"})
+	if !assert.NoError(t, err) {
+		return
+	}
+	assert.Equal(t, []Resource{dep}, (*Output)(result).Deps())
+	go func() { resolve("thing", true) }()
+	outs, known, err := result.Value()
+	assert.NoError(t, err)
+	assert.True(t, known)
+	assert.Equal(t, map[string]interface{}{"name": "thing"}, outs)
+	if assert.Len(t, monitor.invokes, 1) {
+		assert.Equal(t, "urn:parent", monitor.invokes[0].Parent)
+		assert.Equal(t, "other", monitor.invokes[0].Provider)
+		assert.Equal(t, "1.2.3", monitor.invokes[0].Version)
+	}
+
+	// During previews, an invoke with unknown arguments is skipped and its result is unknown.
+	ctx.info.DryRun = true
+	arg, resolve, _ = NewOutput(nil)
+	go func() { resolve(nil, false) }()
+	result, err = ctx.InvokeOutput("test:index:getThing", map[string]interface{}{"name": arg})
+	if !assert.NoError(t, err) {
+		return
+	}
+	_, known, err = result.Value()
+	assert.NoError(t, err)
+	assert.False(t, known)
+	ctx.waitForRPCs()
+	assert.Len(t, monitor.invokes, 1)
+}
+
+func TestReadResourceDependencies(t *testing.T) {
+	ctx, monitor := newTestContext(t)
+
+	// The read isn't sent until the outputs among its properties resolve, and it depends on their resources as well
+	// as on those it was explicitly told about.
+	arg, resolve, _ := NewOutput([]Resource{&testResource{urn: "urn:prop"}})
+	go func() { resolve("thing", true) }()
+	res, err := ctx.ReadResource("test:index:Resource", "read", "some-id", map[string]interface{}{"name": arg},
+		ResourceOpt{DependsOn: []Resource{&testResource{urn: "urn:dep"}}})
+	if !assert.NoError(t, err) {
+		return
+	}
+	_, err = res.URN.Value()
+	assert.NoError(t, err)
+	ctx.waitForRPCs()
+
+	if assert.NotNil(t, monitor.reads["read"]) {
+		assert.Equal(t, "some-id", monitor.reads["read"].Id)
+		assert.Equal(t, "thing", monitor.reads["read"].Properties.Fields["name"].GetStringValue())
+		assert.Equal(t, []string{"urn:dep", "urn:prop"}, monitor.reads["read"].Dependencies)
+	}
+}
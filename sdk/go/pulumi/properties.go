@@ -318,6 +318,146 @@ func (out *Output) URN() (URN, error) {
 	return URN(toString(v)), nil
 }
 
+// ApplyArchive is like Apply, except that the applier produces an archive, and the result is typed accordingly.
+func (out *Output) ApplyArchive(applier func(v interface{}) (asset.Archive, error)) *ArchiveOutput {
+	return (*ArchiveOutput)(out.Apply(func(v interface{}) (interface{}, error) {
+		return applier(v)
+	}))
+}
+
+// ApplyArray is like Apply, except that the applier produces an array, and the result is typed accordingly.
+func (out *Output) ApplyArray(applier func(v interface{}) ([]interface{}, error)) *ArrayOutput {
+	return (*ArrayOutput)(out.Apply(func(v interface{}) (interface{}, error) {
+		return applier(v)
+	}))
+}
+
+// ApplyAsset is like Apply, except that the applier produces an asset, and the result is typed accordingly.
+func (out *Output) ApplyAsset(applier func(v interface{}) (asset.Asset, error)) *AssetOutput {
+	return (*AssetOutput)(out.Apply(func(v interface{}) (interface{}, error) {
+		return applier(v)
+	}))
+}
+
+// ApplyBool is like Apply, except that the applier produces a bool, and the result is typed accordingly.
+func (out *Output) ApplyBool(applier func(v interface{}) (bool, error)) *BoolOutput {
+	return (*BoolOutput)(out.Apply(func(v interface{}) (interface{}, error) {
+		return applier(v)
+	}))
+}
+
+// ApplyFloat32 is like Apply, except that the applier produces a float32, and the result is typed accordingly.
+func (out *Output) ApplyFloat32(applier func(v interface{}) (float32, error)) *Float32Output {
+	return (*Float32Output)(out.Apply(func(v interface{}) (interface{}, error) {
+		return applier(v)
+	}))
+}
+
+// ApplyFloat64 is like Apply, except that the applier produces a float64, and the result is typed accordingly.
+func (out *Output) ApplyFloat64(applier func(v interface{}) (float64, error)) *Float64Output {
+	return (*Float64Output)(out.Apply(func(v interface{}) (interface{}, error) {
+		return applier(v)
+	}))
+}
+
+// ApplyID is like Apply, except that the applier produces an ID, and the result is typed accordingly.
+func (out *Output) ApplyID(applier func(v interface{}) (ID, error)) *IDOutput {
+	return (*IDOutput)(out.Apply(func(v interface{}) (interface{}, error) {
+		return applier(v)
+	}))
+}
+
+// ApplyInt is like Apply, except that the applier produces an int, and the result is typed accordingly.
+func (out *Output) ApplyInt(applier func(v interface{}) (int, error)) *IntOutput {
+	return (*IntOutput)(out.Apply(func(v interface{}) (interface{}, error) {
+		return applier(v)
+	}))
+}
+
+// ApplyInt8 is like Apply, except that the applier produces an int8, and the result is typed accordingly.
+func (out *Output) ApplyInt8(applier func(v interface{}) (int8, error)) *Int8Output {
+	return (*Int8Output)(out.Apply(func(v interface{}) (interface{}, error) {
+		return applier(v)
+	}))
+}
+
+// ApplyInt16 is like Apply, except that the applier produces an int16, and the result is typed accordingly.
+func (out *Output) ApplyInt16(applier func(v interface{}) (int16, error)) *Int16Output {
+	return (*Int16Output)(out.Apply(func(v interface{}) (interface{}, error) {
+		return applier(v)
+	}))
+}
+
+// ApplyInt32 is like Apply, except that the applier produces an int32, and the result is typed accordingly.
+func (out *Output) ApplyInt32(applier func(v interface{}) (int32, error)) *Int32Output {
+	return (*Int32Output)(out.Apply(func(v interface{}) (interface{}, error) {
+		return applier(v)
+	}))
+}
+
+// ApplyInt64 is like Apply, except that the applier produces an int64, and the result is typed accordingly.
+func (out *Output) ApplyInt64(applier func(v interface{}) (int64, error)) *Int64Output {
+	return (*Int64Output)(out.Apply(func(v interface{}) (interface{}, error) {
+		return applier(v)
+	}))
+}
+
+// ApplyMap is like Apply, except that the applier produces a map, and the result is typed accordingly.
+func (out *Output) ApplyMap(applier func(v interface{}) (map[string]interface{}, error)) *MapOutput {
+	return (*MapOutput)(out.Apply(func(v interface{}) (interface{}, error) {
+		return applier(v)
+	}))
+}
+
+// ApplyString is like Apply, except that the applier produces a string, and the result is typed accordingly.
+func (out *Output) ApplyString(applier func(v interface{}) (string, error)) *StringOutput {
+	return (*StringOutput)(out.Apply(func(v interface{}) (interface{}, error) {
+		return applier(v)
+	}))
+}
+
+// ApplyUint is like Apply, except that the applier produces a uint, and the result is typed accordingly.
+func (out *Output) ApplyUint(applier func(v interface{}) (uint, error)) *UintOutput {
+	return (*UintOutput)(out.Apply(func(v interface{}) (interface{}, error) {
+		return applier(v)
+	}))
+}
+
+// ApplyUint8 is like Apply, except that the applier produces a uint8, and the result is typed accordingly.
+func (out *Output) ApplyUint8(applier func(v interface{}) (uint8, error)) *Uint8Output {
+	return (*Uint8Output)(out.Apply(func(v interface{}) (interface{}, error) {
+		return applier(v)
+	}))
+}
+
+// ApplyUint16 is like Apply, except that the applier produces a uint16, and the result is typed accordingly.
+func (out *Output) ApplyUint16(applier func(v interface{}) (uint16, error)) *Uint16Output {
+	return (*Uint16Output)(out.Apply(func(v interface{}) (interface{}, error) {
+		return applier(v)
+	}))
+}
+
+// ApplyUint32 is like Apply, except that the applier produces a uint32, and the result is typed accordingly.
+func (out *Output) ApplyUint32(applier func(v interface{}) (uint32, error)) *Uint32Output {
+	return (*Uint32Output)(out.Apply(func(v interface{}) (interface{}, error) {
+		return applier(v)
+	}))
+}
+
+// ApplyUint64 is like Apply, except that the applier produces a uint64, and the result is typed accordingly.
+func (out *Output) ApplyUint64(applier func(v interface{}) (uint64, error)) *Uint64Output {
+	return (*Uint64Output)(out.Apply(func(v interface{}) (interface{}, error) {
+		return applier(v)
+	}))
+}
+
+// ApplyURN is like Apply, except that the applier produces a URN, and the result is typed accordingly.
+func (out *Output) ApplyURN(applier func(v interface{}) (URN, error)) *URNOutput {
+	return (*URNOutput)(out.Apply(func(v interface{}) (interface{}, error) {
+		return applier(v)
+	}))
+}
+
 // Outputs is a map of property name to value, one for each resource output property.
 type Outputs map[string]*Output
 
@@ -619,6 +759,128 @@ func (out *URNOutput) Apply(applier func(URN) (interface{}, error)) *Output {
 	})
 }
 
+// All returns an output that resolves to an array of the values of all of the given outputs, once they are all
+// available.  The result depends on every resource that the given outputs depend on, and is unknown if any of them
+// is unknown.  If any of the outputs is rejected, so is the result.
+func All(outputs ...*Output) *ArrayOutput {
+	elems := make([]interface{}, len(outputs))
+	for i, out := range outputs {
+		elems[i] = out
+	}
+	return (*ArrayOutput)(Any(elems))
+}
+
+// Any returns an output that resolves to the given value, once any outputs nested within it -- directly, or inside
+// of arrays, slices and maps -- are available, with each of those outputs replaced by its value.  The result depends
+// on every resource that the nested outputs depend on.
+func Any(v interface{}) *Output {
+	result, resolve, reject := NewOutput(gatherDeps(v))
+	go func() {
+		value, known, err := awaitValue(v)
+		if err != nil {
+			reject(err)
+		} else {
+			resolve(value, known)
+		}
+	}()
+	return result
+}
+
+// NewArrayOutput returns an output that resolves to an array of the given elements, any of which may be outputs,
+// once they are all available.
+func NewArrayOutput(elems ...interface{}) *ArrayOutput {
+	return (*ArrayOutput)(Any(elems))
+}
+
+// NewMapOutput returns an output that resolves to a map of the given values, any of which may be outputs, once they
+// are all available.
+func NewMapOutput(m map[string]interface{}) *MapOutput {
+	return (*MapOutput)(Any(m))
+}
+
+// asOutput returns the given value as an output, if it is one, including any of the typed outputs.
+func asOutput(v interface{}) (*Output, bool) {
+	if out, ok := v.(*Output); ok {
+		return out, out != nil
+	}
+	ot := reflect.TypeOf(&Output{})
+	if rv := reflect.ValueOf(v); rv.Kind() == reflect.Ptr && !rv.IsNil() && rv.Type().ConvertibleTo(ot) {
+		return rv.Convert(ot).Interface().(*Output), true
+	}
+	return nil, false
+}
+
+// gatherDeps returns the dependencies of all of the outputs nested within the given value.
+func gatherDeps(v interface{}) []Resource {
+	if out, ok := asOutput(v); ok {
+		return out.Deps()
+	}
+
+	var deps []Resource
+	if v == nil {
+		return deps
+	}
+	switch rv := reflect.ValueOf(v); rv.Kind() {
+	case reflect.Array, reflect.Slice:
+		for i := 0; i < rv.Len(); i++ {
+			deps = append(deps, gatherDeps(rv.Index(i).Interface())...)
+		}
+	case reflect.Map:
+		for _, key := range rv.MapKeys() {
+			deps = append(deps, gatherDeps(rv.MapIndex(key).Interface())...)
+		}
+	}
+	return deps
+}
+
+// awaitValue returns the given value with all of the outputs nested within it replaced by their values, blocking
+// until they are available.  Arrays and slices become []interface{}, and maps with string keys become
+// map[string]interface{}.  The value is unknown if any of the outputs is unknown.
+func awaitValue(v interface{}) (interface{}, bool, error) {
+	if out, ok := asOutput(v); ok {
+		value, known, err := out.Value()
+		if err != nil || !known {
+			return nil, known, err
+		}
+		return awaitValue(value)
+	}
+
+	if v == nil {
+		return nil, true, nil
+	}
+	switch rv := reflect.ValueOf(v); rv.Kind() {
+	case reflect.Array, reflect.Slice:
+		if _, isBytes := v.([]byte); isBytes {
+			return v, true, nil
+		}
+		arr := make([]interface{}, rv.Len())
+		allKnown := true
+		for i := 0; i < rv.Len(); i++ {
+			elem, known, err := awaitValue(rv.Index(i).Interface())
+			if err != nil {
+				return nil, false, err
+			}
+			arr[i], allKnown = elem, allKnown && known
+		}
+		return arr, allKnown, nil
+	case reflect.Map:
+		if rv.Type().Key().Kind() != reflect.String {
+			return v, true, nil
+		}
+		obj := make(map[string]interface{})
+		allKnown := true
+		for _, key := range rv.MapKeys() {
+			elem, known, err := awaitValue(rv.MapIndex(key).Interface())
+			if err != nil {
+				return nil, false, err
+			}
+			obj[key.String()], allKnown = elem, allKnown && known
+		}
+		return obj, allKnown, nil
+	}
+	return v, true, nil
+}
+
 // toString attempts to convert v to a string.
 func toString(v interface{}) string {
 	if s := cast.ToString(v); s != "" {
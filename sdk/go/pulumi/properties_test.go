@@ -15,6 +15,7 @@
 package pulumi
 
 import (
+	"strings"
 	"testing"
 
 	"github.com/pkg/errors"
@@ -275,3 +276,84 @@ func TestOutputApply(t *testing.T) {
 		assert.Nil(t, v)
 	}
 }
+
+func TestOutputAll(t *testing.T) {
+	// Test that joined outputs resolve to all of their values, and depend on all of their resources.
+	{
+		r1, r2 := &testResource{urn: "urn:1"}, &testResource{urn: "urn:2"}
+		out1, resolve1, _ := NewOutput([]Resource{r1})
+		out2, resolve2, _ := NewOutput([]Resource{r2})
+		go func() { resolve1(42, true) }()
+		go func() { resolve2("forty-two", true) }()
+		all := All(out1, out2)
+		assert.Equal(t, []Resource{r1, r2}, (*Output)(all).Deps())
+		v, known, err := all.Value()
+		assert.Nil(t, err)
+		assert.True(t, known)
+		assert.Equal(t, []interface{}{42, "forty-two"}, v)
+	}
+	// Test that a single unknown output makes the result unknown.
+	{
+		out1, resolve1, _ := NewOutput(nil)
+		out2, resolve2, _ := NewOutput(nil)
+		go func() { resolve1(42, true) }()
+		go func() { resolve2(nil, false) }()
+		_, known, err := All(out1, out2).Value()
+		assert.Nil(t, err)
+		assert.False(t, known)
+	}
+	// Test that a single rejected output rejects the result.
+	{
+		out1, resolve1, _ := NewOutput(nil)
+		out2, _, reject2 := NewOutput(nil)
+		go func() { resolve1(42, true) }()
+		go func() { reject2(errors.New("boom")) }()
+		_, _, err := All(out1, out2).Value()
+		assert.NotNil(t, err)
+	}
+}
+
+func TestOutputTypedApply(t *testing.T) {
+	out1, resolve1, _ := NewOutput(nil)
+	out2, resolve2, _ := NewOutput(nil)
+	go func() { resolve1("hello", true) }()
+	go func() { resolve2(3, true) }()
+	s := (*Output)(All(out1, out2)).ApplyString(func(v interface{}) (string, error) {
+		args := v.([]interface{})
+		return strings.Repeat(args[0].(string), args[1].(int)), nil
+	})
+	v, known, err := s.Value()
+	assert.Nil(t, err)
+	assert.True(t, known)
+	assert.Equal(t, "hellohellohello", v)
+
+	n, known, err := (*Output)(s).ApplyInt(func(v interface{}) (int, error) { return len(v.(string)), nil }).Value()
+	assert.Nil(t, err)
+	assert.True(t, known)
+	assert.Equal(t, 15, n)
+}
+
+func TestNestedOutputs(t *testing.T) {
+	out1, resolve1, _ := NewOutput(nil)
+	out2, resolve2, _ := NewOutput(nil)
+	go func() { resolve1("a", true) }()
+	go func() { resolve2(true, true) }()
+
+	m, known, err := NewMapOutput(map[string]interface{}{
+		"plain":  1,
+		"output": out1,
+		"nested": []interface{}{(*BoolOutput)(out2), "b"},
+	}).Value()
+	assert.Nil(t, err)
+	assert.True(t, known)
+	assert.Equal(t, map[string]interface{}{
+		"plain":  1,
+		"output": "a",
+		"nested": []interface{}{true, "b"},
+	}, m)
+
+	arr, known, err := NewArrayOutput(out1, "c").Value()
+	assert.Nil(t, err)
+	assert.True(t, known)
+	assert.Equal(t, []interface{}{"a", "c"}, arr)
+}
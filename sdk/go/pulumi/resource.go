@@ -43,6 +43,32 @@ type ComponentResource interface {
 	Resource
 }
 
+// ComponentResourceState is the state of a registered component resource.  Embed it in a struct to define a new kind
+// of component, pass it as the Parent of the component's children, and finish by calling RegisterOutputs.  Children
+// inherit the component's protection and transformations.
+type ComponentResourceState struct {
+	ctx     *Context
+	urn     URN
+	outputs map[string]interface{}
+}
+
+var _ ComponentResource = (*ComponentResourceState)(nil)
+
+// URN is the component's URN.
+func (c *ComponentResourceState) URN() URN { return c.urn }
+
+// Outputs returns the outputs recorded for this component so far.
+func (c *ComponentResourceState) Outputs() map[string]interface{} { return c.outputs }
+
+// RegisterOutputs records the given outputs, which may refer to its children's outputs, for this component, and
+// registers everything recorded so far with the engine, completing the component's registration.
+func (c *ComponentResourceState) RegisterOutputs(outs map[string]interface{}) error {
+	for k, v := range outs {
+		c.outputs[k] = v
+	}
+	return c.ctx.RegisterResourceOutputs(c.urn, c.outputs)
+}
+
 // ResourceOpt contains optional settings that control a resource's behavior.
 type ResourceOpt struct {
 	// Parent is an optional parent resource to which this resource belongs.
@@ -51,4 +77,68 @@ type ResourceOpt struct {
 	DependsOn []Resource
 	// Protect, when set to true, ensures that this resource cannot be deleted (without first setting it to false).
 	Protect bool
+	// DeleteBeforeReplace, when set to true, ensures that this resource is deleted before its replacement is created,
+	// rather than afterwards.  This is necessary when two instances of the resource can't exist at once, e.g. because
+	// it has a fixed, unique name.
+	DeleteBeforeReplace bool
+	// RetainOnDelete, when set to true, causes this resource to be dropped from the stack's state, rather than
+	// deleted by its provider, when it is no longer part of the program.  The resource itself is left untouched.
+	RetainOnDelete bool
+	// ReplaceOnChanges is an optional list of property paths (e.g. "userData" or "settings.name") that, when
+	// changed, force this resource to be replaced rather than updated in place.
+	ReplaceOnChanges []string
+	// Retries is an optional number of times to retry operations on this resource that the provider reports as having
+	// failed transiently.  Zero uses the engine's default, and a negative number disables retries.
+	Retries int
+	// Transformations is an optional list of transformations to apply to this resource, and to all of the resources
+	// that are created with it as their parent, before they are registered.
+	Transformations []ResourceTransformation
+}
+
+// ResourceTransformation is a function that is given the chance to rewrite a resource's properties and options
+// before it is registered.  Returning nil leaves the resource unchanged.
+type ResourceTransformation func(args *ResourceTransformationArgs) *ResourceTransformationResult
+
+// ResourceTransformationArgs describes the resource that a transformation is being applied to.
+type ResourceTransformationArgs struct {
+	// Type is the resource's fully qualified type token.
+	Type string
+	// Name is the resource's name.
+	Name string
+	// Custom is true for custom resources, and false for component resources.
+	Custom bool
+	// Props are the resource's input properties.
+	Props map[string]interface{}
+	// Opts are the resource's options, merged into one.
+	Opts ResourceOpt
+}
+
+// ResourceTransformationResult is the rewritten properties and options produced by a transformation.
+type ResourceTransformationResult struct {
+	// Props are the properties to use in place of the resource's original ones.
+	Props map[string]interface{}
+	// Opts are the options to use in place of the resource's original ones.
+	Opts ResourceOpt
+}
+
+// mergeOpts combines a list of resource options into one, in the same way that the individual options are read
+// when a resource is registered: the first parent and non-zero retry count win, flags are combined, and lists are
+// concatenated.
+func mergeOpts(opts ...ResourceOpt) ResourceOpt {
+	var merged ResourceOpt
+	for _, opt := range opts {
+		if merged.Parent == nil {
+			merged.Parent = opt.Parent
+		}
+		merged.DependsOn = append(merged.DependsOn, opt.DependsOn...)
+		merged.Protect = merged.Protect || opt.Protect
+		merged.DeleteBeforeReplace = merged.DeleteBeforeReplace || opt.DeleteBeforeReplace
+		merged.RetainOnDelete = merged.RetainOnDelete || opt.RetainOnDelete
+		merged.ReplaceOnChanges = append(merged.ReplaceOnChanges, opt.ReplaceOnChanges...)
+		if merged.Retries == 0 {
+			merged.Retries = opt.Retries
+		}
+		merged.Transformations = append(merged.Transformations, opt.Transformations...)
+	}
+	return merged
 }
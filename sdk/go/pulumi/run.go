@@ -63,6 +63,14 @@ func RunErr(body RunFunc) error {
 	}
 	defer contract.IgnoreClose(ctx)
 
+	return RunWithContext(ctx, body)
+}
+
+// RunWithContext runs the body of a Pulumi program using the given Context for information about the target stack,
+// configuration, and engine connection.
+func RunWithContext(ctx *Context, body RunFunc) error {
+	info := ctx.info
+
 	// Create a root stack resource that we'll parent everything to.
 	reg, err := ctx.RegisterResource(
 		"pulumi:pulumi:Stack", fmt.Sprintf("%s-%s", info.Project, info.Stack), false, nil)
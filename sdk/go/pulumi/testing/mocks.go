@@ -0,0 +1,208 @@
+// Copyright 2016-2018, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package testing allows Pulumi Go programs to be exercised by `go test` without a Pulumi engine.  Resource
+// registrations and invokes are served by an in-process resource monitor that defers to user-supplied mocks.
+package testing
+
+import (
+	"fmt"
+	"sync"
+
+	pbempty "github.com/golang/protobuf/ptypes/empty"
+	"github.com/pkg/errors"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc"
+
+	"github.com/pulumi/pulumi/pkg/resource"
+	"github.com/pulumi/pulumi/pkg/resource/plugin"
+	"github.com/pulumi/pulumi/pkg/tokens"
+	"github.com/pulumi/pulumi/pkg/util/contract"
+	"github.com/pulumi/pulumi/pkg/util/rpcutil"
+	"github.com/pulumi/pulumi/sdk/go/pulumi"
+	pulumirpc "github.com/pulumi/pulumi/sdk/proto/go"
+)
+
+// Mocks supplies the results of the resource registrations and invokes made by a program under test.
+type Mocks interface {
+	// NewResource returns the ID and output properties of a resource with the given type, name, and inputs.  For
+	// resources read with ReadResource, id is the requested ID; otherwise, it is empty.  The returned ID is ignored
+	// for component resources.
+	NewResource(typ, name string, inputs resource.PropertyMap, id string) (string, resource.PropertyMap, error)
+	// Call returns the result of invoking the function tok with the given arguments.
+	Call(tok string, args resource.PropertyMap) (resource.PropertyMap, error)
+}
+
+// MockMonitor is an in-process pulumirpc.ResourceMonitorServer that answers requests using a set of mocks.
+type MockMonitor struct {
+	project tokens.PackageName
+	stack   tokens.QName
+	mocks   Mocks
+	lock    sync.Mutex
+}
+
+var _ pulumirpc.ResourceMonitorServer = (*MockMonitor)(nil)
+
+// NewMockMonitor creates a new resource monitor for the given project and stack that defers to mocks.
+func NewMockMonitor(project, stack string, mocks Mocks) *MockMonitor {
+	contract.Require(mocks != nil, "mocks")
+	return &MockMonitor{
+		project: tokens.PackageName(project),
+		stack:   tokens.QName(stack),
+		mocks:   mocks,
+	}
+}
+
+// RunWithMocks runs the body of a Pulumi program against the given mocks rather than a Pulumi engine.  Any error
+// returned by the body, or by a mock, is returned.
+func RunWithMocks(project, stack string, mocks Mocks, body pulumi.RunFunc) error {
+	monitor := NewMockMonitor(project, stack, mocks)
+
+	cancel := make(chan bool)
+	port, done, err := rpcutil.Serve(0, cancel, []func(*grpc.Server) error{
+		func(srv *grpc.Server) error {
+			pulumirpc.RegisterResourceMonitorServer(srv, monitor)
+			return nil
+		},
+	})
+	if err != nil {
+		return errors.Wrap(err, "starting mock resource monitor")
+	}
+
+	result := func() error {
+		ctx, err := pulumi.NewContext(context.TODO(), pulumi.RunInfo{
+			Project:     project,
+			Stack:       stack,
+			MonitorAddr: fmt.Sprintf("127.0.0.1:%d", port),
+		})
+		if err != nil {
+			return err
+		}
+		defer contract.IgnoreClose(ctx)
+
+		return pulumi.RunWithContext(ctx, body)
+	}()
+
+	// Shut the monitor down and wait for it to finish, preferring any error from the program itself.
+	close(cancel)
+	if err := <-done; err != nil && result == nil {
+		result = err
+	}
+	return result
+}
+
+// newURN computes the URN of a resource the same way the engine does.
+func (m *MockMonitor) newURN(parent, typ, name string) resource.URN {
+	parentType := tokens.Type("")
+	if p := resource.URN(parent); p != "" && p.Type() != resource.RootStackType {
+		parentType = p.QualifiedType()
+	}
+	return resource.NewURN(m.stack, m.project, parentType, tokens.Type(typ), tokens.QName(name))
+}
+
+// newResource defers to the mocks, serializing calls so that they need not be safe for concurrent use.
+func (m *MockMonitor) newResource(typ, name string, inputs resource.PropertyMap,
+	id string) (string, resource.PropertyMap, error) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	return m.mocks.NewResource(typ, name, inputs, id)
+}
+
+// Invoke answers a function invocation using the mocks' Call method.
+func (m *MockMonitor) Invoke(ctx context.Context, req *pulumirpc.InvokeRequest) (*pulumirpc.InvokeResponse, error) {
+	label := fmt.Sprintf("MockMonitor.Invoke(%s)", req.GetTok())
+	args, err := plugin.UnmarshalProperties(req.GetArgs(), plugin.MarshalOptions{Label: label, KeepUnknowns: true})
+	if err != nil {
+		return nil, err
+	}
+
+	m.lock.Lock()
+	ret, err := m.mocks.Call(req.GetTok(), args)
+	m.lock.Unlock()
+	if err != nil {
+		return nil, err
+	}
+
+	mret, err := plugin.MarshalProperties(ret, plugin.MarshalOptions{Label: label, KeepUnknowns: true})
+	if err != nil {
+		return nil, err
+	}
+	return &pulumirpc.InvokeResponse{Return: mret}, nil
+}
+
+// ReadResource answers a resource read using the mocks' NewResource method, passing along the requested ID.
+func (m *MockMonitor) ReadResource(ctx context.Context,
+	req *pulumirpc.ReadResourceRequest) (*pulumirpc.ReadResourceResponse, error) {
+	label := fmt.Sprintf("MockMonitor.ReadResource(%s, %s)", req.GetType(), req.GetName())
+	inputs, err := plugin.UnmarshalProperties(
+		req.GetProperties(), plugin.MarshalOptions{Label: label, KeepUnknowns: true})
+	if err != nil {
+		return nil, err
+	}
+
+	_, outputs, err := m.newResource(req.GetType(), req.GetName(), inputs, req.GetId())
+	if err != nil {
+		return nil, err
+	}
+
+	marshaled, err := plugin.MarshalProperties(outputs, plugin.MarshalOptions{Label: label, KeepUnknowns: true})
+	if err != nil {
+		return nil, err
+	}
+	return &pulumirpc.ReadResourceResponse{
+		Urn:        string(m.newURN(req.GetParent(), req.GetType(), req.GetName())),
+		Properties: marshaled,
+	}, nil
+}
+
+// RegisterResource answers a resource registration using the mocks' NewResource method.  The root stack resource is
+// not passed to the mocks.
+func (m *MockMonitor) RegisterResource(ctx context.Context,
+	req *pulumirpc.RegisterResourceRequest) (*pulumirpc.RegisterResourceResponse, error) {
+	urn := m.newURN(req.GetParent(), req.GetType(), req.GetName())
+	if tokens.Type(req.GetType()) == resource.RootStackType {
+		return &pulumirpc.RegisterResourceResponse{Urn: string(urn)}, nil
+	}
+
+	label := fmt.Sprintf("MockMonitor.RegisterResource(%s, %s)", req.GetType(), req.GetName())
+	inputs, err := plugin.UnmarshalProperties(
+		req.GetObject(), plugin.MarshalOptions{Label: label, KeepUnknowns: true})
+	if err != nil {
+		return nil, err
+	}
+
+	id, outputs, err := m.newResource(req.GetType(), req.GetName(), inputs, "")
+	if err != nil {
+		return nil, err
+	}
+	if !req.GetCustom() {
+		id = ""
+	}
+
+	obj, err := plugin.MarshalProperties(outputs, plugin.MarshalOptions{Label: label, KeepUnknowns: true})
+	if err != nil {
+		return nil, err
+	}
+	return &pulumirpc.RegisterResourceResponse{
+		Urn:    string(urn),
+		Id:     id,
+		Object: obj,
+	}, nil
+}
+
+// RegisterResourceOutputs accepts and discards the outputs of a component resource.
+func (m *MockMonitor) RegisterResourceOutputs(ctx context.Context,
+	req *pulumirpc.RegisterResourceOutputsRequest) (*pbempty.Empty, error) {
+	return &pbempty.Empty{}, nil
+}
@@ -0,0 +1,115 @@
+// Copyright 2016-2018, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package testing
+
+import (
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/pulumi/pulumi/pkg/resource"
+	"github.com/pulumi/pulumi/sdk/go/pulumi"
+)
+
+type testMocks struct {
+	resources map[string]resource.PropertyMap
+	calls     []string
+}
+
+func (m *testMocks) NewResource(typ, name string, inputs resource.PropertyMap,
+	id string) (string, resource.PropertyMap, error) {
+	if typ == "test:index:Broken" {
+		return "", nil, errors.New("cannot create a broken resource")
+	}
+	m.resources[name] = inputs
+
+	if id == "" {
+		id = name + "-id"
+	}
+	outputs := inputs.Copy()
+	outputs["arn"] = resource.NewStringProperty("arn:" + id)
+	return id, outputs, nil
+}
+
+func (m *testMocks) Call(tok string, args resource.PropertyMap) (resource.PropertyMap, error) {
+	m.calls = append(m.calls, tok)
+	return resource.PropertyMap{"region": resource.NewStringProperty("us-west-2")}, nil
+}
+
+func TestRunWithMocks(t *testing.T) {
+	mocks := &testMocks{resources: make(map[string]resource.PropertyMap)}
+
+	err := RunWithMocks("project", "stack", mocks, func(ctx *pulumi.Context) error {
+		comp, err := ctx.RegisterComponentResource("test:index:Component", "comp")
+		if err != nil {
+			return err
+		}
+
+		bucket, err := ctx.RegisterResource("test:index:Bucket", "bucket", true,
+			map[string]interface{}{"size": 42, "arn": nil}, pulumi.ResourceOpt{Parent: comp})
+		if err != nil {
+			return err
+		}
+		urn, err := bucket.URN.Value()
+		assert.NoError(t, err)
+		assert.Equal(t, pulumi.URN("urn:pulumi:stack::project::test:index:Component$test:index:Bucket::bucket"), urn)
+		id, known, err := bucket.ID.Value()
+		assert.NoError(t, err)
+		assert.True(t, known)
+		assert.Equal(t, pulumi.ID("bucket-id"), id)
+		arn, _, err := bucket.State["arn"].Value()
+		assert.NoError(t, err)
+		assert.Equal(t, "arn:bucket-id", arn)
+
+		read, err := ctx.ReadResource("test:index:Bucket", "existing", "existing-bucket",
+			map[string]interface{}{"arn": nil})
+		if err != nil {
+			return err
+		}
+		arn, _, err = read.State["arn"].Value()
+		assert.NoError(t, err)
+		assert.Equal(t, "arn:existing-bucket", arn)
+
+		region, err := ctx.Invoke("test:index:getRegion", nil)
+		assert.NoError(t, err)
+		assert.Equal(t, map[string]interface{}{"region": "us-west-2"}, region)
+		return nil
+	})
+	assert.NoError(t, err)
+
+	assert.Len(t, mocks.resources, 3)
+	assert.Equal(t, resource.PropertyMap{
+		"size": resource.NewNumberProperty(42),
+		"arn":  resource.NewNullProperty(),
+	}, mocks.resources["bucket"])
+	assert.Equal(t, []string{"test:index:getRegion"}, mocks.calls)
+}
+
+func TestRunWithMocksError(t *testing.T) {
+	mocks := &testMocks{resources: make(map[string]resource.PropertyMap)}
+
+	err := RunWithMocks("project", "stack", mocks, func(ctx *pulumi.Context) error {
+		res, err := ctx.RegisterResource("test:index:Broken", "broken", true, nil)
+		if err != nil {
+			return err
+		}
+		_, err = res.URN.Value()
+		return err
+	})
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), "cannot create a broken resource")
+	}
+}
@@ -48,7 +48,7 @@ func (x DiffResponse_DiffChanges) String() string {
 	return proto.EnumName(DiffResponse_DiffChanges_name, int32(x))
 }
 func (DiffResponse_DiffChanges) EnumDescriptor() ([]byte, []int) {
-	return fileDescriptor_provider_4e2b47bc2ad920ea, []int{8, 0}
+	return fileDescriptor_provider_546fdd8aa24a09fc, []int{8, 0}
 }
 
 type ConfigureRequest struct {
@@ -62,7 +62,7 @@ func (m *ConfigureRequest) Reset()         { *m = ConfigureRequest{} }
 func (m *ConfigureRequest) String() string { return proto.CompactTextString(m) }
 func (*ConfigureRequest) ProtoMessage()    {}
 func (*ConfigureRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_provider_4e2b47bc2ad920ea, []int{0}
+	return fileDescriptor_provider_546fdd8aa24a09fc, []int{0}
 }
 func (m *ConfigureRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_ConfigureRequest.Unmarshal(m, b)
@@ -101,7 +101,7 @@ func (m *ConfigureErrorMissingKeys) Reset()         { *m = ConfigureErrorMissing
 func (m *ConfigureErrorMissingKeys) String() string { return proto.CompactTextString(m) }
 func (*ConfigureErrorMissingKeys) ProtoMessage()    {}
 func (*ConfigureErrorMissingKeys) Descriptor() ([]byte, []int) {
-	return fileDescriptor_provider_4e2b47bc2ad920ea, []int{1}
+	return fileDescriptor_provider_546fdd8aa24a09fc, []int{1}
 }
 func (m *ConfigureErrorMissingKeys) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_ConfigureErrorMissingKeys.Unmarshal(m, b)
@@ -140,7 +140,7 @@ func (m *ConfigureErrorMissingKeys_MissingKey) Reset()         { *m = ConfigureE
 func (m *ConfigureErrorMissingKeys_MissingKey) String() string { return proto.CompactTextString(m) }
 func (*ConfigureErrorMissingKeys_MissingKey) ProtoMessage()    {}
 func (*ConfigureErrorMissingKeys_MissingKey) Descriptor() ([]byte, []int) {
-	return fileDescriptor_provider_4e2b47bc2ad920ea, []int{1, 0}
+	return fileDescriptor_provider_546fdd8aa24a09fc, []int{1, 0}
 }
 func (m *ConfigureErrorMissingKeys_MissingKey) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_ConfigureErrorMissingKeys_MissingKey.Unmarshal(m, b)
@@ -177,6 +177,9 @@ func (m *ConfigureErrorMissingKeys_MissingKey) GetDescription() string {
 type InvokeRequest struct {
 	Tok                  string          `protobuf:"bytes,1,opt,name=tok" json:"tok,omitempty"`
 	Args                 *_struct.Struct `protobuf:"bytes,2,opt,name=args" json:"args,omitempty"`
+	Version              string          `protobuf:"bytes,3,opt,name=version" json:"version,omitempty"`
+	Parent               string          `protobuf:"bytes,4,opt,name=parent" json:"parent,omitempty"`
+	Provider             string          `protobuf:"bytes,5,opt,name=provider" json:"provider,omitempty"`
 	XXX_NoUnkeyedLiteral struct{}        `json:"-"`
 	XXX_unrecognized     []byte          `json:"-"`
 	XXX_sizecache        int32           `json:"-"`
@@ -186,7 +189,7 @@ func (m *InvokeRequest) Reset()         { *m = InvokeRequest{} }
 func (m *InvokeRequest) String() string { return proto.CompactTextString(m) }
 func (*InvokeRequest) ProtoMessage()    {}
 func (*InvokeRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_provider_4e2b47bc2ad920ea, []int{2}
+	return fileDescriptor_provider_546fdd8aa24a09fc, []int{2}
 }
 func (m *InvokeRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_InvokeRequest.Unmarshal(m, b)
@@ -220,6 +223,27 @@ func (m *InvokeRequest) GetArgs() *_struct.Struct {
 	return nil
 }
 
+func (m *InvokeRequest) GetVersion() string {
+	if m != nil {
+		return m.Version
+	}
+	return ""
+}
+
+func (m *InvokeRequest) GetParent() string {
+	if m != nil {
+		return m.Parent
+	}
+	return ""
+}
+
+func (m *InvokeRequest) GetProvider() string {
+	if m != nil {
+		return m.Provider
+	}
+	return ""
+}
+
 type InvokeResponse struct {
 	Return               *_struct.Struct `protobuf:"bytes,1,opt,name=return" json:"return,omitempty"`
 	Failures             []*CheckFailure `protobuf:"bytes,2,rep,name=failures" json:"failures,omitempty"`
@@ -232,7 +256,7 @@ func (m *InvokeResponse) Reset()         { *m = InvokeResponse{} }
 func (m *InvokeResponse) String() string { return proto.CompactTextString(m) }
 func (*InvokeResponse) ProtoMessage()    {}
 func (*InvokeResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_provider_4e2b47bc2ad920ea, []int{3}
+	return fileDescriptor_provider_546fdd8aa24a09fc, []int{3}
 }
 func (m *InvokeResponse) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_InvokeResponse.Unmarshal(m, b)
@@ -279,7 +303,7 @@ func (m *CheckRequest) Reset()         { *m = CheckRequest{} }
 func (m *CheckRequest) String() string { return proto.CompactTextString(m) }
 func (*CheckRequest) ProtoMessage()    {}
 func (*CheckRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_provider_4e2b47bc2ad920ea, []int{4}
+	return fileDescriptor_provider_546fdd8aa24a09fc, []int{4}
 }
 func (m *CheckRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_CheckRequest.Unmarshal(m, b)
@@ -332,7 +356,7 @@ func (m *CheckResponse) Reset()         { *m = CheckResponse{} }
 func (m *CheckResponse) String() string { return proto.CompactTextString(m) }
 func (*CheckResponse) ProtoMessage()    {}
 func (*CheckResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_provider_4e2b47bc2ad920ea, []int{5}
+	return fileDescriptor_provider_546fdd8aa24a09fc, []int{5}
 }
 func (m *CheckResponse) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_CheckResponse.Unmarshal(m, b)
@@ -378,7 +402,7 @@ func (m *CheckFailure) Reset()         { *m = CheckFailure{} }
 func (m *CheckFailure) String() string { return proto.CompactTextString(m) }
 func (*CheckFailure) ProtoMessage()    {}
 func (*CheckFailure) Descriptor() ([]byte, []int) {
-	return fileDescriptor_provider_4e2b47bc2ad920ea, []int{6}
+	return fileDescriptor_provider_546fdd8aa24a09fc, []int{6}
 }
 func (m *CheckFailure) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_CheckFailure.Unmarshal(m, b)
@@ -426,7 +450,7 @@ func (m *DiffRequest) Reset()         { *m = DiffRequest{} }
 func (m *DiffRequest) String() string { return proto.CompactTextString(m) }
 func (*DiffRequest) ProtoMessage()    {}
 func (*DiffRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_provider_4e2b47bc2ad920ea, []int{7}
+	return fileDescriptor_provider_546fdd8aa24a09fc, []int{7}
 }
 func (m *DiffRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_DiffRequest.Unmarshal(m, b)
@@ -488,7 +512,7 @@ func (m *DiffResponse) Reset()         { *m = DiffResponse{} }
 func (m *DiffResponse) String() string { return proto.CompactTextString(m) }
 func (*DiffResponse) ProtoMessage()    {}
 func (*DiffResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_provider_4e2b47bc2ad920ea, []int{8}
+	return fileDescriptor_provider_546fdd8aa24a09fc, []int{8}
 }
 func (m *DiffResponse) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_DiffResponse.Unmarshal(m, b)
@@ -548,7 +572,7 @@ func (m *CreateRequest) Reset()         { *m = CreateRequest{} }
 func (m *CreateRequest) String() string { return proto.CompactTextString(m) }
 func (*CreateRequest) ProtoMessage()    {}
 func (*CreateRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_provider_4e2b47bc2ad920ea, []int{9}
+	return fileDescriptor_provider_546fdd8aa24a09fc, []int{9}
 }
 func (m *CreateRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_CreateRequest.Unmarshal(m, b)
@@ -594,7 +618,7 @@ func (m *CreateResponse) Reset()         { *m = CreateResponse{} }
 func (m *CreateResponse) String() string { return proto.CompactTextString(m) }
 func (*CreateResponse) ProtoMessage()    {}
 func (*CreateResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_provider_4e2b47bc2ad920ea, []int{10}
+	return fileDescriptor_provider_546fdd8aa24a09fc, []int{10}
 }
 func (m *CreateResponse) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_CreateResponse.Unmarshal(m, b)
@@ -641,7 +665,7 @@ func (m *ReadRequest) Reset()         { *m = ReadRequest{} }
 func (m *ReadRequest) String() string { return proto.CompactTextString(m) }
 func (*ReadRequest) ProtoMessage()    {}
 func (*ReadRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_provider_4e2b47bc2ad920ea, []int{11}
+	return fileDescriptor_provider_546fdd8aa24a09fc, []int{11}
 }
 func (m *ReadRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_ReadRequest.Unmarshal(m, b)
@@ -694,7 +718,7 @@ func (m *ReadResponse) Reset()         { *m = ReadResponse{} }
 func (m *ReadResponse) String() string { return proto.CompactTextString(m) }
 func (*ReadResponse) ProtoMessage()    {}
 func (*ReadResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_provider_4e2b47bc2ad920ea, []int{12}
+	return fileDescriptor_provider_546fdd8aa24a09fc, []int{12}
 }
 func (m *ReadResponse) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_ReadResponse.Unmarshal(m, b)
@@ -742,7 +766,7 @@ func (m *UpdateRequest) Reset()         { *m = UpdateRequest{} }
 func (m *UpdateRequest) String() string { return proto.CompactTextString(m) }
 func (*UpdateRequest) ProtoMessage()    {}
 func (*UpdateRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_provider_4e2b47bc2ad920ea, []int{13}
+	return fileDescriptor_provider_546fdd8aa24a09fc, []int{13}
 }
 func (m *UpdateRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_UpdateRequest.Unmarshal(m, b)
@@ -801,7 +825,7 @@ func (m *UpdateResponse) Reset()         { *m = UpdateResponse{} }
 func (m *UpdateResponse) String() string { return proto.CompactTextString(m) }
 func (*UpdateResponse) ProtoMessage()    {}
 func (*UpdateResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_provider_4e2b47bc2ad920ea, []int{14}
+	return fileDescriptor_provider_546fdd8aa24a09fc, []int{14}
 }
 func (m *UpdateResponse) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_UpdateResponse.Unmarshal(m, b)
@@ -841,7 +865,7 @@ func (m *DeleteRequest) Reset()         { *m = DeleteRequest{} }
 func (m *DeleteRequest) String() string { return proto.CompactTextString(m) }
 func (*DeleteRequest) ProtoMessage()    {}
 func (*DeleteRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_provider_4e2b47bc2ad920ea, []int{15}
+	return fileDescriptor_provider_546fdd8aa24a09fc, []int{15}
 }
 func (m *DeleteRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_DeleteRequest.Unmarshal(m, b)
@@ -897,7 +921,7 @@ func (m *ErrorResourceInitFailed) Reset()         { *m = ErrorResourceInitFailed
 func (m *ErrorResourceInitFailed) String() string { return proto.CompactTextString(m) }
 func (*ErrorResourceInitFailed) ProtoMessage()    {}
 func (*ErrorResourceInitFailed) Descriptor() ([]byte, []int) {
-	return fileDescriptor_provider_4e2b47bc2ad920ea, []int{16}
+	return fileDescriptor_provider_546fdd8aa24a09fc, []int{16}
 }
 func (m *ErrorResourceInitFailed) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_ErrorResourceInitFailed.Unmarshal(m, b)
@@ -1362,64 +1386,65 @@ var _ResourceProvider_serviceDesc = grpc.ServiceDesc{
 	Metadata: "provider.proto",
 }
 
-func init() { proto.RegisterFile("provider.proto", fileDescriptor_provider_4e2b47bc2ad920ea) }
-
-var fileDescriptor_provider_4e2b47bc2ad920ea = []byte{
-	// 881 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xc4, 0x56, 0xdd, 0x6e, 0x1b, 0x45,
-	0x14, 0xce, 0xda, 0x89, 0x1b, 0x1f, 0xff, 0x68, 0x35, 0x40, 0xe2, 0x6e, 0xb9, 0x88, 0x96, 0x9b,
-	0x0a, 0xa4, 0x0d, 0x4a, 0x2f, 0x80, 0xaa, 0x15, 0x28, 0x89, 0x03, 0x56, 0x55, 0xa7, 0x6c, 0x55,
-	0x2a, 0xb8, 0x41, 0x9b, 0xdd, 0x63, 0x67, 0xf0, 0x66, 0x67, 0x99, 0x9d, 0x35, 0x0a, 0xe2, 0x05,
-	0x2a, 0xde, 0x80, 0xc7, 0xe0, 0xd9, 0x78, 0x00, 0xb4, 0x33, 0xb3, 0xeb, 0x9d, 0x38, 0xb1, 0x4d,
-	0x55, 0xd1, 0xbb, 0x39, 0x73, 0x7e, 0xbe, 0xf3, 0x3f, 0x03, 0xfd, 0x94, 0xb3, 0x39, 0x8d, 0x90,
-	0x7b, 0x29, 0x67, 0x82, 0x91, 0x76, 0x9a, 0xc7, 0xf9, 0x15, 0xe5, 0x69, 0xe8, 0x74, 0xd3, 0x38,
-	0x9f, 0xd2, 0x44, 0x31, 0x9c, 0x07, 0x53, 0xc6, 0xa6, 0x31, 0x1e, 0x4a, 0xea, 0x22, 0x9f, 0x1c,
-	0xe2, 0x55, 0x2a, 0xae, 0x35, 0xf3, 0xe3, 0x9b, 0xcc, 0x4c, 0xf0, 0x3c, 0x14, 0x8a, 0xeb, 0xfe,
-	0x65, 0x81, 0x7d, 0xc2, 0x92, 0x09, 0x9d, 0xe6, 0x1c, 0x7d, 0xfc, 0x35, 0xc7, 0x4c, 0x90, 0xef,
-	0xa0, 0x3d, 0x0f, 0x38, 0x0d, 0x2e, 0x62, 0xcc, 0x06, 0xd6, 0x41, 0xf3, 0x61, 0xe7, 0xe8, 0x53,
-	0xaf, 0x02, 0xf7, 0x6e, 0xca, 0x7b, 0x3f, 0x94, 0xc2, 0xc3, 0x44, 0xf0, 0x6b, 0x7f, 0xa1, 0xec,
-	0x3c, 0x81, 0xbe, 0xc9, 0x24, 0x36, 0x34, 0x67, 0x78, 0x3d, 0xb0, 0x0e, 0xac, 0x87, 0x6d, 0xbf,
-	0x38, 0x92, 0x0f, 0x61, 0x67, 0x1e, 0xc4, 0x39, 0x0e, 0x1a, 0xf2, 0x4e, 0x11, 0x8f, 0x1b, 0x5f,
-	0x5a, 0xee, 0xdf, 0x16, 0xdc, 0xaf, 0xc0, 0x86, 0x9c, 0x33, 0xfe, 0x9c, 0x66, 0x19, 0x4d, 0xa6,
-	0xcf, 0xf0, 0x3a, 0x23, 0xdf, 0x43, 0xe7, 0x6a, 0x41, 0x6a, 0x3f, 0x0f, 0x6f, 0xf3, 0xf3, 0xa6,
-	0xaa, 0xb7, 0x38, 0xfb, 0x75, 0x1b, 0xce, 0x31, 0xc0, 0x82, 0x45, 0x08, 0x6c, 0x27, 0xc1, 0x15,
-	0x6a, 0x5f, 0xe5, 0x99, 0x1c, 0x40, 0x27, 0xc2, 0x2c, 0xe4, 0x34, 0x15, 0x94, 0x25, 0xda, 0xe5,
-	0xfa, 0x95, 0x3b, 0x86, 0xde, 0x28, 0x99, 0xb3, 0x59, 0x95, 0x4d, 0x1b, 0x9a, 0x82, 0xcd, 0xca,
-	0x88, 0x05, 0x9b, 0x91, 0xcf, 0x60, 0x3b, 0xe0, 0xd3, 0x4c, 0x6a, 0x77, 0x8e, 0xf6, 0x3d, 0x55,
-	0x21, 0xaf, 0xac, 0x90, 0xf7, 0x52, 0x56, 0xc8, 0x97, 0x42, 0xee, 0x1c, 0xfa, 0xa5, 0xbd, 0x2c,
-	0x65, 0x49, 0x86, 0xe4, 0x10, 0x5a, 0x1c, 0x45, 0xce, 0x13, 0x69, 0x73, 0x85, 0x01, 0x2d, 0x46,
-	0x1e, 0xc1, 0xee, 0x24, 0xa0, 0x71, 0xce, 0xb1, 0xc0, 0x6c, 0x4a, 0x95, 0x5a, 0x9a, 0x2e, 0x31,
-	0x9c, 0x9d, 0x29, 0xbe, 0x5f, 0x09, 0xba, 0xbf, 0x43, 0x57, 0x72, 0x6a, 0x61, 0x94, 0x90, 0x6d,
-	0xbf, 0x38, 0x16, 0x61, 0xb0, 0x38, 0x5a, 0x1f, 0x46, 0x21, 0x54, 0x08, 0x27, 0xf8, 0x5b, 0x36,
-	0x68, 0xae, 0x11, 0x2e, 0x84, 0xdc, 0x1c, 0x7a, 0x1a, 0x7b, 0x11, 0x32, 0x4d, 0xd2, 0x5c, 0x64,
-	0x6b, 0x43, 0x56, 0x62, 0x6f, 0x17, 0xf2, 0xb1, 0x0e, 0x59, 0x73, 0x88, 0x03, 0xbb, 0x29, 0x67,
-	0x29, 0x72, 0x51, 0x36, 0x6c, 0x45, 0x93, 0xbd, 0xa2, 0x08, 0x41, 0x56, 0xf5, 0x80, 0xa6, 0xdc,
-	0x37, 0x16, 0x74, 0x4e, 0xe9, 0x64, 0x52, 0xa6, 0xad, 0x0f, 0x0d, 0x1a, 0x69, 0xed, 0x06, 0x8d,
-	0xca, 0x34, 0x36, 0x96, 0xd3, 0xd8, 0xfc, 0x2f, 0x69, 0xdc, 0xde, 0x24, 0x8d, 0xff, 0x58, 0xd0,
-	0x55, 0xbe, 0xe8, 0x34, 0x3a, 0xb0, 0xcb, 0x31, 0x8d, 0x83, 0x50, 0xcf, 0x75, 0xdb, 0xaf, 0x68,
-	0x32, 0x80, 0x7b, 0x99, 0x50, 0x23, 0xdf, 0x90, 0xac, 0x92, 0x24, 0x9f, 0xc3, 0x07, 0x11, 0xc6,
-	0x28, 0xf0, 0x18, 0x27, 0xac, 0x98, 0x7a, 0xa9, 0x21, 0xfd, 0xdd, 0xf5, 0x6f, 0x63, 0x91, 0xa7,
-	0x70, 0x2f, 0xbc, 0x0c, 0x92, 0x29, 0x2a, 0x47, 0xfb, 0x47, 0x9f, 0xd4, 0x92, 0x5f, 0xf7, 0x48,
-	0x12, 0x27, 0x4a, 0xd4, 0x2f, 0x75, 0xdc, 0xa7, 0x2a, 0x85, 0xfa, 0x9e, 0xd8, 0xd0, 0x3d, 0x1d,
-	0x9d, 0x9d, 0xfd, 0xfc, 0x6a, 0xfc, 0x6c, 0x7c, 0xfe, 0x7a, 0x6c, 0x6f, 0x91, 0x1e, 0xb4, 0xe5,
-	0xcd, 0xf8, 0x7c, 0x3c, 0xb4, 0xad, 0x8a, 0x7c, 0x79, 0xfe, 0x7c, 0x68, 0x37, 0xdc, 0x9f, 0xa0,
-	0x77, 0xc2, 0x31, 0x10, 0x78, 0x77, 0xeb, 0x7e, 0x01, 0xa0, 0x2b, 0x49, 0x71, 0x6d, 0x03, 0xd7,
-	0x44, 0xdd, 0x1f, 0xa1, 0x5f, 0xda, 0xd6, 0x39, 0xbd, 0x59, 0xe0, 0xb7, 0x36, 0x7d, 0x09, 0x1d,
-	0x1f, 0x83, 0x68, 0xf3, 0xc6, 0x31, 0x91, 0x9a, 0x9b, 0x23, 0xbd, 0x86, 0xae, 0x42, 0x7a, 0xd7,
-	0x21, 0xfc, 0x69, 0x41, 0xef, 0x55, 0x1a, 0xd5, 0x52, 0xff, 0x3e, 0xdb, 0x7f, 0x04, 0xfd, 0xd2,
-	0x19, 0x1d, 0xa8, 0x19, 0x98, 0xb5, 0x79, 0x60, 0xbf, 0x40, 0xef, 0x54, 0xf6, 0xf9, 0xff, 0x50,
-	0x9d, 0x3f, 0x60, 0x5f, 0x3e, 0x58, 0x3e, 0x66, 0x2c, 0xe7, 0x21, 0x8e, 0x12, 0x2a, 0x8a, 0x8d,
-	0x84, 0xd1, 0x3b, 0x2b, 0x54, 0x31, 0xec, 0x6a, 0x5f, 0x15, 0x9e, 0xc9, 0x61, 0xd7, 0xe4, 0xd1,
-	0x9b, 0x1d, 0xb0, 0x4b, 0xe4, 0x17, 0xfa, 0xff, 0x41, 0x8e, 0xa1, 0x5d, 0x3d, 0xa6, 0xe4, 0xc1,
-	0x8a, 0xaf, 0x80, 0xb3, 0xb7, 0x84, 0x3e, 0x2c, 0xfe, 0x22, 0xee, 0x16, 0xf9, 0x1a, 0x5a, 0xea,
-	0x1d, 0x23, 0x83, 0x9a, 0x01, 0xe3, 0xa9, 0x74, 0xee, 0xdf, 0xc2, 0x51, 0xa5, 0x73, 0xb7, 0xc8,
-	0x13, 0xd8, 0x91, 0xdb, 0x99, 0x2c, 0x6d, 0xf2, 0x52, 0x7d, 0xb0, 0xcc, 0xa8, 0xb4, 0xbf, 0x82,
-	0xed, 0x62, 0xa7, 0x90, 0xbd, 0xa5, 0x4d, 0xa4, 0x74, 0xf7, 0xef, 0xd8, 0x50, 0xca, 0x73, 0x35,
-	0xf3, 0x86, 0xe7, 0xc6, 0x8a, 0x31, 0x3c, 0x37, 0x17, 0x84, 0xc2, 0x2e, 0xe6, 0xcd, 0xc0, 0xae,
-	0x8d, 0xba, 0x81, 0x5d, 0x1f, 0x4c, 0x85, 0xad, 0x7a, 0xd8, 0xc0, 0x36, 0x66, 0xcc, 0xc0, 0x36,
-	0x1b, 0x5e, 0x66, 0xad, 0xa5, 0x3a, 0xd7, 0x30, 0x60, 0x34, 0xf3, 0x8a, 0xa2, 0x3d, 0x86, 0xd6,
-	0x49, 0x90, 0x84, 0x18, 0x93, 0x3b, 0x64, 0x56, 0xe8, 0x7e, 0x03, 0xbd, 0x6f, 0x51, 0xbc, 0x90,
-	0x1f, 0xd5, 0x51, 0x32, 0x61, 0x77, 0x9a, 0xf8, 0xa8, 0xe6, 0xd8, 0x42, 0xdc, 0xdd, 0xba, 0x68,
-	0x49, 0xc1, 0x47, 0xff, 0x06, 0x00, 0x00, 0xff, 0xff, 0x9e, 0xbd, 0xd5, 0xcc, 0x09, 0x0b, 0x00,
-	0x00,
+func init() { proto.RegisterFile("provider.proto", fileDescriptor_provider_546fdd8aa24a09fc) }
+
+var fileDescriptor_provider_546fdd8aa24a09fc = []byte{
+	// 907 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xc4, 0x56, 0xdd, 0x6e, 0xe3, 0x44,
+	0x14, 0xae, 0x93, 0x34, 0xdb, 0x9c, 0xfc, 0x28, 0x1a, 0xa0, 0x75, 0xbd, 0x5c, 0x54, 0xe6, 0x66,
+	0x05, 0x52, 0x8a, 0xba, 0x17, 0xc0, 0x6a, 0x57, 0xa0, 0xb6, 0x29, 0x44, 0xab, 0x4d, 0x17, 0xaf,
+	0x96, 0x15, 0xdc, 0x20, 0x37, 0x3e, 0x49, 0x4d, 0x5c, 0xdb, 0xcc, 0x8c, 0x83, 0x8a, 0x78, 0x81,
+	0x15, 0x6f, 0x80, 0x78, 0x0a, 0x9e, 0x8d, 0x07, 0x40, 0xf3, 0xe7, 0x8c, 0x9b, 0xf4, 0x87, 0x55,
+	0x05, 0x77, 0x3e, 0xf3, 0x9d, 0xdf, 0xef, 0x9c, 0x39, 0x63, 0xe8, 0xe5, 0x34, 0x5b, 0xc4, 0x11,
+	0xd2, 0x41, 0x4e, 0x33, 0x9e, 0x91, 0x56, 0x5e, 0x24, 0xc5, 0x45, 0x4c, 0xf3, 0x89, 0xd7, 0xc9,
+	0x93, 0x62, 0x16, 0xa7, 0x0a, 0xf0, 0x1e, 0xce, 0xb2, 0x6c, 0x96, 0xe0, 0xbe, 0x94, 0xce, 0x8a,
+	0xe9, 0x3e, 0x5e, 0xe4, 0xfc, 0x52, 0x83, 0x1f, 0x5e, 0x05, 0x19, 0xa7, 0xc5, 0x84, 0x2b, 0xd4,
+	0xff, 0xc3, 0x81, 0xfe, 0x51, 0x96, 0x4e, 0xe3, 0x59, 0x41, 0x31, 0xc0, 0x9f, 0x0b, 0x64, 0x9c,
+	0x7c, 0x03, 0xad, 0x45, 0x48, 0xe3, 0xf0, 0x2c, 0x41, 0xe6, 0x3a, 0x7b, 0xf5, 0x47, 0xed, 0x83,
+	0x8f, 0x07, 0x65, 0xf0, 0xc1, 0x55, 0xfd, 0xc1, 0x77, 0x46, 0x79, 0x98, 0x72, 0x7a, 0x19, 0x2c,
+	0x8d, 0xbd, 0xa7, 0xd0, 0xab, 0x82, 0xa4, 0x0f, 0xf5, 0x39, 0x5e, 0xba, 0xce, 0x9e, 0xf3, 0xa8,
+	0x15, 0x88, 0x4f, 0xf2, 0x3e, 0x6c, 0x2e, 0xc2, 0xa4, 0x40, 0xb7, 0x26, 0xcf, 0x94, 0xf0, 0xa4,
+	0xf6, 0xb9, 0xe3, 0xff, 0xe5, 0xc0, 0x6e, 0x19, 0x6c, 0x48, 0x69, 0x46, 0x5f, 0xc4, 0x8c, 0xc5,
+	0xe9, 0xec, 0x39, 0x5e, 0x32, 0xf2, 0x2d, 0xb4, 0x2f, 0x96, 0xa2, 0xce, 0x73, 0x7f, 0x5d, 0x9e,
+	0x57, 0x4d, 0x07, 0xcb, 0xef, 0xc0, 0xf6, 0xe1, 0x1d, 0x02, 0x2c, 0x21, 0x42, 0xa0, 0x91, 0x86,
+	0x17, 0xa8, 0x73, 0x95, 0xdf, 0x64, 0x0f, 0xda, 0x11, 0xb2, 0x09, 0x8d, 0x73, 0x1e, 0x67, 0xa9,
+	0x4e, 0xd9, 0x3e, 0xf2, 0xff, 0x74, 0xa0, 0x3b, 0x4a, 0x17, 0xd9, 0xbc, 0xa4, 0xb3, 0x0f, 0x75,
+	0x9e, 0xcd, 0x4d, 0xc9, 0x3c, 0x9b, 0x93, 0x4f, 0xa0, 0x11, 0xd2, 0x19, 0x93, 0xe6, 0xed, 0x83,
+	0x9d, 0x81, 0x6a, 0xd1, 0xc0, 0xb4, 0x68, 0xf0, 0x4a, 0xb6, 0x28, 0x90, 0x4a, 0xc4, 0x85, 0x07,
+	0x0b, 0xa4, 0x4c, 0x84, 0xab, 0x4b, 0x17, 0x46, 0x24, 0xdb, 0xd0, 0xcc, 0x43, 0x8a, 0x29, 0x77,
+	0x1b, 0x12, 0xd0, 0x12, 0xf1, 0x60, 0xcb, 0x8c, 0x8e, 0xbb, 0x29, 0x91, 0x52, 0xf6, 0x17, 0xd0,
+	0x33, 0xd9, 0xb1, 0x3c, 0x4b, 0x19, 0x92, 0x7d, 0x68, 0x52, 0xe4, 0x05, 0x4d, 0x5d, 0xe7, 0xe6,
+	0x74, 0xb4, 0x1a, 0x79, 0x0c, 0x5b, 0xd3, 0x30, 0x4e, 0x0a, 0x8a, 0xa2, 0x82, 0xba, 0x34, 0xb1,
+	0x58, 0x3f, 0xc7, 0xc9, 0xfc, 0x44, 0xe1, 0x41, 0xa9, 0xe8, 0xff, 0x0a, 0x1d, 0x89, 0x58, 0xa4,
+	0x98, 0x90, 0xad, 0x40, 0x7c, 0x0a, 0x52, 0xb2, 0x24, 0xba, 0x9d, 0x14, 0xa1, 0x24, 0x94, 0x53,
+	0xfc, 0x85, 0xb9, 0xf5, 0x5b, 0x94, 0x85, 0x92, 0x5f, 0x40, 0x57, 0xc7, 0x5e, 0x96, 0x1c, 0xa7,
+	0x79, 0xc1, 0xd9, 0xad, 0x25, 0x2b, 0xb5, 0x77, 0x2b, 0xf9, 0x10, 0x3a, 0x36, 0xa2, 0xdb, 0x92,
+	0x23, 0xe5, 0x66, 0xfe, 0x4b, 0x59, 0xb4, 0x92, 0x62, 0xc8, 0xca, 0x91, 0xd2, 0x92, 0xff, 0xd6,
+	0x81, 0xf6, 0x71, 0x3c, 0x9d, 0x1a, 0xda, 0x7a, 0x50, 0x8b, 0x23, 0x6d, 0x5d, 0x8b, 0x23, 0x43,
+	0x63, 0x6d, 0x95, 0xc6, 0xfa, 0xbf, 0xa1, 0xb1, 0x71, 0x17, 0x1a, 0xff, 0x76, 0xa0, 0xa3, 0x72,
+	0xd1, 0x34, 0x7a, 0xb0, 0x45, 0x31, 0x4f, 0xc2, 0x89, 0x5e, 0x13, 0xad, 0xa0, 0x94, 0xc5, 0xd4,
+	0x32, 0xae, 0x36, 0x48, 0x4d, 0x42, 0x46, 0x24, 0x9f, 0xc2, 0x7b, 0x11, 0x26, 0xc8, 0xf1, 0x10,
+	0xa7, 0x99, 0x58, 0x22, 0xd2, 0x42, 0xe6, 0xbb, 0x15, 0xac, 0x83, 0xc8, 0x33, 0x78, 0x30, 0x39,
+	0x0f, 0xd3, 0x19, 0xaa, 0x44, 0x7b, 0x07, 0x1f, 0x59, 0xe4, 0xdb, 0x19, 0x49, 0xe1, 0x48, 0xa9,
+	0x06, 0xc6, 0xc6, 0x7f, 0x06, 0x6d, 0xeb, 0x9c, 0xf4, 0xa1, 0x73, 0x3c, 0x3a, 0x39, 0xf9, 0xf1,
+	0xf5, 0xf8, 0xf9, 0xf8, 0xf4, 0xcd, 0xb8, 0xbf, 0x41, 0xba, 0xd0, 0x92, 0x27, 0xe3, 0xd3, 0xf1,
+	0xb0, 0xef, 0x94, 0xe2, 0xab, 0xd3, 0x17, 0xc3, 0x7e, 0xcd, 0xff, 0x01, 0xba, 0x47, 0x14, 0x43,
+	0x8e, 0xd7, 0x8f, 0xee, 0x67, 0x00, 0xba, 0x93, 0x31, 0xde, 0x3a, 0xc0, 0x96, 0xaa, 0xff, 0x3d,
+	0xf4, 0x8c, 0x6f, 0xcd, 0xe9, 0xd5, 0x06, 0xbf, 0xb3, 0xeb, 0x73, 0x68, 0x07, 0x18, 0x46, 0x77,
+	0x1f, 0x9c, 0x6a, 0xa4, 0xfa, 0xdd, 0x23, 0xbd, 0x81, 0x8e, 0x8a, 0x74, 0xdf, 0x25, 0xfc, 0xee,
+	0x40, 0xf7, 0x75, 0x1e, 0x59, 0xd4, 0xff, 0x9f, 0xe3, 0x3f, 0x82, 0x9e, 0x49, 0x46, 0x17, 0x5a,
+	0x2d, 0xcc, 0xb9, 0x7b, 0x61, 0x3f, 0x41, 0xf7, 0x58, 0xce, 0xf9, 0x7f, 0xd0, 0x9d, 0xdf, 0x60,
+	0x47, 0xbe, 0x7f, 0x01, 0xb2, 0xac, 0xa0, 0x13, 0x1c, 0xa5, 0x31, 0x17, 0x1b, 0x09, 0xa3, 0x7b,
+	0x6b, 0x94, 0xb8, 0xec, 0x6a, 0x5f, 0x89, 0xcc, 0xe4, 0x65, 0xd7, 0xe2, 0xc1, 0xdb, 0x4d, 0xe8,
+	0x9b, 0xc8, 0x2f, 0xf5, 0x1b, 0x44, 0x0e, 0xa1, 0x55, 0xbe, 0xcd, 0xe4, 0xe1, 0x0d, 0x7f, 0x16,
+	0xde, 0xf6, 0x4a, 0xf4, 0xa1, 0xf8, 0xb5, 0xf1, 0x37, 0xc8, 0x97, 0xd0, 0x54, 0xef, 0x18, 0x71,
+	0x2d, 0x07, 0x95, 0x87, 0xd7, 0xdb, 0x5d, 0x83, 0xa8, 0xd6, 0xf9, 0x1b, 0xe4, 0x29, 0x6c, 0xca,
+	0xed, 0x4c, 0x56, 0x36, 0xb9, 0x31, 0x77, 0x57, 0x81, 0xd2, 0xfa, 0x0b, 0x68, 0x88, 0x9d, 0x42,
+	0xb6, 0x57, 0x36, 0x91, 0xb2, 0xdd, 0xb9, 0x66, 0x43, 0xa9, 0xcc, 0xd5, 0x9d, 0xaf, 0x64, 0x5e,
+	0x59, 0x31, 0xde, 0xee, 0x1a, 0xc4, 0x8e, 0x2d, 0xee, 0x5b, 0x25, 0xb6, 0x75, 0xd5, 0xbd, 0x9d,
+	0x95, 0x73, 0x3b, 0xb6, 0x9a, 0xe1, 0x4a, 0xec, 0xca, 0x1d, 0xf3, 0x76, 0xd7, 0x20, 0x16, 0x6b,
+	0x4d, 0x35, 0xb9, 0x15, 0x07, 0x95, 0x61, 0xbe, 0xa1, 0x69, 0x4f, 0xa0, 0x79, 0x14, 0xa6, 0x13,
+	0x4c, 0xc8, 0x35, 0x3a, 0x37, 0xd8, 0x7e, 0x05, 0xdd, 0xaf, 0x91, 0xbf, 0x94, 0xff, 0xbd, 0xa3,
+	0x74, 0x9a, 0x5d, 0xeb, 0xe2, 0x03, 0x2b, 0xb1, 0xa5, 0xba, 0xbf, 0x71, 0xd6, 0x94, 0x8a, 0x8f,
+	0xff, 0x19, 0x00, 0x08, 0x6f, 0x56, 0x26, 0x58, 0x0b, 0x00, 0x00,
 }
@@ -32,6 +32,8 @@ type ReadResourceRequest struct {
 	Name                 string          `protobuf:"bytes,3,opt,name=name" json:"name,omitempty"`
 	Parent               string          `protobuf:"bytes,4,opt,name=parent" json:"parent,omitempty"`
 	Properties           *_struct.Struct `protobuf:"bytes,5,opt,name=properties" json:"properties,omitempty"`
+	Dependencies         []string        `protobuf:"bytes,6,rep,name=dependencies" json:"dependencies,omitempty"`
+	Retries              int32           `protobuf:"varint,7,opt,name=retries" json:"retries,omitempty"`
 	XXX_NoUnkeyedLiteral struct{}        `json:"-"`
 	XXX_unrecognized     []byte          `json:"-"`
 	XXX_sizecache        int32           `json:"-"`
@@ -41,7 +43,7 @@ func (m *ReadResourceRequest) Reset()         { *m = ReadResourceRequest{} }
 func (m *ReadResourceRequest) String() string { return proto.CompactTextString(m) }
 func (*ReadResourceRequest) ProtoMessage()    {}
 func (*ReadResourceRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_resource_55fabfa8edee605e, []int{0}
+	return fileDescriptor_resource_e09c736cb7fa203b, []int{0}
 }
 func (m *ReadResourceRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_ReadResourceRequest.Unmarshal(m, b)
@@ -96,6 +98,20 @@ func (m *ReadResourceRequest) GetProperties() *_struct.Struct {
 	return nil
 }
 
+func (m *ReadResourceRequest) GetDependencies() []string {
+	if m != nil {
+		return m.Dependencies
+	}
+	return nil
+}
+
+func (m *ReadResourceRequest) GetRetries() int32 {
+	if m != nil {
+		return m.Retries
+	}
+	return 0
+}
+
 // ReadResourceResponse contains the result of reading a resource's state.
 type ReadResourceResponse struct {
 	Urn                  string          `protobuf:"bytes,1,opt,name=urn" json:"urn,omitempty"`
@@ -109,7 +125,7 @@ func (m *ReadResourceResponse) Reset()         { *m = ReadResourceResponse{} }
 func (m *ReadResourceResponse) String() string { return proto.CompactTextString(m) }
 func (*ReadResourceResponse) ProtoMessage()    {}
 func (*ReadResourceResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_resource_55fabfa8edee605e, []int{1}
+	return fileDescriptor_resource_e09c736cb7fa203b, []int{1}
 }
 func (m *ReadResourceResponse) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_ReadResourceResponse.Unmarshal(m, b)
@@ -152,6 +168,10 @@ type RegisterResourceRequest struct {
 	Object               *_struct.Struct `protobuf:"bytes,5,opt,name=object" json:"object,omitempty"`
 	Protect              bool            `protobuf:"varint,6,opt,name=protect" json:"protect,omitempty"`
 	Dependencies         []string        `protobuf:"bytes,7,rep,name=dependencies" json:"dependencies,omitempty"`
+	Retries              int32           `protobuf:"varint,8,opt,name=retries" json:"retries,omitempty"`
+	DeleteBeforeReplace  bool            `protobuf:"varint,9,opt,name=deleteBeforeReplace" json:"deleteBeforeReplace,omitempty"`
+	RetainOnDelete       bool            `protobuf:"varint,10,opt,name=retainOnDelete" json:"retainOnDelete,omitempty"`
+	ReplaceOnChanges     []string        `protobuf:"bytes,11,rep,name=replaceOnChanges" json:"replaceOnChanges,omitempty"`
 	XXX_NoUnkeyedLiteral struct{}        `json:"-"`
 	XXX_unrecognized     []byte          `json:"-"`
 	XXX_sizecache        int32           `json:"-"`
@@ -161,7 +181,7 @@ func (m *RegisterResourceRequest) Reset()         { *m = RegisterResourceRequest
 func (m *RegisterResourceRequest) String() string { return proto.CompactTextString(m) }
 func (*RegisterResourceRequest) ProtoMessage()    {}
 func (*RegisterResourceRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_resource_55fabfa8edee605e, []int{2}
+	return fileDescriptor_resource_e09c736cb7fa203b, []int{2}
 }
 func (m *RegisterResourceRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_RegisterResourceRequest.Unmarshal(m, b)
@@ -230,6 +250,34 @@ func (m *RegisterResourceRequest) GetDependencies() []string {
 	return nil
 }
 
+func (m *RegisterResourceRequest) GetRetries() int32 {
+	if m != nil {
+		return m.Retries
+	}
+	return 0
+}
+
+func (m *RegisterResourceRequest) GetDeleteBeforeReplace() bool {
+	if m != nil {
+		return m.DeleteBeforeReplace
+	}
+	return false
+}
+
+func (m *RegisterResourceRequest) GetRetainOnDelete() bool {
+	if m != nil {
+		return m.RetainOnDelete
+	}
+	return false
+}
+
+func (m *RegisterResourceRequest) GetReplaceOnChanges() []string {
+	if m != nil {
+		return m.ReplaceOnChanges
+	}
+	return nil
+}
+
 // RegisterResourceResponse is returned by the engine after a resource has finished being initialized.  It includes the
 // auto-assigned URN, the provider-assigned ID, and any other properties initialized by the engine.
 type RegisterResourceResponse struct {
@@ -247,7 +295,7 @@ func (m *RegisterResourceResponse) Reset()         { *m = RegisterResourceRespon
 func (m *RegisterResourceResponse) String() string { return proto.CompactTextString(m) }
 func (*RegisterResourceResponse) ProtoMessage()    {}
 func (*RegisterResourceResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_resource_55fabfa8edee605e, []int{3}
+	return fileDescriptor_resource_e09c736cb7fa203b, []int{3}
 }
 func (m *RegisterResourceResponse) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_RegisterResourceResponse.Unmarshal(m, b)
@@ -315,7 +363,7 @@ func (m *RegisterResourceOutputsRequest) Reset()         { *m = RegisterResource
 func (m *RegisterResourceOutputsRequest) String() string { return proto.CompactTextString(m) }
 func (*RegisterResourceOutputsRequest) ProtoMessage()    {}
 func (*RegisterResourceOutputsRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_resource_55fabfa8edee605e, []int{4}
+	return fileDescriptor_resource_e09c736cb7fa203b, []int{4}
 }
 func (m *RegisterResourceOutputsRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_RegisterResourceOutputsRequest.Unmarshal(m, b)
@@ -528,38 +576,43 @@ var _ResourceMonitor_serviceDesc = grpc.ServiceDesc{
 	Metadata: "resource.proto",
 }
 
-func init() { proto.RegisterFile("resource.proto", fileDescriptor_resource_55fabfa8edee605e) }
-
-var fileDescriptor_resource_55fabfa8edee605e = []byte{
-	// 472 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x8c, 0x54, 0x3b, 0x8f, 0xd3, 0x40,
-	0x10, 0x3e, 0xdb, 0x87, 0x43, 0x86, 0x53, 0x38, 0x0d, 0x28, 0x67, 0x0c, 0x3a, 0x22, 0xd3, 0x84,
-	0xc6, 0x11, 0x47, 0x41, 0x49, 0x45, 0x41, 0x81, 0x10, 0xa6, 0x06, 0xc9, 0xb1, 0x87, 0xc8, 0x90,
-	0x78, 0x97, 0x7d, 0x9c, 0x74, 0x7f, 0x06, 0xfe, 0x1a, 0x05, 0x3f, 0x04, 0xad, 0xd7, 0x1b, 0xe2,
-	0xc7, 0x3d, 0xba, 0x79, 0x79, 0xe6, 0xfb, 0xbe, 0x99, 0x35, 0xcc, 0x04, 0x49, 0xa6, 0x45, 0x41,
-	0x29, 0x17, 0x4c, 0x31, 0x9c, 0x72, 0xbd, 0xd5, 0xbb, 0x4a, 0xf0, 0x22, 0x7e, 0xba, 0x61, 0x6c,
-	0xb3, 0xa5, 0x55, 0x93, 0x58, 0xeb, 0x6f, 0x2b, 0xda, 0x71, 0x75, 0x65, 0xeb, 0xe2, 0x67, 0xfd,
-	0xa4, 0x54, 0x42, 0x17, 0xaa, 0xcd, 0xce, 0xb8, 0x60, 0x97, 0x55, 0x49, 0xc2, 0xfa, 0xc9, 0x2f,
-	0x0f, 0x1e, 0x65, 0x94, 0x97, 0x59, 0x3b, 0x2c, 0xa3, 0x9f, 0x9a, 0xa4, 0xc2, 0x19, 0xf8, 0x55,
-	0x19, 0x79, 0x0b, 0x6f, 0x39, 0xcd, 0xfc, 0xaa, 0x44, 0x84, 0x63, 0x75, 0xc5, 0x29, 0xf2, 0x9b,
-	0x48, 0x63, 0x9b, 0x58, 0x9d, 0xef, 0x28, 0x0a, 0x6c, 0xcc, 0xd8, 0x38, 0x87, 0x90, 0xe7, 0x82,
-	0x6a, 0x15, 0x1d, 0x37, 0xd1, 0xd6, 0xc3, 0x37, 0x00, 0x5c, 0x30, 0x4e, 0x42, 0x55, 0x24, 0xa3,
-	0x7b, 0x0b, 0x6f, 0xf9, 0xe0, 0xe2, 0x2c, 0xb5, 0x50, 0x53, 0x07, 0x35, 0xfd, 0xdc, 0x40, 0xcd,
-	0x0e, 0x4a, 0x93, 0x1c, 0x1e, 0x77, 0xf1, 0x49, 0xce, 0x6a, 0x49, 0x78, 0x0a, 0x81, 0x16, 0x75,
-	0x8b, 0xd0, 0x98, 0xbd, 0x11, 0xfe, 0xdd, 0x47, 0xfc, 0xf1, 0xe0, 0x2c, 0xa3, 0x4d, 0x25, 0x15,
-	0x89, 0xbe, 0x0e, 0x8e, 0xb7, 0x37, 0xc2, 0xdb, 0x1f, 0xe5, 0x1d, 0x74, 0x78, 0xcf, 0x21, 0x2c,
-	0xb4, 0x54, 0x6c, 0xd7, 0xe8, 0x71, 0x3f, 0x6b, 0x3d, 0x5c, 0x41, 0xc8, 0xd6, 0xdf, 0xa9, 0x50,
-	0xb7, 0x69, 0xd1, 0x96, 0x61, 0x04, 0x13, 0x93, 0x32, 0x5f, 0x84, 0x4d, 0x27, 0xe7, 0x62, 0x02,
-	0x27, 0x25, 0x71, 0xaa, 0x4b, 0xaa, 0x0b, 0xc3, 0x7c, 0xb2, 0x08, 0x96, 0xd3, 0xac, 0x13, 0x4b,
-	0x7e, 0x7b, 0x10, 0x0d, 0x29, 0x5e, 0x2b, 0xa5, 0xdd, 0xbe, 0xbf, 0xdf, 0xfe, 0x7f, 0xb4, 0xc1,
-	0xdd, 0xd0, 0xce, 0x21, 0x94, 0x2a, 0x5f, 0x6f, 0xc9, 0xd1, 0xb6, 0x9e, 0x61, 0x61, 0x2d, 0x73,
-	0x03, 0x06, 0xa6, 0x73, 0x13, 0x82, 0xf3, 0x3e, 0xc0, 0x8f, 0x5a, 0x71, 0xad, 0xa4, 0x5b, 0xc5,
-	0x10, 0xe6, 0x2b, 0x98, 0x30, 0x5b, 0x73, 0xdb, 0xba, 0x5d, 0xdd, 0xc5, 0x5f, 0x1f, 0x1e, 0xba,
-	0xfe, 0x1f, 0x58, 0x5d, 0x29, 0x26, 0xf0, 0x2d, 0x84, 0xef, 0xeb, 0x4b, 0xf6, 0x83, 0x30, 0x4a,
-	0xf7, 0x8f, 0x2c, 0xb5, 0xa1, 0x76, 0x78, 0xfc, 0x64, 0x24, 0x63, 0xe5, 0x4b, 0x8e, 0xf0, 0x13,
-	0x9c, 0x1c, 0xde, 0x28, 0x9e, 0x1f, 0x14, 0x8f, 0x3c, 0xae, 0xf8, 0xf9, 0xb5, 0xf9, 0x7d, 0xcb,
-	0x2f, 0x70, 0xda, 0x97, 0x03, 0x93, 0xce, 0x67, 0xa3, 0xf7, 0x1a, 0xbf, 0xb8, 0xb1, 0x66, 0xdf,
-	0xfe, 0xeb, 0xf0, 0xe2, 0x5b, 0xb5, 0xf1, 0xe5, 0x0d, 0x1d, 0xba, 0x1b, 0x89, 0xe7, 0x03, 0xb9,
-	0xdf, 0x99, 0x1f, 0x51, 0x72, 0xb4, 0x0e, 0x9b, 0xc8, 0xeb, 0x7f, 0x01, 0x00, 0x00, 0xff, 0xff,
-	0x20, 0x92, 0x11, 0xa7, 0xc5, 0x04, 0x00, 0x00,
+func init() { proto.RegisterFile("resource.proto", fileDescriptor_resource_e09c736cb7fa203b) }
+
+var fileDescriptor_resource_e09c736cb7fa203b = []byte{
+	// 554 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x8c, 0x94, 0x4f, 0x6f, 0xd3, 0x4c,
+	0x10, 0xc6, 0x6b, 0xbb, 0x75, 0x9a, 0x69, 0xd5, 0x37, 0xda, 0xbe, 0x4a, 0x8c, 0x41, 0x25, 0x32,
+	0x12, 0x0a, 0x1c, 0x1c, 0x28, 0x07, 0x8e, 0x48, 0xfc, 0x39, 0x70, 0x40, 0x11, 0xe6, 0x0c, 0x92,
+	0x63, 0x4f, 0x83, 0x21, 0xd9, 0x5d, 0x76, 0xd7, 0x95, 0x7a, 0xe3, 0x9b, 0xf0, 0xc5, 0x38, 0xf2,
+	0x41, 0xd0, 0xee, 0xda, 0x21, 0x76, 0x9c, 0xb4, 0xb7, 0x9d, 0x79, 0x66, 0x67, 0x1f, 0xff, 0x76,
+	0xbc, 0x70, 0x26, 0x50, 0xb2, 0x52, 0x64, 0x18, 0x73, 0xc1, 0x14, 0x23, 0x7d, 0x5e, 0x2e, 0xcb,
+	0x55, 0x21, 0x78, 0x16, 0xde, 0x5f, 0x30, 0xb6, 0x58, 0xe2, 0xd4, 0x08, 0xf3, 0xf2, 0x6a, 0x8a,
+	0x2b, 0xae, 0x6e, 0x6c, 0x5d, 0xf8, 0xa0, 0x2d, 0x4a, 0x25, 0xca, 0x4c, 0x55, 0xea, 0x19, 0x17,
+	0xec, 0xba, 0xc8, 0x51, 0xd8, 0x38, 0xfa, 0xed, 0xc0, 0x79, 0x82, 0x69, 0x9e, 0x54, 0x87, 0x25,
+	0xf8, 0xa3, 0x44, 0xa9, 0xc8, 0x19, 0xb8, 0x45, 0x1e, 0x38, 0x63, 0x67, 0xd2, 0x4f, 0xdc, 0x22,
+	0x27, 0x04, 0x0e, 0xd5, 0x0d, 0xc7, 0xc0, 0x35, 0x19, 0xb3, 0xd6, 0x39, 0x9a, 0xae, 0x30, 0xf0,
+	0x6c, 0x4e, 0xaf, 0xc9, 0x10, 0x7c, 0x9e, 0x0a, 0xa4, 0x2a, 0x38, 0x34, 0xd9, 0x2a, 0x22, 0x2f,
+	0x01, 0xb8, 0x60, 0x1c, 0x85, 0x2a, 0x50, 0x06, 0x47, 0x63, 0x67, 0x72, 0x72, 0x39, 0x8a, 0xad,
+	0xd5, 0xb8, 0xb6, 0x1a, 0x7f, 0x32, 0x56, 0x93, 0x8d, 0x52, 0x12, 0xc1, 0x69, 0x8e, 0x1c, 0x69,
+	0x8e, 0x34, 0xd3, 0x5b, 0xfd, 0xb1, 0x37, 0xe9, 0x27, 0x8d, 0x1c, 0x09, 0xa0, 0x27, 0x50, 0x09,
+	0x2d, 0xf7, 0xc6, 0xce, 0xe4, 0x28, 0xa9, 0xc3, 0x28, 0x85, 0xff, 0x9b, 0x5f, 0x27, 0x39, 0xa3,
+	0x12, 0xc9, 0x00, 0xbc, 0x52, 0xd0, 0xea, 0xfb, 0xf4, 0xb2, 0x65, 0xd0, 0xbd, 0xb3, 0xc1, 0xe8,
+	0xa7, 0x07, 0xa3, 0x04, 0x17, 0x85, 0x54, 0x28, 0xda, 0x14, 0x6b, 0x6a, 0x4e, 0x07, 0x35, 0xb7,
+	0x93, 0x9a, 0xd7, 0xa0, 0x36, 0x04, 0x3f, 0x2b, 0xa5, 0x62, 0x2b, 0x43, 0xf3, 0x38, 0xa9, 0x22,
+	0x32, 0x05, 0x9f, 0xcd, 0xbf, 0x61, 0xa6, 0x6e, 0x23, 0x59, 0x95, 0x69, 0x42, 0x5a, 0xd2, 0x3b,
+	0x7c, 0xd3, 0xa9, 0x0e, 0xb7, 0xf8, 0xf6, 0xf6, 0xf3, 0x3d, 0x6e, 0xf0, 0x25, 0xcf, 0xe0, 0x3c,
+	0xc7, 0x25, 0x2a, 0x7c, 0x8d, 0x57, 0x4c, 0x60, 0x82, 0x7c, 0x99, 0x66, 0x18, 0xf4, 0xcd, 0x19,
+	0x5d, 0x12, 0x79, 0xac, 0x07, 0x5b, 0xa5, 0x05, 0x9d, 0xd1, 0xb7, 0x46, 0x0e, 0xc0, 0x14, 0xb7,
+	0xb2, 0xe4, 0x29, 0x0c, 0x84, 0xdd, 0x32, 0xa3, 0x6f, 0xbe, 0xa6, 0x74, 0x81, 0x32, 0x38, 0x31,
+	0xde, 0xb6, 0xf2, 0xd1, 0x2f, 0x07, 0x82, 0xed, 0x2b, 0xd8, 0x79, 0xd5, 0x76, 0xb6, 0xdd, 0xf5,
+	0x6c, 0xff, 0xa3, 0xe9, 0xdd, 0x8d, 0xe6, 0x10, 0x7c, 0xa9, 0xd2, 0xf9, 0x12, 0xeb, 0x6b, 0xb1,
+	0x91, 0xe6, 0x64, 0x57, 0x7a, 0xc2, 0xb5, 0xd5, 0x3a, 0x8c, 0x10, 0x2e, 0xda, 0x06, 0x67, 0xa5,
+	0xe2, 0xa5, 0x92, 0xf5, 0xa8, 0x6c, 0xdb, 0x7c, 0x0e, 0x3d, 0x66, 0x6b, 0x6e, 0x1b, 0xc7, 0xba,
+	0xee, 0xf2, 0x8f, 0x0b, 0xff, 0xd5, 0xfd, 0x3f, 0x30, 0x5a, 0x28, 0x26, 0xc8, 0x2b, 0xf0, 0xdf,
+	0xd3, 0x6b, 0xf6, 0x1d, 0x49, 0x10, 0xaf, 0x9f, 0x90, 0xd8, 0xa6, 0xaa, 0xc3, 0xc3, 0x7b, 0x1d,
+	0x8a, 0xc5, 0x17, 0x1d, 0x90, 0x8f, 0x70, 0xba, 0xf9, 0x0f, 0x91, 0x8b, 0x8d, 0xe2, 0x8e, 0xa7,
+	0x23, 0x7c, 0xb8, 0x53, 0x5f, 0xb7, 0xfc, 0x0c, 0x83, 0x36, 0x0e, 0x12, 0x35, 0xb6, 0x75, 0xfe,
+	0x4f, 0xe1, 0xa3, 0xbd, 0x35, 0xeb, 0xf6, 0x5f, 0x60, 0xb4, 0x83, 0x36, 0x79, 0xb2, 0xa7, 0x43,
+	0xf3, 0x46, 0xc2, 0xe1, 0x16, 0xee, 0x77, 0xfa, 0x99, 0x8d, 0x0e, 0xe6, 0xbe, 0xc9, 0xbc, 0xf8,
+	0x3b, 0x00, 0x8d, 0xc6, 0x83, 0x31, 0xa3, 0x05, 0x00, 0x00,
 }
@@ -295,7 +295,7 @@ func TestConfigSave(t *testing.T) {
 	path := filepath.Join(e.RootPath, "Pulumi.yaml")
 	err := (&workspace.Project{
 		Name:    "testing-config",
-		Runtime: "nodejs",
+		Runtime: workspace.ProjectRuntimeInfo{Name: "nodejs"},
 	}).Save(path)
 	assert.NoError(t, err)
 	e.RunCommand("pulumi", "login", "--cloud-url", e.LocalURL())